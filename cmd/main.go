@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"aicon-coding-test/internal/infrastructure/server"
 )
@@ -10,6 +11,14 @@ import (
 func main() {
 	ctx := context.Background()
 
+	// サブコマンド: migrate（スキーマの適用・ロールバック・状況確認）
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	server := server.NewServer()
 
 	if err := server.Run(ctx); err != nil {
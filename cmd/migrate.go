@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	databaseInfra "aicon-coding-test/internal/infrastructure/database"
+	"aicon-coding-test/internal/infrastructure/database/migrations"
+	"aicon-coding-test/internal/infrastructure/database/seeds"
+)
+
+const migrateUsage = `usage: main migrate <command>
+
+commands:
+  up          未適用のマイグレーションをすべて適用する
+  down [n]    適用済みのマイグレーションを新しい順に n 件ロールバックする（デフォルト: 1）
+  status      マイグレーションの適用状況を表示する
+  seed        サンプルデータを登録する`
+
+// runMigrate は migrate サブコマンドを実行する
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("command is required\n%s", migrateUsage)
+	}
+
+	dbHandler := databaseInfra.NewSqlHandler()
+	defer dbHandler.Close()
+
+	migrator, err := databaseInfra.NewMigrator(dbHandler.Conn, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("✅ Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Already up to date")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, m := range rolledBack {
+			fmt.Printf("↩️  Rolled back %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("Nothing to roll back")
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if status.AppliedAt != nil {
+				fmt.Printf("applied  %04d_%s (%s)\n", status.Version, status.Name, status.AppliedAt.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("pending  %04d_%s\n", status.Version, status.Name)
+			}
+		}
+
+	case "seed":
+		names, err := migrator.Seed(ctx, seeds.FS)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Printf("✅ Seeded %s\n", name)
+		}
+
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", args[0], migrateUsage)
+	}
+
+	return nil
+}
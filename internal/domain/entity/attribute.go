@@ -0,0 +1,247 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// AttributeType はカスタム属性の値の型
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeInteger AttributeType = "integer"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeEnum    AttributeType = "enum" // Options のいずれかの文字列
+)
+
+var attributeTypes = map[AttributeType]bool{
+	AttributeString:  true,
+	AttributeInteger: true,
+	AttributeNumber:  true,
+	AttributeBoolean: true,
+	AttributeEnum:    true,
+}
+
+// カスタム属性の上限
+const (
+	MaxAttributeDefinitions = 50  // 1カテゴリーに定義できる属性の数
+	MaxAttributeLength      = 200 // 文字列の属性の最大文字数（MaxLength 未指定時）
+)
+
+// 属性のキーは英小文字で始まる英小文字・数字・_ の50文字以内
+// JSON のパスとしてそのまま使えるよう、記号は許可しない
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// IsValidAttributeKey は属性のキーとして使える文字列かを返す
+func IsValidAttributeKey(key string) bool {
+	return attributeKeyPattern.MatchString(key)
+}
+
+// AttributeDefinition はカテゴリーで使えるカスタム属性1つ分の定義
+type AttributeDefinition struct {
+	Key       string        `json:"key"`
+	Type      AttributeType `json:"type"`
+	Required  bool          `json:"required,omitempty"`
+	Options   []string      `json:"options,omitempty"`    // enum の選択肢
+	Min       *float64      `json:"min,omitempty"`        // integer / number の最小値
+	Max       *float64      `json:"max,omitempty"`        // integer / number の最大値
+	MaxLength int           `json:"max_length,omitempty"` // string の最大文字数（0 の場合は MaxAttributeLength）
+}
+
+// AttributeSchema はカテゴリーのカスタム属性の定義（定義した順に並ぶ）
+type AttributeSchema []AttributeDefinition
+
+// Find はキーに対応する定義を返す
+func (s AttributeSchema) Find(key string) (AttributeDefinition, bool) {
+	for _, d := range s {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return AttributeDefinition{}, false
+}
+
+// Merge は s に child の定義を重ねたスキーマを返す（同じキーは child の定義で置き換える）
+// 子カテゴリーは親カテゴリーの属性を引き継ぐため、祖先から順に重ねて使う
+func (s AttributeSchema) Merge(child AttributeSchema) AttributeSchema {
+	merged := make(AttributeSchema, 0, len(s)+len(child))
+	for _, d := range s {
+		if override, ok := child.Find(d.Key); ok {
+			d = override
+		}
+		merged = append(merged, d)
+	}
+	for _, d := range child {
+		if _, ok := s.Find(d.Key); !ok {
+			merged = append(merged, d)
+		}
+	}
+	return merged
+}
+
+// validate は属性の定義として正しいかを検証する（エラーのフィールドは attribute_schema[i].<項目>）
+func (s AttributeSchema) validate(errs *domainErrors.ValidationErrors) {
+	if len(s) > MaxAttributeDefinitions {
+		errs.Add("attribute_schema", domainErrors.CodeTooMany, fmt.Sprintf("attribute_schema must have %d attributes or less", MaxAttributeDefinitions), map[string]interface{}{"max": MaxAttributeDefinitions})
+		return
+	}
+
+	seen := make(map[string]bool, len(s))
+	for i, d := range s {
+		field := fmt.Sprintf("attribute_schema[%d]", i)
+
+		switch {
+		case !attributeKeyPattern.MatchString(d.Key):
+			errs.Add(field+".key", domainErrors.CodeInvalidFormat, "key must start with a lowercase letter and contain only lowercase letters, digits and underscores", map[string]interface{}{"format": attributeKeyPattern.String()})
+		case seen[d.Key]:
+			errs.Add(field+".key", domainErrors.CodeDuplicateKey, fmt.Sprintf("key %s is defined more than once", d.Key), nil)
+		}
+		seen[d.Key] = true
+
+		if !attributeTypes[d.Type] {
+			errs.Add(field+".type", domainErrors.CodeInvalidOption, "type must be one of: string, integer, number, boolean, enum", map[string]interface{}{"allowed": []string{"string", "integer", "number", "boolean", "enum"}})
+			continue
+		}
+		if d.Type == AttributeEnum && len(d.Options) == 0 {
+			errs.Add(field+".options", domainErrors.CodeRequired, "options is required for enum attributes", nil)
+		}
+		if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+			errs.Add(field+".max", domainErrors.CodeTooSmall, "max must be greater than or equal to min", map[string]interface{}{"min": *d.Min})
+		}
+		if d.MaxLength < 0 {
+			errs.Add(field+".max_length", domainErrors.CodeTooSmall, "max_length must be 0 or greater", map[string]interface{}{"min": 0})
+		}
+	}
+}
+
+// Attributes はアイテムのカスタム属性の値（キーは AttributeDefinition.Key）
+// 値は JSON から復元した型（string / float64 / bool）で保持する
+type Attributes map[string]interface{}
+
+// String は属性を JSON（キーの昇順）で返す。CSV などへの書き出しに使う
+func (a Attributes) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(map[string]interface{}(a))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Merge は a に patch を重ねた属性を返す（patch の値が nil のキーは削除する）
+// PATCH で送られた属性だけを更新するために使う
+func (a Attributes) Merge(patch Attributes) Attributes {
+	merged := make(Attributes, len(a)+len(patch))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// normalizeAttributes は文字列の値を NormalizeText で正規化し、値が nil のキーを取り除く
+// 整数は JSON から復元した値と揃えるため float64 にする
+func normalizeAttributes(a Attributes) Attributes {
+	normalized := make(Attributes, len(a))
+	for k, v := range a {
+		switch value := v.(type) {
+		case nil:
+			continue
+		case string:
+			normalized[k] = NormalizeText(value)
+		case int:
+			normalized[k] = float64(value)
+		case int64:
+			normalized[k] = float64(value)
+		default:
+			normalized[k] = v
+		}
+	}
+	return normalized
+}
+
+// validateAttributes はアイテムの属性をカテゴリーのスキーマで検証する（エラーのフィールドは attributes.<key>）
+func validateAttributes(errs *domainErrors.ValidationErrors, schema AttributeSchema, attributes Attributes) {
+	for key := range attributes {
+		if _, ok := schema.Find(key); !ok {
+			errs.Add("attributes."+key, domainErrors.CodeUnknownAttribute, fmt.Sprintf("attribute %s is not defined for the category", key), nil)
+		}
+	}
+
+	for _, d := range schema {
+		field := "attributes." + d.Key
+		value, ok := attributes[d.Key]
+		if !ok {
+			if d.Required {
+				errs.Add(field, domainErrors.CodeRequired, field+" is required", nil)
+			}
+			continue
+		}
+
+		switch d.Type {
+		case AttributeString, AttributeEnum:
+			s, ok := value.(string)
+			if !ok {
+				errs.Add(field, domainErrors.CodeInvalidType, field+" must be a string", map[string]interface{}{"type": string(d.Type)})
+				continue
+			}
+			if d.Type == AttributeEnum {
+				if !containsString(d.Options, s) {
+					errs.Add(field, domainErrors.CodeInvalidOption, fmt.Sprintf("%s must be one of the defined options", field), map[string]interface{}{"allowed": d.Options})
+				}
+				continue
+			}
+			maxLength := d.MaxLength
+			if maxLength == 0 {
+				maxLength = MaxAttributeLength
+			}
+			if s == "" {
+				errs.Add(field, domainErrors.CodeRequired, field+" must not be empty", nil)
+			} else if hasInvalidCharacters(s) {
+				errs.Add(field, domainErrors.CodeInvalidCharacters, field+" must not contain control characters", nil)
+			} else if TextLength(s) > maxLength {
+				errs.Add(field, domainErrors.CodeTooLong, fmt.Sprintf("%s must be %d characters or less", field, maxLength), map[string]interface{}{"max": maxLength})
+			}
+
+		case AttributeInteger, AttributeNumber:
+			n, ok := value.(float64)
+			if !ok || (d.Type == AttributeInteger && n != math.Trunc(n)) {
+				errs.Add(field, domainErrors.CodeInvalidType, fmt.Sprintf("%s must be %s", field, map[AttributeType]string{AttributeInteger: "an integer", AttributeNumber: "a number"}[d.Type]), map[string]interface{}{"type": string(d.Type)})
+				continue
+			}
+			if d.Min != nil && n < *d.Min {
+				errs.Add(field, domainErrors.CodeTooSmall, fmt.Sprintf("%s must be %v or greater", field, *d.Min), map[string]interface{}{"min": *d.Min})
+			} else if d.Max != nil && n > *d.Max {
+				errs.Add(field, domainErrors.CodeTooLarge, fmt.Sprintf("%s must be %v or less", field, *d.Max), map[string]interface{}{"max": *d.Max})
+			}
+
+		case AttributeBoolean:
+			if _, ok := value.(bool); !ok {
+				errs.Add(field, domainErrors.CodeInvalidType, field+" must be a boolean", map[string]interface{}{"type": string(d.Type)})
+			}
+		}
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+// schemaCategoryChecker はカテゴリーごとにスキーマを返すテスト用の CategoryChecker
+type schemaCategoryChecker map[string]AttributeSchema
+
+func (s schemaCategoryChecker) IsAssignable(code string) bool {
+	_, ok := s[code]
+	return ok
+}
+
+func (s schemaCategoryChecker) AssignableCodes() []string {
+	codes := make([]string, 0, len(s))
+	for code := range s {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func (s schemaCategoryChecker) EffectiveSchema(code string) AttributeSchema {
+	return s[code]
+}
+
+func TestNewItem_Attributes(t *testing.T) {
+	categories := schemaCategoryChecker{
+		"時計": {
+			{Key: "reference_number", Type: AttributeString, Required: true, MaxLength: 10},
+			{Key: "movement", Type: AttributeEnum, Options: []string{"automatic", "quartz"}},
+			{Key: "case_size_mm", Type: AttributeNumber, Min: floatPtr(10), Max: floatPtr(70)},
+			{Key: "jewels", Type: AttributeInteger},
+			{Key: "box", Type: AttributeBoolean},
+		},
+		"その他": nil,
+	}
+
+	tests := []struct {
+		name       string
+		category   string
+		attributes Attributes
+		wantField  string
+		wantCode   string
+	}{
+		{
+			name:       "正常系: すべての型の属性",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "movement": "automatic", "case_size_mm": 40.5, "jewels": float64(44), "box": true},
+		},
+		{
+			name:       "正常系: 任意の属性は省略できる",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN"},
+		},
+		{
+			name:       "正常系: 値が null の属性は取り除く",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "movement": nil},
+		},
+		{
+			name:      "異常系: 必須の属性がない",
+			category:  "時計",
+			wantField: "attributes.reference_number",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:       "異常系: 文字列が長すぎる",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "12345678901"},
+			wantField:  "attributes.reference_number",
+			wantCode:   domainErrors.CodeTooLong,
+		},
+		{
+			name:       "異常系: 選択肢にない値",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "movement": "solar"},
+			wantField:  "attributes.movement",
+			wantCode:   domainErrors.CodeInvalidOption,
+		},
+		{
+			name:       "異常系: 範囲の最小値を下回る",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "case_size_mm": float64(5)},
+			wantField:  "attributes.case_size_mm",
+			wantCode:   domainErrors.CodeTooSmall,
+		},
+		{
+			name:       "異常系: 範囲の最大値を上回る",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "case_size_mm": float64(71)},
+			wantField:  "attributes.case_size_mm",
+			wantCode:   domainErrors.CodeTooLarge,
+		},
+		{
+			name:       "異常系: 整数の属性に小数",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "jewels": 31.5},
+			wantField:  "attributes.jewels",
+			wantCode:   domainErrors.CodeInvalidType,
+		},
+		{
+			name:       "異常系: 真偽値の属性に文字列",
+			category:   "時計",
+			attributes: Attributes{"reference_number": "116500LN", "box": "yes"},
+			wantField:  "attributes.box",
+			wantCode:   domainErrors.CodeInvalidType,
+		},
+		{
+			name:       "異常系: カテゴリーに定義されていない属性",
+			category:   "その他",
+			attributes: Attributes{"color": "black"},
+			wantField:  "attributes.color",
+			wantCode:   domainErrors.CodeUnknownAttribute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem("アイテム", tt.category, "Brand", 1000, "2023-01-01", tt.attributes, categories)
+
+			if tt.wantCode != "" {
+				verrs, ok := domainErrors.AsValidationErrors(err)
+				require.True(t, ok)
+				require.Len(t, verrs, 1)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				return
+			}
+
+			require.NoError(t, err)
+			for key, value := range tt.attributes {
+				if value == nil {
+					assert.NotContains(t, item.Attributes, key, "値が null の属性は保存しない")
+				} else {
+					assert.Equal(t, value, item.Attributes[key])
+				}
+			}
+		})
+	}
+}
+
+func TestNewCategory_AttributeSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    AttributeSchema
+		wantField string
+		wantCode  string
+	}{
+		{
+			name:   "正常系: 有効な定義",
+			schema: AttributeSchema{{Key: "size", Type: AttributeEnum, Options: []string{"S", "M"}}, {Key: "weight_g", Type: AttributeNumber, Min: floatPtr(0)}},
+		},
+		{
+			name:      "異常系: キーの形式が不正",
+			schema:    AttributeSchema{{Key: "Size", Type: AttributeString}},
+			wantField: "attribute_schema[0].key",
+			wantCode:  domainErrors.CodeInvalidFormat,
+		},
+		{
+			name:      "異常系: キーが重複",
+			schema:    AttributeSchema{{Key: "size", Type: AttributeString}, {Key: "size", Type: AttributeNumber}},
+			wantField: "attribute_schema[1].key",
+			wantCode:  domainErrors.CodeDuplicateKey,
+		},
+		{
+			name:      "異常系: 未定義の型",
+			schema:    AttributeSchema{{Key: "size", Type: "date"}},
+			wantField: "attribute_schema[0].type",
+			wantCode:  domainErrors.CodeInvalidOption,
+		},
+		{
+			name:      "異常系: enum に選択肢がない",
+			schema:    AttributeSchema{{Key: "size", Type: AttributeEnum}},
+			wantField: "attribute_schema[0].options",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:      "異常系: 最小値が最大値より大きい",
+			schema:    AttributeSchema{{Key: "size", Type: AttributeNumber, Min: floatPtr(10), Max: floatPtr(1)}},
+			wantField: "attribute_schema[0].max",
+			wantCode:  domainErrors.CodeTooSmall,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory("アパレル", "アパレル", "Apparel", 60, tt.schema)
+
+			if tt.wantCode != "" {
+				verrs, ok := domainErrors.AsValidationErrors(err)
+				require.True(t, ok)
+				require.Len(t, verrs, 1)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.schema, category.AttributeSchema)
+		})
+	}
+}
+
+func TestCategoryTree_EffectiveSchema(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	tree := NewCategoryTree([]*Category{
+		{ID: 1, Code: "バッグ", AttributeSchema: AttributeSchema{
+			{Key: "color", Type: AttributeString},
+			{Key: "size", Type: AttributeEnum, Options: []string{"S", "M", "L"}},
+		}},
+		{ID: 2, Code: "クラッチ", ParentID: id(1), AttributeSchema: AttributeSchema{
+			{Key: "size", Type: AttributeEnum, Options: []string{"mini", "regular"}},
+			{Key: "has_strap", Type: AttributeBoolean},
+		}},
+	})
+
+	schema := tree.EffectiveSchema("クラッチ")
+	assert.Equal(t, AttributeSchema{
+		{Key: "color", Type: AttributeString},
+		{Key: "size", Type: AttributeEnum, Options: []string{"mini", "regular"}},
+		{Key: "has_strap", Type: AttributeBoolean},
+	}, schema, "親の定義を引き継ぎ、同じキーは子の定義が優先される")
+
+	assert.Len(t, tree.EffectiveSchema("バッグ"), 2)
+	assert.Nil(t, tree.EffectiveSchema("家具"))
+}
+
+func TestAttributes_Merge(t *testing.T) {
+	current := Attributes{"color": "black", "size": "M"}
+
+	merged := current.Merge(Attributes{"size": "L", "color": nil, "has_strap": true})
+
+	assert.Equal(t, Attributes{"size": "L", "has_strap": true}, merged)
+	assert.Equal(t, Attributes{"color": "black", "size": "M"}, current, "元の属性は変更しない")
+	assert.Equal(t, `{"has_strap":true,"size":"L"}`, merged.String())
+	assert.Equal(t, "", Attributes{}.String())
+}
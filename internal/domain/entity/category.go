@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// Category はアイテムのカテゴリー
+// Code はアイテムの category に保存する値で、登録後は変更できない
+// ParentID が nil のカテゴリーは最上位になる。アイテムは子カテゴリーのない末端のカテゴリーにのみ設定できる
+// AttributeSchema はこのカテゴリーで使えるカスタム属性で、子カテゴリーに引き継がれる（CategoryTree.EffectiveSchema）
+type Category struct {
+	ID              int64           `json:"id"`
+	Code            string          `json:"code"`
+	ParentID        *int64          `json:"parent_id"`
+	NameJa          string          `json:"name_ja"`
+	NameEn          string          `json:"name_en"`
+	SortOrder       int             `json:"sort_order"`
+	Active          bool            `json:"active"`
+	AttributeSchema AttributeSchema `json:"attribute_schema"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// カテゴリーのフィールドの最大文字数
+const (
+	MaxCategoryCodeLength = 50
+	MaxCategoryNameLength = 100
+)
+
+// NewCategory は入力を正規化してからバリデーションし、有効なカテゴリーを作成する
+func NewCategory(code, nameJa, nameEn string, sortOrder int, schema AttributeSchema) (*Category, error) {
+	if schema == nil {
+		schema = AttributeSchema{}
+	}
+	category := &Category{
+		Code:            NormalizeText(code),
+		NameJa:          NormalizeText(nameJa),
+		NameEn:          NormalizeText(nameEn),
+		SortOrder:       sortOrder,
+		Active:          true,
+		AttributeSchema: schema,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// Update は表示名・並び順・有効フラグ・属性のスキーマを更新する（コードは変更できない）
+// スキーマを変更しても登録済みのアイテムの属性は検証し直さない（次にアイテムを更新したときに検証される）
+func (c *Category) Update(nameJa, nameEn string, sortOrder int, active bool, schema AttributeSchema) error {
+	if schema == nil {
+		schema = AttributeSchema{}
+	}
+	c.NameJa = NormalizeText(nameJa)
+	c.NameEn = NormalizeText(nameEn)
+	c.SortOrder = sortOrder
+	c.Active = active
+	c.AttributeSchema = schema
+	c.UpdatedAt = time.Now()
+
+	return c.Validate()
+}
+
+// Validate はカテゴリーのフィールドを検証する
+func (c *Category) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if c.Code == "" {
+		errs.Add("code", domainErrors.CodeRequired, "code is required", nil)
+	} else if hasInvalidCharacters(c.Code) {
+		errs.Add("code", domainErrors.CodeInvalidCharacters, "code must not contain control characters", nil)
+	} else if TextLength(c.Code) > MaxCategoryCodeLength {
+		errs.Add("code", domainErrors.CodeTooLong, "code must be 50 characters or less", map[string]interface{}{"max": MaxCategoryCodeLength})
+	}
+
+	validateCategoryName(&errs, "name_ja", c.NameJa)
+	validateCategoryName(&errs, "name_en", c.NameEn)
+
+	if c.ParentID != nil && c.ID != 0 && *c.ParentID == c.ID {
+		errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id must not be the category itself", nil)
+	}
+
+	if c.SortOrder < 0 {
+		errs.Add("sort_order", domainErrors.CodeTooSmall, "sort_order must be 0 or greater", map[string]interface{}{"min": 0})
+	}
+
+	c.AttributeSchema.validate(&errs)
+
+	return errs.Err()
+}
+
+func validateCategoryName(errs *domainErrors.ValidationErrors, field, name string) {
+	if name == "" {
+		errs.Add(field, domainErrors.CodeRequired, field+" is required", nil)
+	} else if hasInvalidCharacters(name) {
+		errs.Add(field, domainErrors.CodeInvalidCharacters, field+" must not contain control characters", nil)
+	} else if TextLength(name) > MaxCategoryNameLength {
+		errs.Add(field, domainErrors.CodeTooLong, field+" must be 100 characters or less", map[string]interface{}{"max": MaxCategoryNameLength})
+	}
+}
+
+// CategoryChecker はアイテムに設定できるカテゴリーを判定する
+// 通常は categories テーブルから作成した *CategoryTree を、ユースケースがリクエストごとに取得してアイテムのバリデーションに渡す
+type CategoryChecker interface {
+	// IsAssignable はアイテムに設定できるカテゴリー（有効な末端のカテゴリー）かを返す
+	IsAssignable(code string) bool
+	// AssignableCodes はアイテムに設定できるカテゴリーのコードを並び順に返す
+	AssignableCodes() []string
+	// EffectiveSchema はカテゴリーのアイテムに設定できるカスタム属性（祖先の定義を含む）を返す
+	EffectiveSchema(code string) AttributeSchema
+}
+
+// FixedCategories は ValidCategories（テーブル導入前の固定のカテゴリー）で判定する CategoryChecker
+// カテゴリーのテーブルを使わないテストなどで使う
+var FixedCategories CategoryChecker = fixedCategories{}
+
+type fixedCategories struct{}
+
+func (fixedCategories) IsAssignable(code string) bool {
+	for _, valid := range ValidCategories {
+		if code == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (fixedCategories) AssignableCodes() []string {
+	return ValidCategories
+}
+
+// 固定のカテゴリーにはカスタム属性がない
+func (fixedCategories) EffectiveSchema(code string) AttributeSchema {
+	return nil
+}
+
+// categoryListMessage は有効なカテゴリーの一覧を含むエラーメッセージを返す
+func categoryListMessage(codes []string) string {
+	return "category must be one of: " + strings.Join(codes, ", ")
+}
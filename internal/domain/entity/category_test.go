@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		nameJa    string
+		nameEn    string
+		sortOrder int
+		wantField string
+		wantCode  string
+	}{
+		{
+			name:      "正常系: 有効なカテゴリー",
+			code:      "アパレル",
+			nameJa:    "アパレル",
+			nameEn:    "Apparel",
+			sortOrder: 60,
+		},
+		{
+			name:      "正常系: 前後の空白を取り除く",
+			code:      "  アパレル ",
+			nameJa:    "アパレル",
+			nameEn:    " Apparel ",
+			sortOrder: 0,
+		},
+		{
+			name:      "異常系: コードが未入力",
+			code:      "   ",
+			nameJa:    "アパレル",
+			nameEn:    "Apparel",
+			wantField: "code",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:      "異常系: コードが50文字超過",
+			code:      strings.Repeat("あ", 51),
+			nameJa:    "アパレル",
+			nameEn:    "Apparel",
+			wantField: "code",
+			wantCode:  domainErrors.CodeTooLong,
+		},
+		{
+			name:      "異常系: 英語名が未入力",
+			code:      "アパレル",
+			nameJa:    "アパレル",
+			wantField: "name_en",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:      "異常系: 並び順が負の値",
+			code:      "アパレル",
+			nameJa:    "アパレル",
+			nameEn:    "Apparel",
+			sortOrder: -1,
+			wantField: "sort_order",
+			wantCode:  domainErrors.CodeTooSmall,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory(tt.code, tt.nameJa, tt.nameEn, tt.sortOrder, nil)
+
+			if tt.wantField != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				verrs, ok := domainErrors.AsValidationErrors(err)
+				require.True(t, ok)
+				require.Len(t, verrs, 1)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "アパレル", category.Code)
+			assert.Equal(t, "Apparel", category.NameEn)
+			assert.True(t, category.Active)
+		})
+	}
+}
+
+type stubCategoryChecker []string
+
+func (s stubCategoryChecker) IsAssignable(code string) bool {
+	for _, c := range s {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (s stubCategoryChecker) AssignableCodes() []string {
+	return s
+}
+
+func (s stubCategoryChecker) EffectiveSchema(code string) AttributeSchema {
+	return nil
+}
+
+func TestNewItem_CategoryChecker(t *testing.T) {
+	categories := stubCategoryChecker{"時計", "アパレル"}
+
+	_, err := NewItem("シャツ", "アパレル", "Brand", 1000, "2023-01-01", nil, categories)
+	assert.NoError(t, err)
+
+	_, err = NewItem("バッグ", "バッグ", "Brand", 1000, "2023-01-01", nil, categories)
+	verrs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeInvalidCategory, verrs[0].Code)
+	assert.Contains(t, verrs[0].Message, "時計, アパレル")
+}
+
+func TestCategoryTree(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	// バッグ(1) ─┬ トート(2)
+	//            └ クラッチ(3) ─ ミニクラッチ(4)
+	// 時計(5)（無効）─ 腕時計(6)
+	tree := NewCategoryTree([]*Category{
+		{ID: 1, Code: "バッグ", Active: true},
+		{ID: 2, Code: "トート", ParentID: id(1), Active: true},
+		{ID: 3, Code: "クラッチ", ParentID: id(1), Active: true},
+		{ID: 4, Code: "ミニクラッチ", ParentID: id(3), Active: true},
+		{ID: 5, Code: "時計", Active: false},
+		{ID: 6, Code: "腕時計", ParentID: id(5), Active: true},
+	})
+
+	codes := func(categories []*Category) []string {
+		var result []string
+		for _, c := range categories {
+			result = append(result, c.Code)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"バッグ", "時計"}, codes(tree.Roots()))
+	assert.Equal(t, []string{"トート", "クラッチ", "ミニクラッチ"}, codes(tree.Descendants(1)))
+	assert.True(t, tree.IsDescendant(1, 4))
+	assert.False(t, tree.IsDescendant(4, 1))
+	assert.Equal(t, []string{"バッグ", "クラッチ", "ミニクラッチ"}, tree.Path("ミニクラッチ"))
+	assert.Nil(t, tree.Path("家具"))
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "正常系: 末端のカテゴリー", code: "トート", want: true},
+		{name: "正常系: 孫のカテゴリー", code: "ミニクラッチ", want: true},
+		{name: "異常系: 子カテゴリーがある", code: "バッグ", want: false},
+		{name: "異常系: 親が無効", code: "腕時計", want: false},
+		{name: "異常系: 未登録", code: "家具", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tree.IsAssignable(tt.code))
+		})
+	}
+
+	assert.Equal(t, []string{"トート", "ミニクラッチ"}, tree.AssignableCodes())
+}
@@ -0,0 +1,164 @@
+package entity
+
+// CategoryTree はカテゴリーの親子関係をたどるためのビュー
+// 子カテゴリーは渡された順（通常は sort_order, id の昇順）に並ぶ
+type CategoryTree struct {
+	categories []*Category
+	byID       map[int64]*Category
+	byCode     map[string]*Category
+	children   map[int64][]*Category // キー 0 は最上位のカテゴリー
+}
+
+// NewCategoryTree はカテゴリーの一覧から親子関係を組み立てる
+// 親が一覧にないカテゴリーは最上位として扱う
+func NewCategoryTree(categories []*Category) *CategoryTree {
+	t := &CategoryTree{
+		categories: categories,
+		byID:       make(map[int64]*Category, len(categories)),
+		byCode:     make(map[string]*Category, len(categories)),
+		children:   make(map[int64][]*Category),
+	}
+	for _, c := range categories {
+		t.byID[c.ID] = c
+		t.byCode[c.Code] = c
+	}
+	for _, c := range categories {
+		parent := int64(0)
+		if c.ParentID != nil {
+			if _, ok := t.byID[*c.ParentID]; ok {
+				parent = *c.ParentID
+			}
+		}
+		t.children[parent] = append(t.children[parent], c)
+	}
+	return t
+}
+
+// All はすべてのカテゴリーを渡された順に返す
+func (t *CategoryTree) All() []*Category {
+	return t.categories
+}
+
+// Roots は最上位のカテゴリーを返す
+func (t *CategoryTree) Roots() []*Category {
+	return t.children[0]
+}
+
+// Children は直下の子カテゴリーを返す
+func (t *CategoryTree) Children(id int64) []*Category {
+	return t.children[id]
+}
+
+// FindByID はIDに対応するカテゴリーを返す
+func (t *CategoryTree) FindByID(id int64) (*Category, bool) {
+	c, ok := t.byID[id]
+	return c, ok
+}
+
+// FindByCode はコードに対応するカテゴリーを返す
+func (t *CategoryTree) FindByCode(code string) (*Category, bool) {
+	c, ok := t.byCode[code]
+	return c, ok
+}
+
+// IsLeaf は子カテゴリーがないかを返す
+func (t *CategoryTree) IsLeaf(id int64) bool {
+	return len(t.children[id]) == 0
+}
+
+// Descendants は子孫のカテゴリーを深さ優先の順に返す（自身は含まない）
+func (t *CategoryTree) Descendants(id int64) []*Category {
+	var descendants []*Category
+	for _, child := range t.children[id] {
+		descendants = append(descendants, child)
+		descendants = append(descendants, t.Descendants(child.ID)...)
+	}
+	return descendants
+}
+
+// IsDescendant は candidate が id の子孫かを返す
+func (t *CategoryTree) IsDescendant(id, candidate int64) bool {
+	for _, d := range t.Descendants(id) {
+		if d.ID == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Path は最上位からカテゴリーまでのコードを返す（例: [バッグ トート]）
+// 存在しないカテゴリーの場合は nil を返す
+func (t *CategoryTree) Path(code string) []string {
+	var path []string
+	for c, ok := t.byCode[code]; ok; {
+		path = append([]string{c.Code}, path...)
+		if c.ParentID == nil {
+			break
+		}
+		c, ok = t.byID[*c.ParentID]
+	}
+	return path
+}
+
+// IsActive はカテゴリーと祖先のカテゴリーがすべて有効かを返す
+// 親を無効にすると、子カテゴリーもアイテムに設定できなくなる
+func (t *CategoryTree) IsActive(id int64) bool {
+	for c, ok := t.byID[id]; ok; {
+		if !c.Active {
+			return false
+		}
+		if c.ParentID == nil {
+			return true
+		}
+		c, ok = t.byID[*c.ParentID]
+	}
+	return false
+}
+
+// IsAssignable はアイテムに設定できるカテゴリー（有効な末端のカテゴリー）かを返す
+func (t *CategoryTree) IsAssignable(code string) bool {
+	c, ok := t.byCode[code]
+	return ok && t.IsLeaf(c.ID) && t.IsActive(c.ID)
+}
+
+// AssignableCodes はアイテムに設定できるカテゴリーのコードを木の順に返す
+func (t *CategoryTree) AssignableCodes() []string {
+	var codes []string
+	var walk func(parent int64)
+	walk = func(parent int64) {
+		for _, c := range t.children[parent] {
+			if !c.Active {
+				continue
+			}
+			if t.IsLeaf(c.ID) {
+				codes = append(codes, c.Code)
+			}
+			walk(c.ID)
+		}
+	}
+	walk(0)
+	return codes
+}
+
+// EffectiveSchema はカテゴリーのアイテムに設定できるカスタム属性を返す
+// 最上位の祖先から順にスキーマを重ねるため、同じキーは子カテゴリーの定義が優先される
+func (t *CategoryTree) EffectiveSchema(code string) AttributeSchema {
+	c, ok := t.byCode[code]
+	if !ok {
+		return nil
+	}
+	var lineage []*Category
+	for ok {
+		lineage = append(lineage, c)
+		if c.ParentID == nil {
+			break
+		}
+		c, ok = t.byID[*c.ParentID]
+	}
+
+	var schema AttributeSchema
+	for i := len(lineage) - 1; i >= 0; i-- {
+		schema = schema.Merge(lineage[i].AttributeSchema)
+	}
+	return schema
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// Disposal はアイテムの売却の記録
+// Cost と GainLoss は保存せず、読み込み時にアイテムの現在の購入価格から求める
+type Disposal struct {
+	ID        int64     `json:"id"`
+	ItemID    int64     `json:"item_id"`
+	SoldOn    string    `json:"sold_on"`    // 売却日（YYYY-MM-DD 形式）
+	SalePrice int       `json:"sale_price"` // 売却価格（円）
+	Fees      int       `json:"fees"`       // 販売手数料・送料などの売却にかかった費用（円）
+	Channel   string    `json:"channel"`    // 売却先（例: メルカリ、買取店）
+	Note      string    `json:"note"`
+	Actor     string    `json:"actor"`
+	Cost      int       `json:"cost"`      // 取得費（アイテムの購入価格）
+	GainLoss  int       `json:"gain_loss"` // 売却損益（売却価格 - 費用 - 取得費）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaxSaleChannelLength は売却先の最大文字数
+const MaxSaleChannelLength = 100
+
+// MaxSaleNoteLength は売却のメモの最大文字数
+const MaxSaleNoteLength = 500
+
+// NewDisposal はアイテムを売却した記録を作成する
+// 売却に遷移できない所有状況の場合は status の invalid_transition エラーを返す。所有状況の変更は Sell で行う
+func NewDisposal(item *Item, soldOn string, salePrice, fees int, channel, note string) (*Disposal, error) {
+	var errs domainErrors.ValidationErrors
+
+	if !item.Status.CanTransitionTo(StatusSold) {
+		errs.Add("status", domainErrors.CodeInvalidTransition, "status cannot change from "+string(item.Status)+" to sold",
+			map[string]interface{}{"from": string(item.Status), "allowed": nextStatuses(item.Status, false)})
+	}
+
+	soldOn = NormalizeText(soldOn)
+	if soldOn == "" {
+		errs.Add("sold_on", domainErrors.CodeRequired, "sold_on is required", nil)
+	} else if !isValidDateFormat(soldOn) {
+		errs.Add("sold_on", domainErrors.CodeInvalidFormat, "sold_on must be in YYYY-MM-DD format", map[string]interface{}{"format": "YYYY-MM-DD"})
+	} else if today := time.Now().Format("2006-01-02"); soldOn > today {
+		errs.Add("sold_on", domainErrors.CodeTooLarge, "sold_on must not be in the future", map[string]interface{}{"max": today})
+	} else if soldOn < item.PurchaseDate {
+		errs.Add("sold_on", domainErrors.CodeTooSmall, "sold_on must be on or after purchase_date", map[string]interface{}{"min": item.PurchaseDate})
+	}
+
+	// 無償で手放した場合は売却ではなく disposed に変更する
+	if salePrice < 1 {
+		errs.Add("sale_price", domainErrors.CodeTooSmall, "sale_price must be 1 or greater", map[string]interface{}{"min": 1})
+	}
+	if fees < 0 {
+		errs.Add("fees", domainErrors.CodeTooSmall, "fees must be 0 or greater", map[string]interface{}{"min": 0})
+	}
+
+	channel = NormalizeText(channel)
+	if channel == "" {
+		errs.Add("channel", domainErrors.CodeRequired, "channel is required", nil)
+	} else if hasInvalidCharacters(channel) {
+		errs.Add("channel", domainErrors.CodeInvalidCharacters, "channel must not contain control characters", nil)
+	} else if TextLength(channel) > MaxSaleChannelLength {
+		errs.Add("channel", domainErrors.CodeTooLong, "channel must be 100 characters or less", map[string]interface{}{"max": MaxSaleChannelLength})
+	}
+
+	note = NormalizeText(note)
+	if hasInvalidCharacters(note) {
+		errs.Add("note", domainErrors.CodeInvalidCharacters, "note must not contain control characters", nil)
+	} else if TextLength(note) > MaxSaleNoteLength {
+		errs.Add("note", domainErrors.CodeTooLong, "note must be 500 characters or less", map[string]interface{}{"max": MaxSaleNoteLength})
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	disposal := &Disposal{
+		ItemID:    item.ID,
+		SoldOn:    soldOn,
+		SalePrice: salePrice,
+		Fees:      fees,
+		Channel:   channel,
+		Note:      note,
+	}
+	disposal.SetCost(item.PurchasePrice)
+	return disposal, nil
+}
+
+// SetCost は取得費を設定し、売却損益を計算する
+func (d *Disposal) SetCost(cost int) {
+	d.Cost = cost
+	d.GainLoss = d.SalePrice - d.Fees - cost
+}
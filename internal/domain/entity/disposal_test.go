@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestNewDisposal(t *testing.T) {
+	newItem := func(status ItemStatus) *Item {
+		item, err := NewItem("バッグ1", "バッグ", "HERMES", 1000000, "2023-01-01", nil, FixedCategories)
+		require.NoError(t, err)
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+
+	t.Run("正常系: 売却損益を計算する", func(t *testing.T) {
+		disposal, err := NewDisposal(newItem(StatusOwned), "2024-05-01", 1300000, 130000, " メルカリ ", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), disposal.ItemID)
+		assert.Equal(t, "メルカリ", disposal.Channel)
+		assert.Equal(t, 1000000, disposal.Cost)
+		assert.Equal(t, 170000, disposal.GainLoss)
+	})
+
+	t.Run("正常系: 売却損", func(t *testing.T) {
+		disposal, err := NewDisposal(newItem(StatusConsigned), "2024-05-01", 800000, 80000, "買取店", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, -280000, disposal.GainLoss)
+	})
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	errorTests := []struct {
+		name      string
+		status    ItemStatus
+		soldOn    string
+		salePrice int
+		fees      int
+		channel   string
+		note      string
+		wantField string
+		wantCode  string
+	}{
+		{name: "異常系: 売却済み", status: StatusSold, soldOn: "2024-05-01", salePrice: 1, channel: "買取店", wantField: "status", wantCode: domainErrors.CodeInvalidTransition},
+		{name: "異常系: 売却日がない", status: StatusOwned, soldOn: "", salePrice: 1, channel: "買取店", wantField: "sold_on", wantCode: domainErrors.CodeRequired},
+		{name: "異常系: 売却日の形式が不正", status: StatusOwned, soldOn: "2024/05/01", salePrice: 1, channel: "買取店", wantField: "sold_on", wantCode: domainErrors.CodeInvalidFormat},
+		{name: "異常系: 未来の売却日", status: StatusOwned, soldOn: tomorrow, salePrice: 1, channel: "買取店", wantField: "sold_on", wantCode: domainErrors.CodeTooLarge},
+		{name: "異常系: 購入日より前の売却日", status: StatusOwned, soldOn: "2022-12-31", salePrice: 1, channel: "買取店", wantField: "sold_on", wantCode: domainErrors.CodeTooSmall},
+		{name: "異常系: 売却価格が0", status: StatusOwned, soldOn: "2024-05-01", salePrice: 0, channel: "買取店", wantField: "sale_price", wantCode: domainErrors.CodeTooSmall},
+		{name: "異常系: 費用が負の値", status: StatusOwned, soldOn: "2024-05-01", salePrice: 1, fees: -1, channel: "買取店", wantField: "fees", wantCode: domainErrors.CodeTooSmall},
+		{name: "異常系: 売却先がない", status: StatusOwned, soldOn: "2024-05-01", salePrice: 1, channel: " ", wantField: "channel", wantCode: domainErrors.CodeRequired},
+		{name: "異常系: メモが長すぎる", status: StatusOwned, soldOn: "2024-05-01", salePrice: 1, channel: "買取店", note: strings.Repeat("あ", MaxSaleNoteLength+1), wantField: "note", wantCode: domainErrors.CodeTooLong},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDisposal(newItem(tt.status), tt.soldOn, tt.salePrice, tt.fees, tt.channel, tt.note)
+
+			var errs domainErrors.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Equal(t, tt.wantCode, errs[0].Code)
+		})
+	}
+}
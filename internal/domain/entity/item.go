@@ -1,102 +1,115 @@
 package entity
 
 import (
-	"errors"
-	"strings"
 	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Category      string     `json:"category"`
+	Brand         string     `json:"brand"`
+	PurchasePrice int        `json:"purchase_price"`
+	PurchaseDate  string     `json:"purchase_date"` // YYYY-MM-DD 形式
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Version       int        `json:"version"`              // 楽観的排他制御用（更新のたびに増える）
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"` // ゴミ箱に移動した日時（削除されていない場合は nil）
+	Attributes    Attributes `json:"attributes"`           // カテゴリーごとのカスタム属性（カテゴリーの AttributeSchema で検証する）
+	Tags          []string   `json:"tags"`                 // タグ名（名前順）。SetTags で設定する
+	LocationID    *int64     `json:"location_id"`          // 現在の保管場所（未設定の場合は nil）。MoveTo で変更する
+	Status        ItemStatus `json:"status"`               // 所有状況（登録時は owned）。ChangeStatus で変更する
 }
 
-// カテゴリー定義
+// ValidCategories は初期のカテゴリーのコード
+// カテゴリーは categories テーブルで管理し、この値はテーブルを使わない場合（FixedCategories）のみ使う
 var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+// NewItem は入力を正規化（NormalizeText）してからバリデーションし、アイテムを作成する
+// カテゴリーと属性は categories で検証する
+func NewItem(name, category, brand string, purchasePrice int, purchaseDate string, attributes Attributes, categories CategoryChecker) (*Item, error) {
 	item := &Item{
-		Name:          strings.TrimSpace(name),
-		Category:      strings.TrimSpace(category),
-		Brand:         strings.TrimSpace(brand),
+		Name:          NormalizeText(name),
+		Category:      NormalizeText(category),
+		Brand:         NormalizeText(brand),
 		PurchasePrice: purchasePrice,
-		PurchaseDate:  strings.TrimSpace(purchaseDate),
+		PurchaseDate:  NormalizeText(purchaseDate),
+		Attributes:    normalizeAttributes(attributes),
+		Tags:          []string{},
+		Status:        StatusOwned,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
 
-	if err := item.Validate(); err != nil {
+	if err := item.Validate(categories); err != nil {
 		return nil, err
 	}
 
 	return item, nil
 }
 
+// MaxNameLength は name / brand の最大文字数（バイト数ではなく文字数）
+const MaxNameLength = 100
+
 // アイテムフィールドのバリデーション
-func (i *Item) Validate() error {
-	var errs []string
+// エラーがある場合はフィールドごとのエラーを domainErrors.ValidationErrors で返す
+// カテゴリーは categories に設定できるもののみ受け付け、属性はそのカテゴリーのスキーマで検証する
+func (i *Item) Validate(categories CategoryChecker) error {
+	var errs domainErrors.ValidationErrors
 
 	if i.Name == "" {
-		errs = append(errs, "name is required")
-	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+		errs.Add("name", domainErrors.CodeRequired, "name is required", nil)
+	} else if hasInvalidCharacters(i.Name) {
+		errs.Add("name", domainErrors.CodeInvalidCharacters, "name must not contain control characters", nil)
+	} else if TextLength(i.Name) > MaxNameLength {
+		errs.Add("name", domainErrors.CodeTooLong, "name must be 100 characters or less", map[string]interface{}{"max": MaxNameLength})
 	}
 
 	if i.Category == "" {
-		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		errs.Add("category", domainErrors.CodeRequired, "category is required", nil)
+	} else if !categories.IsAssignable(i.Category) {
+		allowed := categories.AssignableCodes()
+		errs.Add("category", domainErrors.CodeInvalidCategory, categoryListMessage(allowed), map[string]interface{}{"allowed": allowed})
+	} else {
+		// カテゴリーが確定している場合のみ、そのカテゴリーのスキーマで属性を検証する
+		validateAttributes(&errs, categories.EffectiveSchema(i.Category), i.Attributes)
 	}
 
 	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+		errs.Add("brand", domainErrors.CodeRequired, "brand is required", nil)
+	} else if hasInvalidCharacters(i.Brand) {
+		errs.Add("brand", domainErrors.CodeInvalidCharacters, "brand must not contain control characters", nil)
+	} else if TextLength(i.Brand) > MaxNameLength {
+		errs.Add("brand", domainErrors.CodeTooLong, "brand must be 100 characters or less", map[string]interface{}{"max": MaxNameLength})
 	}
 
 	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+		errs.Add("purchase_price", domainErrors.CodeTooSmall, "purchase_price must be 0 or greater", map[string]interface{}{"min": 0})
 	}
 
 	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		errs.Add("purchase_date", domainErrors.CodeRequired, "purchase_date is required", nil)
 	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+		errs.Add("purchase_date", domainErrors.CodeInvalidFormat, "purchase_date must be in YYYY-MM-DD format", map[string]interface{}{"format": "YYYY-MM-DD"})
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
-	}
-
-	return nil
+	return errs.Err()
 }
 
 // アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
-	i.Name = strings.TrimSpace(name)
-	i.Category = strings.TrimSpace(category)
-	i.Brand = strings.TrimSpace(brand)
+// NewItem と同じく入力を正規化してからバリデーションする（属性は渡されたもので置き換える）
+func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string, attributes Attributes, categories CategoryChecker) error {
+	i.Name = NormalizeText(name)
+	i.Category = NormalizeText(category)
+	i.Brand = NormalizeText(brand)
 	i.PurchasePrice = purchasePrice
-	i.PurchaseDate = strings.TrimSpace(purchaseDate)
+	i.PurchaseDate = NormalizeText(purchaseDate)
+	i.Attributes = normalizeAttributes(attributes)
 	i.UpdatedAt = time.Now()
 
-	return i.Validate()
-}
-
-// カテゴリーのバリデーション
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
-		}
-	}
-	return false
+	return i.Validate(categories)
 }
 
 // デート形式のバリデーション
@@ -104,8 +117,3 @@ func isValidDateFormat(dateStr string) bool {
 	_, err := time.Parse("2006-01-02", dateStr)
 	return err == nil
 }
-
-// カテゴリーの取得
-func GetValidCategories() []string {
-	return ValidCategories
-}
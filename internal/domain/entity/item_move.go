@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// ItemMove はアイテムの保管場所の移動履歴の1件
+// FromLocationID は移動前の保管場所（保管場所が未設定だった場合は nil）
+// 保管場所を削除した場合、その保管場所を指していた FromLocationID / ToLocationID は nil になる
+type ItemMove struct {
+	ID             int64     `json:"id"`
+	ItemID         int64     `json:"item_id"`
+	FromLocationID *int64    `json:"from_location_id"`
+	ToLocationID   *int64    `json:"to_location_id"`
+	FromPath       []string  `json:"from_path"` // 移動前の保管場所の最上位からの名前（表示用。取得時に設定する）
+	ToPath         []string  `json:"to_path"`   // 移動先の保管場所の最上位からの名前（表示用。取得時に設定する）
+	Note           string    `json:"note"`
+	Actor          string    `json:"actor"`
+	MovedAt        time.Time `json:"moved_at"` // 実際に移動した日時（省略した場合は記録した日時）
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// MaxMoveNoteLength は移動履歴のメモの最大文字数
+const MaxMoveNoteLength = 500
+
+// MoveTo はアイテムを保管場所 locationID に移動し、記録する移動履歴を返す
+// movedAt がゼロ値の場合は現在日時を使う。保管場所が存在するかは呼び出し側で確認する
+func (i *Item) MoveTo(locationID int64, note string, movedAt time.Time) (*ItemMove, error) {
+	var errs domainErrors.ValidationErrors
+
+	now := time.Now()
+	if movedAt.IsZero() {
+		movedAt = now
+	}
+
+	if locationID <= 0 {
+		errs.Add("location_id", domainErrors.CodeRequired, "location_id is required", nil)
+	} else if i.LocationID != nil && *i.LocationID == locationID {
+		errs.Add("location_id", domainErrors.CodeInvalidLocation, "location_id must differ from the current location", nil)
+	}
+
+	note = NormalizeText(note)
+	if hasInvalidCharacters(note) {
+		errs.Add("note", domainErrors.CodeInvalidCharacters, "note must not contain control characters", nil)
+	} else if TextLength(note) > MaxMoveNoteLength {
+		errs.Add("note", domainErrors.CodeTooLong, "note must be 500 characters or less", map[string]interface{}{"max": MaxMoveNoteLength})
+	}
+
+	if movedAt.After(now) {
+		errs.Add("moved_at", domainErrors.CodeTooLarge, "moved_at must not be in the future", map[string]interface{}{"max": now.Format(time.RFC3339)})
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	move := &ItemMove{
+		ItemID:         i.ID,
+		FromLocationID: i.LocationID,
+		ToLocationID:   &locationID,
+		Note:           note,
+		MovedAt:        movedAt,
+	}
+	i.LocationID = &locationID
+	return move, nil
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+// RevisionAction はアイテムに対して行われた操作の種類
+type RevisionAction string
+
+const (
+	RevisionCreate  RevisionAction = "create"
+	RevisionUpdate  RevisionAction = "update"
+	RevisionDelete  RevisionAction = "delete"
+	RevisionRestore RevisionAction = "restore"
+	RevisionRevert  RevisionAction = "revert"
+	RevisionPurge   RevisionAction = "purge"
+)
+
+// ItemRevision はアイテムの変更履歴の1件
+// Snapshot は操作後（削除の場合は削除時点）のアイテム全体、Changes は直前の状態から変わったフィールド
+type ItemRevision struct {
+	ID        int64          `json:"-"`
+	ItemID    int64          `json:"item_id"`
+	Revision  int            `json:"revision"` // アイテムごとの連番（1から始まる）
+	Action    RevisionAction `json:"action"`
+	Actor     string         `json:"actor"`
+	Snapshot  Item           `json:"snapshot"`
+	Changes   []FieldChange  `json:"changes"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// FieldChange は1フィールド分の変更内容（登録時の Before は nil）
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// NewItemRevision は操作前後のアイテムから変更履歴を作成する
+// before が nil の場合（登録）は全フィールドを変更として記録する
+func NewItemRevision(action RevisionAction, actor string, before, after *Item) *ItemRevision {
+	return &ItemRevision{
+		ItemID:   after.ID,
+		Action:   action,
+		Actor:    actor,
+		Snapshot: *after,
+		Changes:  DiffItems(before, after),
+	}
+}
+
+// DiffItems はユーザーが変更できるフィールドと削除日時のうち、before と after で値が異なるものを返す
+// attributes のように比較できない値があるため reflect.DeepEqual で比較する
+func DiffItems(before, after *Item) []FieldChange {
+	changes := []FieldChange{}
+	for _, field := range revisionFields {
+		var old interface{}
+		if before != nil {
+			old = field.value(before)
+		}
+		value := field.value(after)
+		if before != nil && reflect.DeepEqual(old, value) {
+			continue
+		}
+		// 登録時は値のないフィールド（削除日時）を含めない
+		if before == nil && value == nil {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field.name, Before: old, After: value})
+	}
+	return changes
+}
+
+// 変更履歴で差分を取るフィールド（JSONのフィールド名と値の取り出し方）
+var revisionFields = []struct {
+	name  string
+	value func(*Item) interface{}
+}{
+	{"name", func(i *Item) interface{} { return i.Name }},
+	{"category", func(i *Item) interface{} { return i.Category }},
+	{"brand", func(i *Item) interface{} { return i.Brand }},
+	{"purchase_price", func(i *Item) interface{} { return i.PurchasePrice }},
+	{"purchase_date", func(i *Item) interface{} { return i.PurchaseDate }},
+	{"attributes", func(i *Item) interface{} {
+		// 属性が未設定（nil）の場合と空の場合を同じ値として扱う
+		if len(i.Attributes) == 0 {
+			return Attributes{}
+		}
+		return i.Attributes
+	}},
+	{"tags", func(i *Item) interface{} {
+		if len(i.Tags) == 0 {
+			return []string{}
+		}
+		return i.Tags
+	}},
+	// ゴミ箱への移動（delete）と復元（restore）で変わる
+	{"deleted_at", func(i *Item) interface{} {
+		if i.DeletedAt == nil {
+			return nil
+		}
+		return *i.DeletedAt
+	}},
+}
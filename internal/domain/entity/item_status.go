@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// ItemStatus はアイテムの所有状況
+type ItemStatus string
+
+const (
+	StatusOwned     ItemStatus = "owned"     // 手元にある
+	StatusOnLoan    ItemStatus = "on_loan"   // 人に貸している
+	StatusInRepair  ItemStatus = "in_repair" // 修理・メンテナンスに出している
+	StatusConsigned ItemStatus = "consigned" // 委託販売に出している
+	StatusSold      ItemStatus = "sold"      // 売却した
+	StatusLost      ItemStatus = "lost"      // 紛失した
+	StatusStolen    ItemStatus = "stolen"    // 盗難にあった
+	StatusDisposed  ItemStatus = "disposed"  // 廃棄・譲渡した
+)
+
+// ItemStatuses は指定できる所有状況
+var ItemStatuses = []string{
+	string(StatusOwned), string(StatusOnLoan), string(StatusInRepair), string(StatusConsigned),
+	string(StatusSold), string(StatusLost), string(StatusStolen), string(StatusDisposed),
+}
+
+// ManualStatuses は ChangeStatus で指定できる所有状況
+// 売却（sold）は売却の記録と合わせて Sell でのみ変更する（記録がないと売却損益の集計に含まれないため）
+var ManualStatuses = []string{
+	string(StatusOwned), string(StatusOnLoan), string(StatusInRepair), string(StatusConsigned),
+	string(StatusLost), string(StatusStolen), string(StatusDisposed),
+}
+
+// HeldStatuses は現在の所有資産として数える所有状況（手元になくても所有権があるもの）
+var HeldStatuses = []ItemStatus{StatusOwned, StatusOnLoan, StatusInRepair, StatusConsigned}
+
+// statusTransitions は状態ごとに遷移できる状態
+// 売却・廃棄は取り消せない。紛失・盗難は見つかった場合に owned に戻せる
+var statusTransitions = map[ItemStatus][]ItemStatus{
+	StatusOwned:     {StatusOnLoan, StatusInRepair, StatusConsigned, StatusSold, StatusLost, StatusStolen, StatusDisposed},
+	StatusOnLoan:    {StatusOwned, StatusLost, StatusStolen},
+	StatusInRepair:  {StatusOwned, StatusLost, StatusStolen, StatusDisposed},
+	StatusConsigned: {StatusOwned, StatusSold, StatusLost, StatusStolen},
+	StatusSold:      {},
+	StatusLost:      {StatusOwned},
+	StatusStolen:    {StatusOwned},
+	StatusDisposed:  {},
+}
+
+// IsValid は定義済みの所有状況かを返す
+func (s ItemStatus) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// IsHeld は現在の所有資産として数える状態かを返す
+func (s ItemStatus) IsHeld() bool {
+	for _, held := range HeldStatuses {
+		if s == held {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo は to に遷移できるかを返す
+func (s ItemStatus) CanTransitionTo(to ItemStatus) bool {
+	for _, next := range statusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStatuses は s から遷移できる状態の一覧を返す（エラーの allowed に使う）
+// manual が true の場合は ChangeStatus で指定できない sold を除く
+func nextStatuses(s ItemStatus, manual bool) []string {
+	allowed := make([]string, 0, len(statusTransitions[s]))
+	for _, next := range statusTransitions[s] {
+		if manual && next == StatusSold {
+			continue
+		}
+		allowed = append(allowed, string(next))
+	}
+	return allowed
+}
+
+// ItemStatusEvent は所有状況の変更の記録
+type ItemStatusEvent struct {
+	ID         int64      `json:"id"`
+	ItemID     int64      `json:"item_id"`
+	FromStatus ItemStatus `json:"from_status"`
+	ToStatus   ItemStatus `json:"to_status"`
+	Reason     string     `json:"reason"`
+	Actor      string     `json:"actor"`
+	ChangedAt  time.Time  `json:"changed_at"` // 実際に状態が変わった日時（省略した場合は記録した日時）
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// MaxStatusReasonLength は所有状況の変更理由の最大文字数
+const MaxStatusReasonLength = 500
+
+// ChangeStatus は所有状況を to に変更し、記録するイベントを返す
+// 遷移できる状態は statusTransitions で決まり、理由は必須。changedAt がゼロ値の場合は現在日時を使う
+// sold は指定できない（売却は Sell で行う）
+func (i *Item) ChangeStatus(to ItemStatus, reason string, changedAt time.Time) (*ItemStatusEvent, error) {
+	return i.changeStatus(to, reason, changedAt, true)
+}
+
+// Sell は所有状況を sold に変更し、記録するイベントを返す。売却の記録（NewDisposal）と合わせて使う
+func (i *Item) Sell(reason string, soldAt time.Time) (*ItemStatusEvent, error) {
+	return i.changeStatus(StatusSold, reason, soldAt, false)
+}
+
+func (i *Item) changeStatus(to ItemStatus, reason string, changedAt time.Time, manual bool) (*ItemStatusEvent, error) {
+	var errs domainErrors.ValidationErrors
+
+	now := time.Now()
+	if changedAt.IsZero() {
+		changedAt = now
+	}
+
+	from := i.Status
+	if to == "" {
+		errs.Add("status", domainErrors.CodeRequired, "status is required", nil)
+	} else if manual && to == StatusSold {
+		errs.Add("status", domainErrors.CodeInvalidOption, "status sold can only be set by recording a sale",
+			map[string]interface{}{"allowed": ManualStatuses})
+	} else if !to.IsValid() {
+		errs.Add("status", domainErrors.CodeInvalidOption, "status must be one of: "+strings.Join(ManualStatuses, ", "),
+			map[string]interface{}{"allowed": ManualStatuses})
+	} else if !from.CanTransitionTo(to) {
+		errs.Add("status", domainErrors.CodeInvalidTransition, fmt.Sprintf("status cannot change from %s to %s", from, to),
+			map[string]interface{}{"from": string(from), "allowed": nextStatuses(from, manual)})
+	}
+
+	reason = NormalizeText(reason)
+	if reason == "" {
+		errs.Add("reason", domainErrors.CodeRequired, "reason is required", nil)
+	} else if hasInvalidCharacters(reason) {
+		errs.Add("reason", domainErrors.CodeInvalidCharacters, "reason must not contain control characters", nil)
+	} else if TextLength(reason) > MaxStatusReasonLength {
+		errs.Add("reason", domainErrors.CodeTooLong, "reason must be 500 characters or less", map[string]interface{}{"max": MaxStatusReasonLength})
+	}
+
+	if changedAt.After(now) {
+		errs.Add("changed_at", domainErrors.CodeTooLarge, "changed_at must not be in the future", map[string]interface{}{"max": now.Format(time.RFC3339)})
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	event := &ItemStatusEvent{
+		ItemID:     i.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedAt:  changedAt,
+	}
+	i.Status = to
+	return event, nil
+}
@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestItemStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from ItemStatus
+		to   ItemStatus
+		want bool
+	}{
+		{name: "正常系: 手元から貸し出し", from: StatusOwned, to: StatusOnLoan, want: true},
+		{name: "正常系: 委託販売から売却", from: StatusConsigned, to: StatusSold, want: true},
+		{name: "正常系: 紛失から手元に戻る", from: StatusLost, to: StatusOwned, want: true},
+		{name: "正常系: 修理中に廃棄", from: StatusInRepair, to: StatusDisposed, want: true},
+		{name: "異常系: 売却は取り消せない", from: StatusSold, to: StatusOwned},
+		{name: "異常系: 廃棄は取り消せない", from: StatusDisposed, to: StatusOwned},
+		{name: "異常系: 貸し出し中は売却できない", from: StatusOnLoan, to: StatusSold},
+		{name: "異常系: 同じ状態", from: StatusOwned, to: StatusOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestItemStatus_IsHeld(t *testing.T) {
+	for _, status := range []ItemStatus{StatusOwned, StatusOnLoan, StatusInRepair, StatusConsigned} {
+		assert.True(t, status.IsHeld(), status)
+	}
+	for _, status := range []ItemStatus{StatusSold, StatusLost, StatusStolen, StatusDisposed} {
+		assert.False(t, status.IsHeld(), status)
+	}
+}
+
+func TestItem_ChangeStatus(t *testing.T) {
+	newItem := func(status ItemStatus) *Item {
+		item, err := NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, FixedCategories)
+		require.NoError(t, err)
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+
+	t.Run("正常系: 新しいアイテムは手元にある", func(t *testing.T) {
+		assert.Equal(t, StatusOwned, newItem(StatusOwned).Status)
+	})
+
+	t.Run("正常系: 変更前の状態と理由を記録する", func(t *testing.T) {
+		item := newItem(StatusOwned)
+		changedAt := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+		event, err := item.ChangeStatus(StatusConsigned, " 買取店に委託 ", changedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusConsigned, item.Status)
+		assert.Equal(t, int64(1), event.ItemID)
+		assert.Equal(t, StatusOwned, event.FromStatus)
+		assert.Equal(t, StatusConsigned, event.ToStatus)
+		assert.Equal(t, "買取店に委託", event.Reason)
+		assert.Equal(t, changedAt, event.ChangedAt)
+	})
+
+	t.Run("正常系: 日時を省略した場合は現在日時", func(t *testing.T) {
+		event, err := newItem(StatusStolen).ChangeStatus(StatusOwned, "警察から返還された", time.Time{})
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), event.ChangedAt, time.Second)
+	})
+
+	errorTests := []struct {
+		name      string
+		from      ItemStatus
+		to        ItemStatus
+		reason    string
+		changedAt time.Time
+		wantField string
+		wantCode  string
+	}{
+		{name: "異常系: 状態の指定がない", from: StatusOwned, to: "", reason: "理由", wantField: "status", wantCode: domainErrors.CodeRequired},
+		{name: "異常系: 未定義の状態", from: StatusOwned, to: "archived", reason: "理由", wantField: "status", wantCode: domainErrors.CodeInvalidOption},
+		{name: "異常系: 売却は Sell でのみ行う", from: StatusOwned, to: StatusSold, reason: "売却した", wantField: "status", wantCode: domainErrors.CodeInvalidOption},
+		{name: "異常系: 遷移できない状態", from: StatusSold, to: StatusOwned, reason: "買い戻した", wantField: "status", wantCode: domainErrors.CodeInvalidTransition},
+		{name: "異常系: 理由がない", from: StatusOwned, to: StatusLost, reason: "  ", wantField: "reason", wantCode: domainErrors.CodeRequired},
+		{name: "異常系: 理由が長すぎる", from: StatusOwned, to: StatusLost, reason: strings.Repeat("あ", MaxStatusReasonLength+1), wantField: "reason", wantCode: domainErrors.CodeTooLong},
+		{name: "異常系: 未来の日時", from: StatusOwned, to: StatusLost, reason: "理由", changedAt: time.Now().Add(time.Hour), wantField: "changed_at", wantCode: domainErrors.CodeTooLarge},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newItem(tt.from)
+
+			_, err := item.ChangeStatus(tt.to, tt.reason, tt.changedAt)
+
+			var errs domainErrors.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Equal(t, tt.wantCode, errs[0].Code)
+			assert.Equal(t, tt.from, item.Status)
+		})
+	}
+
+	t.Run("異常系: 遷移できる状態をパラメータに含める", func(t *testing.T) {
+		_, err := newItem(StatusLost).ChangeStatus(StatusOnLoan, "見つかったので貸した", time.Time{})
+
+		var errs domainErrors.ValidationErrors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, "lost", errs[0].Params["from"])
+		assert.Equal(t, []string{"owned"}, errs[0].Params["allowed"])
+	})
+
+	t.Run("異常系: 遷移できる状態に sold を含めない", func(t *testing.T) {
+		_, err := newItem(StatusConsigned).ChangeStatus(StatusInRepair, "修理に出した", time.Time{})
+
+		var errs domainErrors.ValidationErrors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"owned", "lost", "stolen"}, errs[0].Params["allowed"])
+	})
+
+	t.Run("異常系: sold を指定した場合は指定できる状態をパラメータに含める", func(t *testing.T) {
+		_, err := newItem(StatusOwned).ChangeStatus(StatusSold, "売却した", time.Time{})
+
+		var errs domainErrors.ValidationErrors
+		require.True(t, errors.As(err, &errs))
+		assert.NotContains(t, errs[0].Params["allowed"], "sold")
+	})
+}
+
+func TestItem_Sell(t *testing.T) {
+	newItem := func(status ItemStatus) *Item {
+		item, err := NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, FixedCategories)
+		require.NoError(t, err)
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+
+	t.Run("正常系: 委託販売中のアイテムを売却済みにする", func(t *testing.T) {
+		item := newItem(StatusConsigned)
+		soldAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+		event, err := item.Sell("メルカリで売却", soldAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusSold, item.Status)
+		assert.Equal(t, StatusConsigned, event.FromStatus)
+		assert.Equal(t, StatusSold, event.ToStatus)
+		assert.Equal(t, soldAt, event.ChangedAt)
+	})
+
+	t.Run("異常系: 売却に遷移できない状態", func(t *testing.T) {
+		item := newItem(StatusLost)
+
+		_, err := item.Sell("見つかったので売却", time.Time{})
+
+		var errs domainErrors.ValidationErrors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, domainErrors.CodeInvalidTransition, errs[0].Code)
+		assert.Equal(t, []string{"owned"}, errs[0].Params["allowed"])
+		assert.Equal(t, StatusLost, item.Status)
+	})
+}
@@ -1,11 +1,14 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
 )
 
 func TestNewItem(t *testing.T) {
@@ -40,7 +43,7 @@ func TestNewItem(t *testing.T) {
 		},
 		{
 			name:          "異常系: 名前が100文字超過",
-			itemName:      "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
+			itemName:      strings.Repeat("時", 101),
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
@@ -118,6 +121,25 @@ func TestNewItem(t *testing.T) {
 			wantErr:       true,
 			expectedErr:   "purchase_date must be in YYYY-MM-DD format",
 		},
+		{
+			name:          "正常系: 日本語の名前は100文字まで登録できる",
+			itemName:      strings.Repeat("時", 100),
+			category:      "時計",
+			brand:         strings.Repeat("ロ", 100),
+			purchasePrice: 1500000,
+			purchaseDate:  "2023-01-15",
+			wantErr:       false,
+		},
+		{
+			name:          "異常系: 名前に制御文字",
+			itemName:      "ロレックス\x00デイトナ",
+			category:      "時計",
+			brand:         "ROLEX",
+			purchasePrice: 1500000,
+			purchaseDate:  "2023-01-15",
+			wantErr:       true,
+			expectedErr:   "name must not contain control characters",
+		},
 		{
 			name:          "正常系: 購入価格が0",
 			itemName:      "ギフト品",
@@ -131,7 +153,7 @@ func TestNewItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, tt.purchaseDate)
+			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, tt.purchaseDate, nil, FixedCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -159,7 +181,7 @@ func TestNewItem(t *testing.T) {
 
 func TestItem_Update(t *testing.T) {
 	// 初期アイテムを作成
-	item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+	item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01", nil, FixedCategories)
 	require.NoError(t, err)
 
 	originalUpdatedAt := item.UpdatedAt
@@ -208,7 +230,7 @@ func TestItem_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, tt.newDate)
+			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, tt.newDate, nil, FixedCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -265,7 +287,7 @@ func TestItem_Validate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.item.Validate()
+			err := tt.item.Validate(FixedCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -278,7 +300,67 @@ func TestItem_Validate(t *testing.T) {
 	}
 }
 
-func TestIsValidCategory(t *testing.T) {
+func TestItem_ValidateFieldErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		item       *Item
+		wantField  string
+		wantCode   string
+		wantParams map[string]interface{}
+	}{
+		{
+			name:      "異常系: 名前が未入力",
+			item:      &Item{Name: "", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+			wantField: "name",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:       "異常系: ブランドが長すぎる",
+			item:       &Item{Name: "時計", Category: "時計", Brand: strings.Repeat("a", 101), PurchaseDate: "2023-01-15"},
+			wantField:  "brand",
+			wantCode:   domainErrors.CodeTooLong,
+			wantParams: map[string]interface{}{"max": 100},
+		},
+		{
+			name:       "異常系: 無効なカテゴリー",
+			item:       &Item{Name: "時計", Category: "家具", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+			wantField:  "category",
+			wantCode:   domainErrors.CodeInvalidCategory,
+			wantParams: map[string]interface{}{"allowed": ValidCategories},
+		},
+		{
+			name:       "異常系: 負の価格",
+			item:       &Item{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: -1, PurchaseDate: "2023-01-15"},
+			wantField:  "purchase_price",
+			wantCode:   domainErrors.CodeTooSmall,
+			wantParams: map[string]interface{}{"min": 0},
+		},
+		{
+			name:       "異常系: 日付の形式が不正",
+			item:       &Item{Name: "時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023/01/15"},
+			wantField:  "purchase_date",
+			wantCode:   domainErrors.CodeInvalidFormat,
+			wantParams: map[string]interface{}{"format": "YYYY-MM-DD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.item.Validate(FixedCategories)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Equal(t, tt.wantCode, errs[0].Code)
+			assert.Equal(t, tt.wantParams, errs[0].Params)
+			assert.Equal(t, err.Error(), errs[0].Message)
+		})
+	}
+}
+
+func TestFixedCategories_IsAssignable(t *testing.T) {
 	tests := []struct {
 		name     string
 		category string
@@ -296,7 +378,7 @@ func TestIsValidCategory(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isValidCategory(tt.category)
+			got := FixedCategories.IsAssignable(tt.category)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -328,10 +410,118 @@ func TestIsValidDateFormat(t *testing.T) {
 	}
 }
 
-func TestGetValidCategories(t *testing.T) {
-	categories := GetValidCategories()
+func TestFixedCategories_AssignableCodes(t *testing.T) {
+	categories := FixedCategories.AssignableCodes()
 	expected := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 5)
 }
+
+func TestDiffItems(t *testing.T) {
+	base := &Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}
+	deletedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		before *Item
+		after  func() *Item
+		want   []FieldChange
+	}{
+		{
+			name:   "正常系: 登録時は全フィールドが変更になる",
+			before: nil,
+			after:  func() *Item { return base },
+			want: []FieldChange{
+				{Field: "name", Before: nil, After: "時計1"},
+				{Field: "category", Before: nil, After: "時計"},
+				{Field: "brand", Before: nil, After: "ROLEX"},
+				{Field: "purchase_price", Before: nil, After: 1000000},
+				{Field: "purchase_date", Before: nil, After: "2023-01-01"},
+				{Field: "attributes", Before: nil, After: Attributes{}},
+				{Field: "tags", Before: nil, After: []string{}},
+			},
+		},
+		{
+			name:   "正常系: 変更されたフィールドのみ",
+			before: base,
+			after: func() *Item {
+				item := *base
+				item.Name = "時計2"
+				item.PurchasePrice = 1200000
+				item.Version = 2
+				return &item
+			},
+			want: []FieldChange{
+				{Field: "name", Before: "時計1", After: "時計2"},
+				{Field: "purchase_price", Before: 1000000, After: 1200000},
+			},
+		},
+		{
+			name:   "正常系: 変更なし",
+			before: base,
+			after: func() *Item {
+				item := *base
+				return &item
+			},
+			want: []FieldChange{},
+		},
+		{
+			name:   "正常系: 属性の変更",
+			before: base,
+			after: func() *Item {
+				item := *base
+				item.Attributes = Attributes{"movement": "automatic"}
+				return &item
+			},
+			want: []FieldChange{
+				{Field: "attributes", Before: Attributes{}, After: Attributes{"movement": "automatic"}},
+			},
+		},
+		{
+			name:   "正常系: 属性が nil と空は変更なし",
+			before: base,
+			after: func() *Item {
+				item := *base
+				item.Attributes = Attributes{}
+				return &item
+			},
+			want: []FieldChange{},
+		},
+		{
+			name: "正常系: 復元は削除日時の変更",
+			before: func() *Item {
+				item := *base
+				item.DeletedAt = &deletedAt
+				item.Version = 2
+				return &item
+			}(),
+			after: func() *Item {
+				item := *base
+				item.Version = 3
+				return &item
+			},
+			want: []FieldChange{
+				{Field: "deleted_at", Before: deletedAt, After: nil},
+			},
+		},
+		{
+			name:   "正常系: タグの変更",
+			before: base,
+			after: func() *Item {
+				item := *base
+				item.Tags = []string{"旅行"}
+				return &item
+			},
+			want: []FieldChange{
+				{Field: "tags", Before: []string{}, After: []string{"旅行"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DiffItems(tt.before, tt.after()))
+		})
+	}
+}
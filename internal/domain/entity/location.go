@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// LocationKind は保管場所の種類
+// 建物 > 部屋 > 収納（金庫・箱・引き出しなど）の順に入れ子にする
+type LocationKind string
+
+const (
+	LocationBuilding  LocationKind = "building"  // 自宅・実家・銀行など（最上位にのみ置ける）
+	LocationRoom      LocationKind = "room"      // 寝室・書斎など（建物の中にのみ置ける）
+	LocationContainer LocationKind = "container" // 金庫・貸金庫・箱など（建物・部屋・収納の中に置ける）
+)
+
+// LocationKinds は指定できる保管場所の種類
+var LocationKinds = []string{string(LocationBuilding), string(LocationRoom), string(LocationContainer)}
+
+// allowedParentKinds は種類ごとに親にできる保管場所の種類（nil は最上位のみ）
+var allowedParentKinds = map[LocationKind][]LocationKind{
+	LocationBuilding:  nil,
+	LocationRoom:      {LocationBuilding},
+	LocationContainer: {LocationBuilding, LocationRoom, LocationContainer},
+}
+
+// Location はアイテムの保管場所
+// ParentID が nil の保管場所は最上位になる。アイテムはどの階層の保管場所にも置ける
+type Location struct {
+	ID        int64        `json:"id"`
+	ParentID  *int64       `json:"parent_id"`
+	Name      string       `json:"name"`
+	Kind      LocationKind `json:"kind"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// MaxLocationNameLength は保管場所の名前の最大文字数
+const MaxLocationNameLength = 100
+
+// NewLocation は名前を正規化してからバリデーションし、保管場所を作成する
+// 親との組み合わせは CanBePlacedIn で確認する
+func NewLocation(name string, kind LocationKind) (*Location, error) {
+	location := &Location{
+		Name:      NormalizeText(name),
+		Kind:      kind,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// Update は名前と種類を更新する
+func (l *Location) Update(name string, kind LocationKind) error {
+	l.Name = NormalizeText(name)
+	l.Kind = kind
+	l.UpdatedAt = time.Now()
+
+	return l.Validate()
+}
+
+// Validate は保管場所のフィールドを検証する
+func (l *Location) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if l.Name == "" {
+		errs.Add("name", domainErrors.CodeRequired, "name is required", nil)
+	} else if hasInvalidCharacters(l.Name) {
+		errs.Add("name", domainErrors.CodeInvalidCharacters, "name must not contain control characters", nil)
+	} else if TextLength(l.Name) > MaxLocationNameLength {
+		errs.Add("name", domainErrors.CodeTooLong, "name must be 100 characters or less", map[string]interface{}{"max": MaxLocationNameLength})
+	}
+
+	if _, ok := allowedParentKinds[l.Kind]; !ok {
+		errs.Add("kind", domainErrors.CodeInvalidOption, "kind must be one of: building, room, container", map[string]interface{}{"allowed": LocationKinds})
+	}
+
+	if l.ParentID != nil && l.ID != 0 && *l.ParentID == l.ID {
+		errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id must not be the location itself", nil)
+	}
+
+	return errs.Err()
+}
+
+// CanBePlacedIn は parent の中に置けるかを検証する（parent が nil の場合は最上位）
+// 建物は最上位にのみ、部屋は建物の中にのみ置ける
+func (l *Location) CanBePlacedIn(parent *Location) error {
+	if canBePlacedIn(l.Kind, parent) {
+		return nil
+	}
+	return placementError("parent_id", l.Kind)
+}
+
+// CanContain は種類を変更した後も、中にある保管場所 children を置いたままにできるかを検証する
+func (l *Location) CanContain(children []*Location) error {
+	for _, child := range children {
+		if !canBePlacedIn(child.Kind, l) {
+			return placementError("kind", child.Kind)
+		}
+	}
+	return nil
+}
+
+func canBePlacedIn(kind LocationKind, parent *Location) bool {
+	if parent == nil {
+		return kind == LocationBuilding
+	}
+	for _, allowed := range allowedParentKinds[kind] {
+		if parent.Kind == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// placementError は kind の保管場所を置ける場所を示すバリデーションエラーを返す
+func placementError(field string, kind LocationKind) error {
+	allowed := allowedParentKinds[kind]
+	names := make([]string, 0, len(allowed)+1)
+	for _, k := range allowed {
+		names = append(names, string(k))
+	}
+	if len(names) == 0 {
+		names = append(names, "top level")
+	}
+
+	var errs domainErrors.ValidationErrors
+	errs.Add(field, domainErrors.CodeInvalidParentKind,
+		fmt.Sprintf("a %s can only be placed in: %s", kind, strings.Join(names, ", ")),
+		map[string]interface{}{"kind": string(kind), "allowed": names})
+	return errs.Err()
+}
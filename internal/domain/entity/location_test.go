@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestNewLocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		locName   string
+		kind      LocationKind
+		want      string
+		wantField string
+		wantCode  string
+	}{
+		{
+			name:    "正常系: 名前を正規化する",
+			locName: "  自宅  ",
+			kind:    LocationBuilding,
+			want:    "自宅",
+		},
+		{
+			name:      "異常系: 空の名前",
+			locName:   " ",
+			kind:      LocationRoom,
+			wantField: "name",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:      "異常系: 名前が長すぎる",
+			locName:   strings.Repeat("あ", MaxLocationNameLength+1),
+			kind:      LocationRoom,
+			wantField: "name",
+			wantCode:  domainErrors.CodeTooLong,
+		},
+		{
+			name:      "異常系: 種類が不正",
+			locName:   "倉庫",
+			kind:      "warehouse",
+			wantField: "kind",
+			wantCode:  domainErrors.CodeInvalidOption,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := NewLocation(tt.locName, tt.kind)
+
+			if tt.wantCode != "" {
+				var errs domainErrors.ValidationErrors
+				require.True(t, errors.As(err, &errs))
+				require.Len(t, errs, 1)
+				assert.Equal(t, tt.wantField, errs[0].Field)
+				assert.Equal(t, tt.wantCode, errs[0].Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, location.Name)
+		})
+	}
+}
+
+func TestLocation_CanBePlacedIn(t *testing.T) {
+	building := &Location{ID: 1, Kind: LocationBuilding}
+	room := &Location{ID: 2, Kind: LocationRoom}
+	container := &Location{ID: 3, Kind: LocationContainer}
+
+	tests := []struct {
+		name    string
+		kind    LocationKind
+		parent  *Location
+		wantErr bool
+	}{
+		{name: "正常系: 建物は最上位", kind: LocationBuilding, parent: nil},
+		{name: "正常系: 部屋は建物の中", kind: LocationRoom, parent: building},
+		{name: "正常系: 収納は部屋の中", kind: LocationContainer, parent: room},
+		{name: "正常系: 収納は収納の中", kind: LocationContainer, parent: container},
+		{name: "異常系: 建物は建物の中に置けない", kind: LocationBuilding, parent: building, wantErr: true},
+		{name: "異常系: 部屋は最上位に置けない", kind: LocationRoom, parent: nil, wantErr: true},
+		{name: "異常系: 部屋は収納の中に置けない", kind: LocationRoom, parent: container, wantErr: true},
+		{name: "異常系: 収納は最上位に置けない", kind: LocationContainer, parent: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Location{Kind: tt.kind}).CanBePlacedIn(tt.parent)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var errs domainErrors.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			assert.Equal(t, "parent_id", errs[0].Field)
+			assert.Equal(t, domainErrors.CodeInvalidParentKind, errs[0].Code)
+			assert.Equal(t, string(tt.kind), errs[0].Params["kind"])
+		})
+	}
+}
+
+func TestItem_MoveTo(t *testing.T) {
+	newItem := func(locationID *int64) *Item {
+		item, err := NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, FixedCategories)
+		require.NoError(t, err)
+		item.ID = 1
+		item.LocationID = locationID
+		return item
+	}
+	current := int64(3)
+
+	t.Run("正常系: 移動前の保管場所を記録して移動する", func(t *testing.T) {
+		item := newItem(&current)
+		movedAt := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+
+		move, err := item.MoveTo(6, " 貸金庫へ ", movedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), *item.LocationID)
+		assert.Equal(t, int64(1), move.ItemID)
+		assert.Equal(t, int64(3), *move.FromLocationID)
+		assert.Equal(t, int64(6), *move.ToLocationID)
+		assert.Equal(t, "貸金庫へ", move.Note)
+		assert.Equal(t, movedAt, move.MovedAt)
+	})
+
+	t.Run("正常系: 日時を省略した場合は現在日時", func(t *testing.T) {
+		move, err := newItem(nil).MoveTo(6, "", time.Time{})
+
+		require.NoError(t, err)
+		assert.Nil(t, move.FromLocationID)
+		assert.WithinDuration(t, time.Now(), move.MovedAt, time.Second)
+	})
+
+	errorTests := []struct {
+		name       string
+		locationID int64
+		note       string
+		movedAt    time.Time
+		wantField  string
+		wantCode   string
+	}{
+		{name: "異常系: 保管場所の指定がない", locationID: 0, wantField: "location_id", wantCode: domainErrors.CodeRequired},
+		{name: "異常系: 現在と同じ保管場所", locationID: current, wantField: "location_id", wantCode: domainErrors.CodeInvalidLocation},
+		{name: "異常系: メモが長すぎる", locationID: 6, note: strings.Repeat("あ", MaxMoveNoteLength+1), wantField: "note", wantCode: domainErrors.CodeTooLong},
+		{name: "異常系: 未来の日時", locationID: 6, movedAt: time.Now().Add(time.Hour), wantField: "moved_at", wantCode: domainErrors.CodeTooLarge},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newItem(&current)
+
+			_, err := item.MoveTo(tt.locationID, tt.note, tt.movedAt)
+
+			var errs domainErrors.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Equal(t, tt.wantCode, errs[0].Code)
+			assert.Equal(t, current, *item.LocationID)
+		})
+	}
+}
+
+func TestLocationTree(t *testing.T) {
+	home := int64(1)
+	bedroom := int64(2)
+	tree := NewLocationTree([]*Location{
+		{ID: 1, Name: "自宅", Kind: LocationBuilding},
+		{ID: 2, ParentID: &home, Name: "寝室", Kind: LocationRoom},
+		{ID: 3, ParentID: &bedroom, Name: "金庫", Kind: LocationContainer},
+		{ID: 4, Name: "銀行", Kind: LocationBuilding},
+	})
+
+	t.Run("正常系: 最上位からのパス", func(t *testing.T) {
+		assert.Equal(t, []string{"自宅", "寝室", "金庫"}, tree.Path(3))
+		assert.Nil(t, tree.Path(99))
+	})
+
+	t.Run("正常系: 中にある保管場所", func(t *testing.T) {
+		assert.Len(t, tree.Descendants(1), 2)
+		assert.True(t, tree.IsDescendant(1, 3))
+		assert.False(t, tree.IsDescendant(3, 1))
+		assert.Len(t, tree.Children(0), 2)
+	})
+}
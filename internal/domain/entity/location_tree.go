@@ -0,0 +1,82 @@
+package entity
+
+// LocationTree は保管場所の親子関係をたどるためのビュー
+// 子の保管場所は渡された順（通常は名前, id の昇順）に並ぶ
+type LocationTree struct {
+	locations []*Location
+	byID      map[int64]*Location
+	children  map[int64][]*Location // キー 0 は最上位の保管場所
+}
+
+// NewLocationTree は保管場所の一覧から親子関係を組み立てる
+// 親が一覧にない保管場所は最上位として扱う
+func NewLocationTree(locations []*Location) *LocationTree {
+	t := &LocationTree{
+		locations: locations,
+		byID:      make(map[int64]*Location, len(locations)),
+		children:  make(map[int64][]*Location),
+	}
+	for _, l := range locations {
+		t.byID[l.ID] = l
+	}
+	for _, l := range locations {
+		parent := int64(0)
+		if l.ParentID != nil {
+			if _, ok := t.byID[*l.ParentID]; ok {
+				parent = *l.ParentID
+			}
+		}
+		t.children[parent] = append(t.children[parent], l)
+	}
+	return t
+}
+
+// All はすべての保管場所を渡された順に返す
+func (t *LocationTree) All() []*Location {
+	return t.locations
+}
+
+// Children は直下の保管場所を返す（id が 0 の場合は最上位の保管場所）
+func (t *LocationTree) Children(id int64) []*Location {
+	return t.children[id]
+}
+
+// FindByID はIDに対応する保管場所を返す
+func (t *LocationTree) FindByID(id int64) (*Location, bool) {
+	l, ok := t.byID[id]
+	return l, ok
+}
+
+// Descendants は中にある保管場所を深さ優先の順に返す（自身は含まない）
+func (t *LocationTree) Descendants(id int64) []*Location {
+	var descendants []*Location
+	for _, child := range t.children[id] {
+		descendants = append(descendants, child)
+		descendants = append(descendants, t.Descendants(child.ID)...)
+	}
+	return descendants
+}
+
+// IsDescendant は candidate が id の中にある保管場所かを返す
+func (t *LocationTree) IsDescendant(id, candidate int64) bool {
+	for _, d := range t.Descendants(id) {
+		if d.ID == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Path は最上位から保管場所までの名前を返す（例: [自宅 寝室 金庫]）
+// 存在しない保管場所の場合は nil を返す
+func (t *LocationTree) Path(id int64) []string {
+	var path []string
+	for l, ok := t.byID[id]; ok; {
+		path = append([]string{l.Name}, path...)
+		if l.ParentID == nil {
+			break
+		}
+		l, ok = t.byID[*l.ParentID]
+	}
+	return path
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// Tag はアイテムに自由に付けるラベル（「限定品」「母からの贈り物」など）
+// 1つのタグを複数のアイテムに付けられる。名前は大文字・小文字を区別せず一意
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// タグの上限
+const (
+	MaxTagNameLength = 50 // タグ名の最大文字数
+	MaxItemTags      = 20 // 1つのアイテムに付けられるタグの数
+)
+
+// NewTag は名前を正規化してからバリデーションし、タグを作成する
+func NewTag(name string) (*Tag, error) {
+	tag := &Tag{
+		Name:      NormalizeText(name),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// Rename はタグの名前を変更する（タグが付いたアイテムすべてに反映される）
+func (t *Tag) Rename(name string) error {
+	t.Name = NormalizeText(name)
+	t.UpdatedAt = time.Now()
+
+	return t.Validate()
+}
+
+// Validate はタグのフィールドを検証する
+func (t *Tag) Validate() error {
+	var errs domainErrors.ValidationErrors
+	validateTagName(&errs, "name", t.Name)
+	return errs.Err()
+}
+
+// NormalizeTags はアイテムに付けるタグ名を正規化し、重複を取り除いて名前順に並べる
+// 大文字・小文字だけが異なる名前は同じタグとみなし、最初に指定された表記を使う
+// エラーのフィールドは tags[i]（i は渡されたスライスでの位置）
+func NormalizeTags(names []string) ([]string, error) {
+	var errs domainErrors.ValidationErrors
+
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = NormalizeText(name)
+		if !validateTagName(&errs, fmt.Sprintf("tags[%d]", i), name) {
+			continue
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+
+	if len(normalized) > MaxItemTags {
+		errs.Add("tags", domainErrors.CodeTooMany, fmt.Sprintf("tags must have %d tags or less", MaxItemTags), map[string]interface{}{"max": MaxItemTags})
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// SetTags はアイテムのタグを置き換える（NormalizeTags で正規化・検証する）
+func (i *Item) SetTags(names []string) error {
+	tags, err := NormalizeTags(names)
+	if err != nil {
+		return err
+	}
+	i.Tags = tags
+	return nil
+}
+
+// validateTagName はタグ名を検証し、正しい場合は true を返す
+func validateTagName(errs *domainErrors.ValidationErrors, field, name string) bool {
+	switch {
+	case name == "":
+		errs.Add(field, domainErrors.CodeRequired, field+" is required", nil)
+	case hasInvalidCharacters(name):
+		errs.Add(field, domainErrors.CodeInvalidCharacters, field+" must not contain control characters", nil)
+	case TextLength(name) > MaxTagNameLength:
+		errs.Add(field, domainErrors.CodeTooLong, fmt.Sprintf("%s must be %d characters or less", field, MaxTagNameLength), map[string]interface{}{"max": MaxTagNameLength})
+	default:
+		return true
+	}
+	return false
+}
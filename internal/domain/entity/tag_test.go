@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tooMany := make([]string, MaxItemTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%02d", i)
+	}
+
+	tests := []struct {
+		name      string
+		tags      []string
+		want      []string
+		wantField string
+		wantCode  string
+	}{
+		{
+			name: "正常系: 前後の空白を除いて名前順に並べる",
+			tags: []string{" 旅行 ", "限定品", "母からの贈り物"},
+			want: []string{"旅行", "母からの贈り物", "限定品"},
+		},
+		{
+			name: "正常系: 大文字・小文字だけが異なる名前は最初の表記を使う",
+			tags: []string{"Gift", "gift", "GIFT"},
+			want: []string{"Gift"},
+		},
+		{
+			name: "正常系: 全角英数字は半角にする",
+			tags: []string{"ＶＩＮＴＡＧＥ", "VINTAGE"},
+			want: []string{"VINTAGE"},
+		},
+		{
+			name: "正常系: 空の配列",
+			tags: nil,
+			want: []string{},
+		},
+		{
+			name:      "異常系: 空のタグ名",
+			tags:      []string{"旅行", "  "},
+			wantField: "tags[1]",
+			wantCode:  domainErrors.CodeRequired,
+		},
+		{
+			name:      "異常系: タグ名が長すぎる",
+			tags:      []string{strings.Repeat("あ", MaxTagNameLength+1)},
+			wantField: "tags[0]",
+			wantCode:  domainErrors.CodeTooLong,
+		},
+		{
+			name:      "異常系: 制御文字を含む",
+			tags:      []string{"旅行\x07"},
+			wantField: "tags[0]",
+			wantCode:  domainErrors.CodeInvalidCharacters,
+		},
+		{
+			name:      "異常系: タグが多すぎる",
+			tags:      tooMany,
+			wantField: "tags",
+			wantCode:  domainErrors.CodeTooMany,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := NormalizeTags(tt.tags)
+
+			if tt.wantCode != "" {
+				var errs domainErrors.ValidationErrors
+				require.True(t, errors.As(err, &errs))
+				require.Len(t, errs, 1)
+				assert.Equal(t, tt.wantField, errs[0].Field)
+				assert.Equal(t, tt.wantCode, errs[0].Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tags)
+		})
+	}
+}
+
+func TestTag_Rename(t *testing.T) {
+	tag, err := NewTag("旅行")
+	require.NoError(t, err)
+
+	t.Run("正常系: 名前を正規化して変更する", func(t *testing.T) {
+		require.NoError(t, tag.Rename(" 旅行用 "))
+		assert.Equal(t, "旅行用", tag.Name)
+	})
+
+	t.Run("異常系: 空の名前", func(t *testing.T) {
+		err := tag.Rename("")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText は入力された文字列を保存する形に正規化する
+//   - NFKC 正規化: "ＲＯＬＥＸ" → "ROLEX", "ｴﾙﾒｽ" → "エルメス"
+//   - 空白（全角スペース・タブ・改行を含む）の連続を半角スペース1つにまとめる
+//   - 前後の空白を取り除く
+//
+// 制御文字は取り除かずに残し、Validate でエラーにする（黙って内容を変えないため）
+func NormalizeText(s string) string {
+	var b strings.Builder
+	space := false
+
+	for _, r := range norm.NFKC.String(s) {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// TextLength は文字数を返す
+// MySQL の utf8mb4 の VARCHAR(n) と同じく、バイト数ではなく文字（コードポイント）の数で数える
+func TextLength(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// hasInvalidCharacters は制御文字または不正なUTF-8のバイト列が含まれるかを返す
+func hasInvalidCharacters(s string) bool {
+	if !utf8.ValidString(s) {
+		return true
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return true
+		}
+	}
+	return false
+}
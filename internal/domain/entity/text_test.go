@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"正常系: そのまま", "ロレックス デイトナ", "ロレックス デイトナ"},
+		{"正常系: 全角英数字を半角にする", "ＲＯＬＥＸ　１６５２０", "ROLEX 16520"},
+		{"正常系: 半角カタカナを全角にする", "ｴﾙﾒｽ ﾊﾞｰｷﾝ", "エルメス バーキン"},
+		{"正常系: 前後の全角スペースを取り除く", "　バーキン　", "バーキン"},
+		{"正常系: 連続する空白をまとめる", "ロレックス  \t デイトナ", "ロレックス デイトナ"},
+		{"正常系: 改行も空白として扱う", "ロレックス\r\nデイトナ", "ロレックス デイトナ"},
+		{"正常系: 結合文字を合成する", "\u30ab\u3099ラス", "ガラス"},
+		{"正常系: 空白のみは空文字", " 　\t", ""},
+		{"正常系: 制御文字は残す（バリデーションでエラーにする）", "a\x00b", "a\x00b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeText(tt.input))
+		})
+	}
+}
+
+func TestTextLength(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"ASCII", "ROLEX", 5},
+		{"日本語はバイト数ではなく文字数", "ロレックス", 5},
+		{"4バイト文字も1文字", "𠮷野家", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TextLength(tt.input))
+		})
+	}
+}
+
+func FuzzNormalizeText(f *testing.F) {
+	for _, seed := range []string{"", "ロレックス デイトナ", "　ＲＯＬＥＸ　", "ｴﾙﾒｽ", "a\t\n b", "\u30ab\u3099", "a\x00b", "\u0020\u0301x"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip()
+		}
+		got := NormalizeText(s)
+
+		// 何度正規化しても結果が変わらない
+		assert.Equal(t, got, NormalizeText(got))
+		assert.True(t, norm.NFKC.IsNormalString(got))
+		// 前後に空白がなく、空白は半角スペース1つずつになっている
+		assert.Equal(t, strings.TrimFunc(got, unicode.IsSpace), got)
+		assert.NotContains(t, got, "  ")
+		for _, r := range got {
+			if unicode.IsSpace(r) {
+				assert.Equal(t, ' ', r)
+			}
+		}
+	})
+}
+
+func FuzzNewItem(f *testing.F) {
+	f.Add("ロレックス デイトナ", "ROLEX")
+	f.Add(strings.Repeat("時", 100), strings.Repeat("a", 100))
+	f.Add("　", "ｴﾙﾒｽ")
+	f.Add("a\x00b", "\u200b")
+
+	f.Fuzz(func(t *testing.T, name, brand string) {
+		item, err := NewItem(name, "時計", brand, 0, "2023-01-15", nil, FixedCategories)
+		if err != nil {
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			return
+		}
+
+		// 登録できたアイテムはデータベースの VARCHAR(100) に収まり、制御文字を含まない
+		for _, v := range []string{item.Name, item.Brand} {
+			assert.NotEmpty(t, v)
+			assert.LessOrEqual(t, utf8.RuneCountInString(v), MaxNameLength)
+			assert.False(t, hasInvalidCharacters(v))
+			assert.Equal(t, v, NormalizeText(v))
+		}
+	})
+}
@@ -7,10 +7,33 @@ var (
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabaseError  = errors.New("database error")
 	ErrDuplicateEntry = errors.New("duplicate entry")
+	// ErrRevisionNotFound は指定された変更履歴が存在しない場合のエラー
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrVersionConflict は楽観的排他制御で、指定されたバージョンが現在のバージョンと一致しない場合のエラー
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrCategoryNotFound は指定されたカテゴリーが存在しない場合のエラー
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrCategoryInUse はアイテムで使われているカテゴリーを削除・無効化しようとした場合のエラー
+	ErrCategoryInUse = errors.New("category is in use")
+	// ErrCategoryHasChildren は子カテゴリーがあるカテゴリーを削除しようとした場合のエラー
+	ErrCategoryHasChildren = errors.New("category has children")
+	// ErrTagNotFound は指定されたタグが存在しない場合のエラー
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrLocationNotFound は指定された保管場所が存在しない場合のエラー
+	ErrLocationNotFound = errors.New("location not found")
+	// ErrLocationInUse はアイテムが置かれている保管場所を削除しようとした場合のエラー
+	ErrLocationInUse = errors.New("location is in use")
+	// ErrLocationHasChildren は中に別の保管場所がある保管場所を削除しようとした場合のエラー
+	ErrLocationHasChildren = errors.New("location has children")
+	// ErrDisposalNotFound は売却の記録がないアイテムの記録を取得しようとした場合のエラー
+	ErrDisposalNotFound = errors.New("disposal not found")
+	// ErrItemSold は売却の記録があるアイテムを完全に削除しようとした場合のエラー
+	ErrItemSold = errors.New("item has been sold")
 )
 
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrItemNotFound) || errors.Is(err, ErrRevisionNotFound) || errors.Is(err, ErrCategoryNotFound) || errors.Is(err, ErrTagNotFound) ||
+		errors.Is(err, ErrLocationNotFound) || errors.Is(err, ErrDisposalNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -20,3 +43,7 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
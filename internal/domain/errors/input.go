@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// フィールド単位でない入力エラーのコード
+// 値の範囲・形式などフィールドと共通のものは validation.go のコード（CodeRequired など）を使う
+const (
+	// 更新する項目が1つも指定されていない
+	CodeNoFieldsToUpdate = "no_fields_to_update"
+	// 範囲外の値（params: name, min, max）
+	CodeOutOfRange = "out_of_range"
+	// 整数でない（params: name）
+	CodeNotInteger = "not_integer"
+	// true / false でない（params: name）
+	CodeNotBoolean = "not_boolean"
+	// 範囲の始まりが終わりより後になっている（params: from, to）
+	CodeInvalidRange = "invalid_range"
+	// 同時に指定できないパラメータを指定している（params: name, other）
+	CodeConflictingParams = "conflicting_params"
+	// カーソルの形式・署名が不正
+	CodeInvalidCursor = "invalid_cursor"
+	// カーソルを作成したときと並び順が異なる
+	CodeCursorSortMismatch = "cursor_sort_mismatch"
+	// カーソルを作成したときと絞り込み条件が異なる
+	CodeCursorFilterMismatch = "cursor_filter_mismatch"
+	// 並び替えに使えないフィールド（params: field）
+	CodeUnsupportedSortField = "unsupported_sort_field"
+	// 並び替えに同じフィールドを2回以上指定している（params: field）
+	CodeDuplicateSortField = "duplicate_sort_field"
+	// 関連度の並び替えに検索キーワードがない
+	CodeRelevanceRequiresKeyword = "relevance_requires_keyword"
+	// タグの絞り込みが長すぎる・多すぎる（params: max_length, max）
+	CodeInvalidTagFilter = "invalid_tag_filter"
+	// カスタム属性の絞り込みのキーが不正（params: key）
+	CodeInvalidAttributeFilter = "invalid_attribute_filter"
+	// 価格帯の区切りが0以上の昇順の整数でない（params: name）
+	CodeInvalidPriceBuckets = "invalid_price_buckets"
+	// 出力できない列（params: column）
+	CodeUnknownColumn = "unknown_column"
+	// 年に適用できる税のルールがない（params: year）
+	CodeNoTaxRule = "no_tax_rule"
+	// タグを自身に統合しようとしている
+	CodeMergeIntoSelf = "merge_into_self"
+	// If-Match に複数のエンティティタグを指定している
+	CodeMultipleEntityTags = "multiple_entity_tags"
+	// If-Match のエンティティタグが引用符で囲まれていない
+	CodeUnquotedEntityTag = "unquoted_entity_tag"
+	// インポートのマッピングが JSON のオブジェクトでない
+	CodeInvalidMapping = "invalid_mapping"
+	// マッピングにインポートできないフィールドがある（params: field）
+	CodeUnknownMappingField = "unknown_mapping_field"
+	// アップロードされたファイルを読み込めない（params: name）
+	CodeUnreadableFile = "unreadable_file"
+	// CSVにヘッダーもない
+	CodeEmptyCSV = "empty_csv"
+	// CSVの形式が不正（params: line, reason）
+	CodeInvalidCSV = "invalid_csv"
+	// CSVの行数が上限を超えている（params: max）
+	CodeTooManyRows = "too_many_rows"
+	// CSVにフィールドの列がない（params: column, field）
+	CodeMissingColumn = "missing_column"
+)
+
+// InputError はフィールド単位でない入力のエラー（更新する項目がない、クエリパラメータが不正など）
+// ValidationError と同じく、レスポンスではコードと params からリクエストの言語のメッセージを作る
+type InputError struct {
+	Code    string                 `json:"code"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	Message string                 `json:"message"`
+}
+
+func (e *InputError) Error() string {
+	return e.Message
+}
+
+// InputErrors は1回の検証で見つかったフィールド単位でないエラーの一覧
+// errors.Is(err, ErrInvalidInput) が true になるため、既存の IsValidationError でも判定できる
+type InputErrors []*InputError
+
+// Error は ErrInvalidInput の文言に続けてメッセージをカンマ区切りで連結して返す
+func (e InputErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, v := range e {
+		messages[i] = v.Message
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(messages, ", ")
+}
+
+func (e InputErrors) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// Add はエラーを追加する
+func (e *InputErrors) Add(code, message string, params map[string]interface{}) {
+	*e = append(*e, &InputError{Code: code, Params: params, Message: message})
+}
+
+// Err はエラーがあれば自身を、なければ nil を返す
+func (e InputErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// NewInputError はエラーが1件の InputErrors を返す
+func NewInputError(code, message string, params map[string]interface{}) error {
+	return InputErrors{{Code: code, Params: params, Message: message}}
+}
+
+// AsInputErrors は err に含まれるフィールド単位でないエラーを取り出す
+func AsInputErrors(err error) (InputErrors, bool) {
+	var errs InputErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+	return nil, false
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// バリデーションエラーのコード（クライアントがエラーの種類を判別するための固定値）
+const (
+	CodeRequired        = "required"         // 必須項目が未入力
+	CodeTooLong         = "too_long"         // 最大文字数を超えている（params: max）
+	CodeTooSmall        = "too_small"        // 最小値を下回っている（params: min）
+	CodeInvalidCategory = "invalid_category" // 定義されていないカテゴリー（params: allowed）
+	CodeInvalidFormat   = "invalid_format"   // 形式が不正（params: format）
+	// 制御文字や不正なUTF-8が含まれている
+	CodeInvalidCharacters = "invalid_characters"
+	// 親カテゴリーが存在しない、または自身・子孫のカテゴリーを親に指定している
+	CodeInvalidParent = "invalid_parent"
+	// 親に指定したカテゴリーにアイテムがある（アイテムは末端のカテゴリーにのみ設定できる）
+	CodeParentHasItems = "parent_has_items"
+	// 最大値を上回っている（params: max）
+	CodeTooLarge = "too_large"
+	// 値の型が定義と異なる（params: type）
+	CodeInvalidType = "invalid_type"
+	// 選択肢にない値（params: allowed）
+	CodeInvalidOption = "invalid_option"
+	// カテゴリーに定義されていないカスタム属性
+	CodeUnknownAttribute = "unknown_attribute"
+	// 同じキーが2回以上定義されている
+	CodeDuplicateKey = "duplicate_key"
+	// 指定できる個数を超えている（params: max）
+	CodeTooMany = "too_many"
+	// 保管場所の種類に合わない親を指定している（params: kind, allowed）
+	CodeInvalidParentKind = "invalid_parent_kind"
+	// 移動先の保管場所が存在しない、または現在の保管場所と同じ
+	CodeInvalidLocation = "invalid_location"
+	// 現在の状態から遷移できない状態を指定している（params: from, allowed）
+	CodeInvalidTransition = "invalid_transition"
+)
+
+// ValidationError はフィールド単位のバリデーションエラー
+type ValidationError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	Message string                 `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors は1回のバリデーションで見つかったエラーの一覧
+// errors.Is(err, ErrInvalidInput) が true になるため、既存の IsValidationError でも判定できる
+type ValidationErrors []*ValidationError
+
+// Error はメッセージをカンマ区切りで連結して返す
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, v := range e {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// Add はエラーを追加する
+func (e *ValidationErrors) Add(field, code, message string, params map[string]interface{}) {
+	*e = append(*e, &ValidationError{Field: field, Code: code, Params: params, Message: message})
+}
+
+// Err はエラーがあれば自身を、なければ nil を返す
+// （空の ValidationErrors をそのまま error として返すと nil にならないため）
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// AsValidationErrors は err に含まれるフィールド単位のエラーを取り出す
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+	return nil, false
+}
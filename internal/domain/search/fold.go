@@ -0,0 +1,77 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// 全文検索用のテキスト正規化（フォールディング）
+// 以下をまとめて行い、表記ゆれがあっても同じ文字列になるようにする
+//   - 全角/半角の統一（NFKC）: "ｴﾙﾒｽ" → "エルメス", "ＲＯＬＥＸ" → "ROLEX"
+//   - ダイアクリティカルマークの除去: "HERMÈS" → "HERMES"（濁点・半濁点は残す）
+//   - 大文字/小文字の統一: "HERMES" → "hermes"
+//   - ひらがな/カタカナの統一: "えるめす" → "エルメス"
+//   - 空白の統一と連続空白の圧縮
+
+// Fold は検索インデックス・検索語の両方に適用する正規化を行う
+func Fold(s string) string {
+	var b strings.Builder
+	lastSpace := true
+
+	for _, r := range []rune(foldMarks(norm.NFKC.String(s))) {
+		r = foldRune(r)
+		if unicode.IsSpace(r) {
+			if !lastSpace {
+				b.WriteRune(' ')
+			}
+			lastSpace = true
+			continue
+		}
+		b.WriteRune(r)
+		lastSpace = false
+	}
+
+	return strings.TrimRight(b.String(), " ")
+}
+
+// Terms は検索クエリを正規化し、空白区切りの検索語に分割する
+// MySQLの BOOLEAN MODE で演算子として解釈される記号は取り除く
+func Terms(query string) []string {
+	cleaned := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`+-<>()~*"@`, r) {
+			return ' '
+		}
+		return r
+	}, Fold(query))
+
+	return strings.Fields(cleaned)
+}
+
+// foldMarks は濁点・半濁点以外の結合文字（アクセント記号など）を取り除く
+func foldMarks(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.Predicate(isStrippableMark)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return folded
+}
+
+func isStrippableMark(r rune) bool {
+	// U+3099, U+309A は結合用の濁点・半濁点（"ガ" = "カ" + U+3099）
+	return unicode.Is(unicode.Mn, r) && r != '゙' && r != '゚'
+}
+
+// foldRune は1文字単位の正規化（小文字化・ひらがなのカタカナ化）を行う
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	// ひらがな（ぁ〜ゖ）はコードポイントを0x60ずらすと対応するカタカナになる
+	if r >= 'ぁ' && r <= 'ゖ' {
+		r += 0x60
+	}
+	return r
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"ダイアクリティカルマークの除去", "HERMÈS", "hermes"},
+		{"大文字小文字の統一", "Hermes", "hermes"},
+		{"半角カタカナの全角化", "ｴﾙﾒｽ", "エルメス"},
+		{"半角カタカナの濁点結合", "ﾃﾞｲﾄﾅ", "デイトナ"},
+		{"ひらがなのカタカナ化", "でいとな", "デイトナ"},
+		{"全角英数字の半角化", "ＲＯＬＥＸ　１６５２０", "rolex 16520"},
+		{"濁点・半濁点は残す", "バッグ パンプス", "バッグ パンプス"},
+		{"連続空白の圧縮と前後の空白除去", "  ロレックス \t デイトナ  ", "ロレックス デイトナ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Fold(tt.input))
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"hermes", "バーキン"}, Terms(`+"HERMÈS" -ﾊﾞｰｷﾝ*`))
+	assert.Empty(t, Terms("  ()  "))
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		query  string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "アクセント付きの原文を強調",
+			text:   "HERMÈS",
+			query:  "hermes",
+			want:   "<mark>HERMÈS</mark>",
+			wantOK: true,
+		},
+		{
+			name:   "半角カナの検索語で全角の原文を強調",
+			text:   "エルメス バーキン",
+			query:  "ｴﾙﾒｽ",
+			want:   "<mark>エルメス</mark> バーキン",
+			wantOK: true,
+		},
+		{
+			name:   "半角カナの原文は濁点ごと強調",
+			text:   "ﾃﾞｲﾄﾅ 16520",
+			query:  "デイ",
+			want:   "<mark>ﾃﾞｲ</mark>ﾄﾅ 16520",
+			wantOK: true,
+		},
+		{
+			name:   "複数の検索語",
+			text:   "ロレックス デイトナ",
+			query:  "ロレ トナ",
+			want:   "<mark>ロレ</mark>ックス デイ<mark>トナ</mark>",
+			wantOK: true,
+		},
+		{
+			name:   "HTMLはエスケープする",
+			text:   "Tiffany & Co.",
+			query:  "tiffany",
+			want:   "<mark>Tiffany</mark> &amp; Co.",
+			wantOK: true,
+		},
+		{
+			name:   "一致なし",
+			text:   "ROLEX",
+			query:  "omega",
+			want:   "ROLEX",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Highlight(tt.text, Terms(tt.query), "<mark>", "</mark>")
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
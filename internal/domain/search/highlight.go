@@ -0,0 +1,101 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Highlight は text のうち検索語（Terms で正規化済み）に一致した部分を open / close で囲んで返す
+// 一致判定は Fold 後の文字列で行い、囲む位置は元の文字列に対応付ける
+// タグ以外の部分はHTMLエスケープする。一致する箇所がない場合は ok=false を返す
+func Highlight(text string, terms []string, open, close string) (string, bool) {
+	original := []rune(text)
+	clusters := splitClusters(original)
+
+	// 正規化後の各文字が元のどのクラスタから来たかを記録する
+	var folded []rune
+	var owner []int
+	for i, c := range clusters {
+		for _, r := range Fold(string(original[c.start:c.end])) {
+			folded = append(folded, r)
+			owner = append(owner, i)
+		}
+	}
+
+	marked := make([]bool, len(original))
+	found := false
+	for _, term := range terms {
+		needle := []rune(term)
+		if len(needle) == 0 {
+			continue
+		}
+		for pos := 0; pos+len(needle) <= len(folded); pos++ {
+			if !hasPrefixRunes(folded[pos:], needle) {
+				continue
+			}
+			found = true
+			first := clusters[owner[pos]]
+			last := clusters[owner[pos+len(needle)-1]]
+			for j := first.start; j < last.end; j++ {
+				marked[j] = true
+			}
+			pos += len(needle) - 1
+		}
+	}
+
+	if !found {
+		return html.EscapeString(text), false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(original); {
+		j := i
+		for j < len(original) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(original[i:j]))
+		if marked[i] {
+			b.WriteString(open + segment + close)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+
+	return b.String(), true
+}
+
+type cluster struct {
+	start, end int
+}
+
+// splitClusters は結合文字や半角の濁点・半濁点を直前の文字とまとめて1つのクラスタにする
+// （"ｶﾞ" や "E" + U+0300 を分割して強調しないため）
+func splitClusters(rs []rune) []cluster {
+	var clusters []cluster
+	for i, r := range rs {
+		if len(clusters) > 0 && isContinuation(r) {
+			clusters[len(clusters)-1].end = i + 1
+			continue
+		}
+		clusters = append(clusters, cluster{start: i, end: i + 1})
+	}
+	return clusters
+}
+
+func isContinuation(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || r == 'ﾞ' || r == 'ﾟ'
+}
+
+func hasPrefixRunes(s, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
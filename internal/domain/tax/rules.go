@@ -0,0 +1,110 @@
+package tax
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// 生活用動産の譲渡所得（総合課税）の計算ルール
+// 基準額・控除額などは年ごとのルール表（JSON）で定義し、コードを変えずに更新できるようにする
+//   - 生活に通常必要な動産の売却は非課税。貴金属・宝石・時計などで1個の価額が基準額を超えるものは課税対象
+//   - 保有期間が基準年数を超えるものは長期、それ以外は短期
+//   - 特別控除は短期・長期の合計で一定額まで（短期から先に控除する）
+//   - 長期の譲渡所得は一部（通常は1/2）のみを総所得金額に算入する
+
+//go:embed default_rules.json
+var defaultRules []byte
+
+// Rule はある年以降に適用する譲渡所得の計算ルール
+type Rule struct {
+	EffectiveFrom          int      `json:"effective_from"`            // 適用する最初の年
+	TaxableCategories      []string `json:"taxable_categories"`        // 課税対象のカテゴリーのコード（子カテゴリーを含む）
+	MinTaxablePrice        int      `json:"min_taxable_price"`         // 1個の売却価格がこの金額を超える場合に課税対象
+	LongTermYears          int      `json:"long_term_years"`           // 保有期間がこの年数を超える場合は長期
+	SpecialDeduction       int      `json:"special_deduction"`         // 特別控除額（年間の上限）
+	LongTermTaxablePercent int      `json:"long_term_taxable_percent"` // 長期の譲渡所得のうち総所得金額に算入する割合（%）
+	EstimatedCostPercent   int      `json:"estimated_cost_percent"`    // 概算取得費の割合（%）。購入価格より大きい場合はこちらを取得費にする
+}
+
+// Rules は適用開始年の昇順に並んだルール表
+type Rules struct {
+	rules []Rule
+}
+
+// ParseRules は {"rules": [...]} 形式のJSONからルール表を作成する
+func ParseRules(data []byte) (*Rules, error) {
+	var doc struct {
+		Rules []Rule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid tax rules: %w", err)
+	}
+	if len(doc.Rules) == 0 {
+		return nil, fmt.Errorf("invalid tax rules: rules must not be empty")
+	}
+
+	seen := make(map[int]bool, len(doc.Rules))
+	for i, rule := range doc.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid tax rules: rules[%d]: %w", i, err)
+		}
+		if seen[rule.EffectiveFrom] {
+			return nil, fmt.Errorf("invalid tax rules: rules[%d]: effective_from %d is duplicated", i, rule.EffectiveFrom)
+		}
+		seen[rule.EffectiveFrom] = true
+	}
+
+	sort.Slice(doc.Rules, func(i, j int) bool { return doc.Rules[i].EffectiveFrom < doc.Rules[j].EffectiveFrom })
+	return &Rules{rules: doc.Rules}, nil
+}
+
+// DefaultRules は組み込みのルール表を返す
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultRules)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// For は year に適用するルール（適用開始年が year 以前で最も新しいもの）を返す
+func (r *Rules) For(year int) (Rule, bool) {
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if r.rules[i].EffectiveFrom <= year {
+			return r.rules[i], true
+		}
+	}
+	return Rule{}, false
+}
+
+func (r Rule) validate() error {
+	switch {
+	case r.EffectiveFrom < 1:
+		return fmt.Errorf("effective_from must be 1 or greater")
+	case r.MinTaxablePrice < 0:
+		return fmt.Errorf("min_taxable_price must be 0 or greater")
+	case r.LongTermYears < 0:
+		return fmt.Errorf("long_term_years must be 0 or greater")
+	case r.SpecialDeduction < 0:
+		return fmt.Errorf("special_deduction must be 0 or greater")
+	case r.LongTermTaxablePercent < 0 || r.LongTermTaxablePercent > 100:
+		return fmt.Errorf("long_term_taxable_percent must be between 0 and 100")
+	case r.EstimatedCostPercent < 0 || r.EstimatedCostPercent > 100:
+		return fmt.Errorf("estimated_cost_percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// isTaxableCategory はカテゴリー（親から順のコード）のいずれかが課税対象かを返す
+func (r Rule) isTaxableCategory(path []string) bool {
+	for _, code := range path {
+		for _, taxable := range r.TaxableCategories {
+			if code == taxable {
+				return true
+			}
+		}
+	}
+	return false
+}
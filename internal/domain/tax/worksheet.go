@@ -0,0 +1,166 @@
+package tax
+
+import (
+	"fmt"
+	"time"
+)
+
+// Term は保有期間による区分
+type Term string
+
+const (
+	TermShort Term = "short" // 短期（保有期間が基準年数以下）
+	TermLong  Term = "long"  // 長期（保有期間が基準年数を超える）
+)
+
+// 課税対象外の理由
+const (
+	ExemptCategory       = "non_taxable_category" // 課税対象のカテゴリーではない（生活用動産）
+	ExemptBelowThreshold = "below_threshold"      // 1個の売却価格が基準額以下
+)
+
+// Disposal はワークシートに載せる1件の売却
+type Disposal struct {
+	ItemID        int64
+	Name          string
+	Category      string
+	CategoryPath  []string // 最上位からアイテムのカテゴリーまでのコード
+	PurchaseDate  string   // YYYY-MM-DD 形式
+	PurchasePrice int
+	SoldOn        string // YYYY-MM-DD 形式
+	SalePrice     int
+	Fees          int
+}
+
+// Line はワークシートの1行（1件の売却）
+type Line struct {
+	ItemID        int64  `json:"item_id"`
+	Name          string `json:"name"`
+	Category      string `json:"category"`
+	PurchaseDate  string `json:"purchase_date"`
+	SoldOn        string `json:"sold_on"`
+	Term          Term   `json:"term"`
+	Taxable       bool   `json:"taxable"`
+	ExemptReason  string `json:"exempt_reason,omitempty"`
+	SalePrice     int    `json:"sale_price"`     // 総収入金額
+	Cost          int    `json:"cost"`           // 取得費
+	EstimatedCost bool   `json:"estimated_cost"` // 取得費に概算取得費を使ったか
+	Fees          int    `json:"fees"`           // 譲渡費用
+	Gain          int    `json:"gain"`           // 譲渡損益（総収入金額 - 取得費 - 譲渡費用）
+}
+
+// TermTotal は短期・長期それぞれの課税対象の合計
+type TermTotal struct {
+	Count     int `json:"count"`
+	SalePrice int `json:"sale_price"`
+	Cost      int `json:"cost"`
+	Fees      int `json:"fees"`
+	Gain      int `json:"gain"`      // 譲渡損益の合計
+	NetGain   int `json:"net_gain"`  // 短期・長期の間で損益を通算した後の譲渡益（損失は0）
+	Deduction int `json:"deduction"` // 特別控除額
+	Income    int `json:"income"`    // 譲渡所得の金額（NetGain - Deduction）
+}
+
+// Worksheet は1年分の譲渡所得の計算書
+type Worksheet struct {
+	Year      int       `json:"year"`
+	Rule      Rule      `json:"rule"`
+	Lines     []*Line   `json:"lines"`
+	ShortTerm TermTotal `json:"short_term"`
+	LongTerm  TermTotal `json:"long_term"`
+	// TaxableIncome は総所得金額に算入する金額（短期の所得 + 長期の所得 × 算入割合）
+	TaxableIncome int `json:"taxable_income"`
+}
+
+// Worksheet は year の売却から譲渡所得の計算書を作成する
+// 課税対象外の売却も理由とともに行に含めるが、合計には含めない（生活用動産の損失は通算できない）
+func (r Rule) Worksheet(year int, disposals []Disposal) (*Worksheet, error) {
+	ws := &Worksheet{Year: year, Rule: r, Lines: []*Line{}}
+
+	for _, d := range disposals {
+		line, err := r.line(d)
+		if err != nil {
+			return nil, err
+		}
+		ws.Lines = append(ws.Lines, line)
+		if !line.Taxable {
+			continue
+		}
+
+		total := &ws.ShortTerm
+		if line.Term == TermLong {
+			total = &ws.LongTerm
+		}
+		total.Count++
+		total.SalePrice += line.SalePrice
+		total.Cost += line.Cost
+		total.Fees += line.Fees
+		total.Gain += line.Gain
+	}
+
+	// 短期・長期の一方が損失の場合は、もう一方の譲渡益から差し引く
+	short, long := ws.ShortTerm.Gain, ws.LongTerm.Gain
+	if short < 0 {
+		long += short
+		short = 0
+	} else if long < 0 {
+		short += long
+		long = 0
+	}
+	ws.ShortTerm.NetGain = max(short, 0)
+	ws.LongTerm.NetGain = max(long, 0)
+
+	// 特別控除は短期の譲渡益から先に差し引く
+	ws.ShortTerm.Deduction = min(r.SpecialDeduction, ws.ShortTerm.NetGain)
+	ws.LongTerm.Deduction = min(r.SpecialDeduction-ws.ShortTerm.Deduction, ws.LongTerm.NetGain)
+	ws.ShortTerm.Income = ws.ShortTerm.NetGain - ws.ShortTerm.Deduction
+	ws.LongTerm.Income = ws.LongTerm.NetGain - ws.LongTerm.Deduction
+
+	ws.TaxableIncome = ws.ShortTerm.Income + ws.LongTerm.Income*r.LongTermTaxablePercent/100
+	return ws, nil
+}
+
+// line は1件の売却の保有期間の区分・課税対象かどうか・譲渡損益を求める
+func (r Rule) line(d Disposal) (*Line, error) {
+	purchased, err := time.Parse("2006-01-02", d.PurchaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("item %d: invalid purchase_date %q", d.ItemID, d.PurchaseDate)
+	}
+	sold, err := time.Parse("2006-01-02", d.SoldOn)
+	if err != nil {
+		return nil, fmt.Errorf("item %d: invalid sold_on %q", d.ItemID, d.SoldOn)
+	}
+
+	line := &Line{
+		ItemID:       d.ItemID,
+		Name:         d.Name,
+		Category:     d.Category,
+		PurchaseDate: d.PurchaseDate,
+		SoldOn:       d.SoldOn,
+		Term:         TermShort,
+		Taxable:      true,
+		SalePrice:    d.SalePrice,
+		Cost:         d.PurchasePrice,
+		Fees:         d.Fees,
+	}
+
+	// 保有期間は購入日から売却日まで（基準年数を経過した日の翌日以降の売却が長期）
+	if sold.After(purchased.AddDate(r.LongTermYears, 0, 0)) {
+		line.Term = TermLong
+	}
+
+	if !r.isTaxableCategory(d.CategoryPath) {
+		line.Taxable = false
+		line.ExemptReason = ExemptCategory
+	} else if d.SalePrice <= r.MinTaxablePrice {
+		line.Taxable = false
+		line.ExemptReason = ExemptBelowThreshold
+	}
+
+	if estimated := d.SalePrice * r.EstimatedCostPercent / 100; estimated > line.Cost {
+		line.Cost = estimated
+		line.EstimatedCost = true
+	}
+	line.Gain = line.SalePrice - line.Cost - line.Fees
+	return line, nil
+}
@@ -0,0 +1,206 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule() Rule {
+	return Rule{
+		EffectiveFrom:          1989,
+		TaxableCategories:      []string{"時計", "ジュエリー"},
+		MinTaxablePrice:        300000,
+		LongTermYears:          5,
+		SpecialDeduction:       500000,
+		LongTermTaxablePercent: 50,
+		EstimatedCostPercent:   5,
+	}
+}
+
+func TestRule_Line(t *testing.T) {
+	tests := []struct {
+		name        string
+		disposal    Disposal
+		wantTerm    Term
+		wantTaxable bool
+		wantReason  string
+		wantCost    int
+		wantGain    int
+	}{
+		{
+			name:        "正常系: 5年以内の売却は短期",
+			disposal:    Disposal{CategoryPath: []string{"時計"}, PurchaseDate: "2020-04-01", PurchasePrice: 1000000, SoldOn: "2025-04-01", SalePrice: 1500000, Fees: 100000},
+			wantTerm:    TermShort,
+			wantTaxable: true,
+			wantCost:    1000000,
+			wantGain:    400000,
+		},
+		{
+			name:        "正常系: 5年を超える売却は長期",
+			disposal:    Disposal{CategoryPath: []string{"時計"}, PurchaseDate: "2020-04-01", PurchasePrice: 1000000, SoldOn: "2025-04-02", SalePrice: 1500000},
+			wantTerm:    TermLong,
+			wantTaxable: true,
+			wantCost:    1000000,
+			wantGain:    500000,
+		},
+		{
+			name:        "正常系: 親カテゴリーが課税対象",
+			disposal:    Disposal{CategoryPath: []string{"ジュエリー", "リング"}, PurchaseDate: "2024-01-01", PurchasePrice: 200000, SoldOn: "2024-06-01", SalePrice: 400000},
+			wantTerm:    TermShort,
+			wantTaxable: true,
+			wantCost:    200000,
+			wantGain:    200000,
+		},
+		{
+			name:        "正常系: 購入価格が概算取得費より小さい場合は概算取得費",
+			disposal:    Disposal{CategoryPath: []string{"時計"}, PurchaseDate: "2024-01-01", PurchasePrice: 0, SoldOn: "2024-06-01", SalePrice: 1000000},
+			wantTerm:    TermShort,
+			wantTaxable: true,
+			wantCost:    50000,
+			wantGain:    950000,
+		},
+		{
+			name:       "正常系: 課税対象外のカテゴリー",
+			disposal:   Disposal{CategoryPath: []string{"バッグ", "トート"}, PurchaseDate: "2024-01-01", PurchasePrice: 500000, SoldOn: "2024-06-01", SalePrice: 800000},
+			wantTerm:   TermShort,
+			wantReason: ExemptCategory,
+			wantCost:   500000,
+			wantGain:   300000,
+		},
+		{
+			name:       "正常系: 売却価格が基準額以下",
+			disposal:   Disposal{CategoryPath: []string{"時計"}, PurchaseDate: "2024-01-01", PurchasePrice: 100000, SoldOn: "2024-06-01", SalePrice: 300000},
+			wantTerm:   TermShort,
+			wantReason: ExemptBelowThreshold,
+			wantCost:   100000,
+			wantGain:   200000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := testRule().line(tt.disposal)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTerm, line.Term)
+			assert.Equal(t, tt.wantTaxable, line.Taxable)
+			assert.Equal(t, tt.wantReason, line.ExemptReason)
+			assert.Equal(t, tt.wantCost, line.Cost)
+			assert.Equal(t, tt.wantGain, line.Gain)
+		})
+	}
+
+	t.Run("異常系: 日付の形式が不正", func(t *testing.T) {
+		_, err := testRule().line(Disposal{PurchaseDate: "2024/01/01", SoldOn: "2024-06-01"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRule_Worksheet(t *testing.T) {
+	watch := func(purchaseDate string, cost, salePrice int) Disposal {
+		return Disposal{Category: "時計", CategoryPath: []string{"時計"}, PurchaseDate: purchaseDate, PurchasePrice: cost, SoldOn: "2024-06-01", SalePrice: salePrice}
+	}
+
+	tests := []struct {
+		name          string
+		disposals     []Disposal
+		wantShort     TermTotal
+		wantLong      TermTotal
+		wantTaxable   int
+		wantLineCount int
+	}{
+		{
+			name:          "正常系: 特別控除は短期から先に差し引く",
+			disposals:     []Disposal{watch("2023-01-01", 1000000, 1400000), watch("2010-01-01", 1000000, 2000000)},
+			wantShort:     TermTotal{Count: 1, SalePrice: 1400000, Cost: 1000000, Gain: 400000, NetGain: 400000, Deduction: 400000, Income: 0},
+			wantLong:      TermTotal{Count: 1, SalePrice: 2000000, Cost: 1000000, Gain: 1000000, NetGain: 1000000, Deduction: 100000, Income: 900000},
+			wantTaxable:   450000,
+			wantLineCount: 2,
+		},
+		{
+			name:          "正常系: 短期の損失を長期の譲渡益から差し引く",
+			disposals:     []Disposal{watch("2023-01-01", 1500000, 1000000), watch("2010-01-01", 1000000, 2500000)},
+			wantShort:     TermTotal{Count: 1, SalePrice: 1000000, Cost: 1500000, Gain: -500000},
+			wantLong:      TermTotal{Count: 1, SalePrice: 2500000, Cost: 1000000, Gain: 1500000, NetGain: 1000000, Deduction: 500000, Income: 500000},
+			wantTaxable:   250000,
+			wantLineCount: 2,
+		},
+		{
+			name: "正常系: 課税対象外の売却は合計に含めない",
+			disposals: []Disposal{
+				watch("2023-01-01", 100000, 1100000),
+				{Category: "トート", CategoryPath: []string{"バッグ", "トート"}, PurchaseDate: "2023-01-01", PurchasePrice: 2000000, SoldOn: "2024-06-01", SalePrice: 500000},
+			},
+			wantShort:     TermTotal{Count: 1, SalePrice: 1100000, Cost: 100000, Gain: 1000000, NetGain: 1000000, Deduction: 500000, Income: 500000},
+			wantTaxable:   500000,
+			wantLineCount: 2,
+		},
+		{
+			name:          "正常系: 売却がない場合は0",
+			disposals:     nil,
+			wantLineCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, err := testRule().Worksheet(2024, tt.disposals)
+
+			require.NoError(t, err)
+			assert.Equal(t, 2024, ws.Year)
+			assert.Len(t, ws.Lines, tt.wantLineCount)
+			assert.Equal(t, tt.wantShort, ws.ShortTerm)
+			assert.Equal(t, tt.wantLong, ws.LongTerm)
+			assert.Equal(t, tt.wantTaxable, ws.TaxableIncome)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	t.Run("正常系: 適用開始年が最も新しいルールを使う", func(t *testing.T) {
+		rules, err := ParseRules([]byte(`{"rules": [
+			{"effective_from": 2030, "taxable_categories": ["時計"], "min_taxable_price": 500000, "long_term_years": 5, "special_deduction": 300000, "long_term_taxable_percent": 50},
+			{"effective_from": 1989, "taxable_categories": ["時計"], "min_taxable_price": 300000, "long_term_years": 5, "special_deduction": 500000, "long_term_taxable_percent": 50}
+		]}`))
+		require.NoError(t, err)
+
+		rule, ok := rules.For(2024)
+		require.True(t, ok)
+		assert.Equal(t, 300000, rule.MinTaxablePrice)
+
+		rule, ok = rules.For(2031)
+		require.True(t, ok)
+		assert.Equal(t, 500000, rule.MinTaxablePrice)
+
+		_, ok = rules.For(1980)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 組み込みのルール", func(t *testing.T) {
+		rule, ok := DefaultRules().For(2024)
+
+		require.True(t, ok)
+		assert.Equal(t, 500000, rule.SpecialDeduction)
+	})
+
+	errorTests := []struct {
+		name string
+		data string
+	}{
+		{name: "異常系: JSONの形式が不正", data: `{"rules": [`},
+		{name: "異常系: ルールが空", data: `{"rules": []}`},
+		{name: "異常系: 算入割合が範囲外", data: `{"rules": [{"effective_from": 1989, "long_term_taxable_percent": 150}]}`},
+		{name: "異常系: 適用開始年が重複", data: `{"rules": [{"effective_from": 1989}, {"effective_from": 1989}]}`},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.data))
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"crypto/rand"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +17,29 @@ var (
 	DBHost     string
 	DBName     string
 	DBPort     string
+
+	// 起動時に未適用のマイグレーションを適用するか（デフォルト: true）
+	DBAutoMigrate bool
+	// 起動時にサンプルデータを登録するか（デフォルト: false）
+	DBSeed bool
+
+	// ページングカーソルの署名に使う秘密鍵
+	CursorSecret []byte
+
+	// ファセット集計の価格帯の区切り（カンマ区切りの円の金額、未設定の場合はデフォルト値）
+	FacetPriceBuckets string
+
+	// ゴミ箱のアイテムを自動で完全削除するまでの日数（デフォルト: 30、0 の場合は自動削除しない）
+	TrashRetentionDays int
+	// ゴミ箱の自動削除を実行する間隔（デフォルト: 1時間）
+	TrashSweepInterval time.Duration
+
+	// カテゴリーのキャッシュを読み込み直す間隔（デフォルト: 1分）
+	// 他のサーバーで変更したカテゴリーは最大でこの時間だけ遅れて反映される
+	CategoryCacheTTL time.Duration
+
+	// 譲渡所得の計算ルール表（JSON）のパス（未設定の場合は組み込みのルール表）
+	TaxRulesFile string
 )
 
 func init() {
@@ -27,6 +53,27 @@ func init() {
 	DBHost = os.Getenv("DB_HOST")
 	DBPort = os.Getenv("DB_PORT")
 	DBName = os.Getenv("DB_NAME")
+	DBAutoMigrate = getEnvBool("DB_AUTO_MIGRATE", true)
+	DBSeed = getEnvBool("DB_SEED", false)
+
+	FacetPriceBuckets = os.Getenv("FACET_PRICE_BUCKETS")
+
+	TrashRetentionDays = getEnvInt("TRASH_RETENTION_DAYS", 30)
+	TrashSweepInterval = getEnvDuration("TRASH_SWEEP_INTERVAL", time.Hour)
+
+	CategoryCacheTTL = getEnvDuration("CATEGORY_CACHE_TTL", time.Minute)
+
+	TaxRulesFile = os.Getenv("TAX_RULES_FILE")
+
+	CursorSecret = []byte(os.Getenv("CURSOR_SECRET"))
+	if len(CursorSecret) == 0 {
+		// 未設定の場合は起動ごとにランダムな鍵を使う（再起動すると発行済みのカーソルは無効になる）
+		log.Println("⚠️  CURSOR_SECRETが設定されていないため、ランダムな鍵を使用します。")
+		CursorSecret = make([]byte, 32)
+		if _, err := rand.Read(CursorSecret); err != nil {
+			log.Fatalf("Failed to generate cursor secret: %v", err)
+		}
+	}
 }
 
 // DB接続文字列を返す
@@ -36,3 +83,30 @@ func GetDSN() string {
 		DBUser, DBPassword, DBHost, DBPort, DBName,
 	)
 }
+
+// getEnvBool は真偽値の環境変数を取得する（未設定・不正な値の場合は defaultValue）
+func getEnvBool(key string, defaultValue bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+// getEnvInt は整数の環境変数を取得する（未設定・不正な値の場合は defaultValue）
+func getEnvInt(key string, defaultValue int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+// getEnvDuration は "30m" や "1h" 形式の環境変数を取得する（未設定・不正な値の場合は defaultValue）
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || v <= 0 {
+		return defaultValue
+	}
+	return v
+}
@@ -1,8 +1,5 @@
--- データベースの文字セットを明示的に設定
-SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
-SET CHARACTER SET utf8mb4;
-
 -- Create items table for managing valuable items and collections
+-- 以前の sql/init.sql で作成したテーブルがある場合は何もしない（不足している列は 0012 以降で追加する）
 CREATE TABLE IF NOT EXISTS items (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
     name VARCHAR(100) NOT NULL COMMENT 'Item name',
@@ -12,17 +9,11 @@ CREATE TABLE IF NOT EXISTS items (
     purchase_date DATE NOT NULL COMMENT 'Purchase date in YYYY-MM-DD format',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
-    
+    search_text VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Normalized name and brand for full-text search',
+
     INDEX idx_category (category),
     INDEX idx_brand (brand),
     INDEX idx_purchase_date (purchase_date),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    FULLTEXT INDEX ft_search_text (search_text) WITH PARSER ngram
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
-
--- Insert sample data for testing
-INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES
-('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),
-('エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20'),
-('ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10'),
-('ルブタン パンプス', '靴', 'Christian Louboutin', 150000, '2023-04-05'),
-('アップルウォッチ', 'その他', 'Apple', 50000, '2023-05-12');
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- 楽観的排他制御用のバージョン（更新のたびに1ずつ増える）
+ALTER TABLE items
+    ADD COLUMN version INT NOT NULL DEFAULT 1 COMMENT 'Optimistic lock version, incremented on every update' AFTER updated_at;
@@ -0,0 +1,5 @@
+-- ゴミ箱にあるアイテムは削除してからカラムを取り除く
+DELETE FROM items WHERE deleted_at IS NOT NULL;
+ALTER TABLE items
+    DROP INDEX idx_deleted_at,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- 論理削除（ゴミ箱）用の削除日時（NULL の場合は削除されていない）
+ALTER TABLE items
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp, NULL while the item is active' AFTER version,
+    ADD INDEX idx_deleted_at (deleted_at);
@@ -0,0 +1,15 @@
+-- アイテムの変更履歴（登録・更新・削除・復元・差し戻しのたびに1行追加する）
+-- アイテムを完全に削除（purge）した場合は履歴も削除する
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Revised item',
+    revision INT NOT NULL COMMENT 'Sequential revision number per item, starting at 1',
+    action VARCHAR(20) NOT NULL COMMENT 'create, update, delete, restore or revert',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who made the change (X-Actor header)',
+    snapshot JSON NOT NULL COMMENT 'Full item after the change',
+    changes JSON NOT NULL COMMENT 'Changed fields with before and after values',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the change was made',
+
+    UNIQUE KEY uq_item_revision (item_id, revision),
+    CONSTRAINT fk_item_revisions_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Change history of items';
@@ -0,0 +1,2 @@
+ALTER TABLE items DROP FOREIGN KEY fk_items_category;
+DROP TABLE IF EXISTS categories;
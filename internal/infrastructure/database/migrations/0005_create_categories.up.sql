@@ -0,0 +1,30 @@
+-- アイテムのカテゴリー（これまでコードに固定で定義していた値をテーブルで管理する）
+-- items.category には code を保存する。code は変更できない
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(50) NOT NULL COMMENT 'Value stored in items.category',
+    name_ja VARCHAR(100) NOT NULL COMMENT 'Display name in Japanese',
+    name_en VARCHAR(100) NOT NULL COMMENT 'Display name in English',
+    sort_order INT NOT NULL DEFAULT 0 COMMENT 'Display order, ascending',
+    active BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Inactive categories cannot be assigned to items',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uq_categories_code (code),
+    INDEX idx_sort_order (sort_order)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Item categories';
+
+INSERT IGNORE INTO categories (code, name_ja, name_en, sort_order) VALUES
+    ('時計', '時計', 'Watches', 10),
+    ('バッグ', 'バッグ', 'Bags', 20),
+    ('ジュエリー', 'ジュエリー', 'Jewelry', 30),
+    ('靴', '靴', 'Shoes', 40),
+    ('その他', 'その他', 'Other', 50);
+
+-- 既存のアイテムが使っている未定義のカテゴリーも登録し、外部キーを張れるようにする
+INSERT IGNORE INTO categories (code, name_ja, name_en, sort_order)
+    SELECT DISTINCT category, category, category, 900 FROM items;
+
+-- 使用中のカテゴリーは削除できない
+ALTER TABLE items
+    ADD CONSTRAINT fk_items_category FOREIGN KEY (category) REFERENCES categories (code) ON UPDATE RESTRICT ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+ALTER TABLE categories DROP FOREIGN KEY fk_categories_parent;
+ALTER TABLE categories DROP COLUMN parent_id;
@@ -0,0 +1,5 @@
+-- カテゴリーの親子関係（parent_id が NULL のカテゴリーは最上位）
+-- 子カテゴリーがあるカテゴリーは削除できない
+ALTER TABLE categories
+    ADD COLUMN parent_id BIGINT NULL COMMENT 'Parent category, NULL for top-level categories' AFTER code,
+    ADD CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories (id) ON UPDATE RESTRICT ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+ALTER TABLE items DROP COLUMN attributes;
+ALTER TABLE categories DROP COLUMN attribute_schema;
@@ -0,0 +1,13 @@
+-- カテゴリーごとのカスタム属性
+-- categories.attribute_schema に属性の定義（型・必須・選択肢・範囲）を、items.attributes に値を JSON で保存する
+ALTER TABLE categories
+    ADD COLUMN attribute_schema JSON NULL COMMENT 'Custom attribute definitions for items in this category' AFTER active;
+
+ALTER TABLE items
+    ADD COLUMN attributes JSON NULL COMMENT 'Custom attribute values validated against the category schema' AFTER purchase_date;
+
+-- 初期のカテゴリーの属性（既存のアイテムが検証で失敗しないよう、すべて任意とする）
+UPDATE categories SET attribute_schema = '[{"key":"reference_number","type":"string","max_length":50},{"key":"movement","type":"enum","options":["automatic","manual","quartz"]},{"key":"case_size_mm","type":"number","min":10,"max":70}]'
+    WHERE code = '時計' AND attribute_schema IS NULL;
+UPDATE categories SET attribute_schema = '[{"key":"size","type":"enum","options":["mini","small","medium","large"]},{"key":"color","type":"string","max_length":50},{"key":"has_dust_bag","type":"boolean"}]'
+    WHERE code = 'バッグ' AND attribute_schema IS NULL;
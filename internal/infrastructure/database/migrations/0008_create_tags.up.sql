@@ -0,0 +1,22 @@
+-- アイテムに自由に付けるタグ（多対多）
+-- タグ名の比較は照合順序に従い、大文字・小文字を区別しない
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Tag name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uq_tags_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Free-form tags for items';
+
+-- アイテムとタグの対応（アイテムを完全に削除した場合・タグを統合した場合は行も削除する）
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Tagged item',
+    tag_id BIGINT NOT NULL COMMENT 'Assigned tag',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the tag was assigned',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_item_tags_tag (tag_id),
+    CONSTRAINT fk_item_tags_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags assigned to items';
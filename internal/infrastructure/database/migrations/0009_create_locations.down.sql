@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS item_moves;
+ALTER TABLE items DROP FOREIGN KEY fk_items_location;
+ALTER TABLE items DROP COLUMN location_id;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,36 @@
+-- アイテムの保管場所（建物 > 部屋 > 収納の階層。parent_id が NULL の保管場所は最上位）
+-- 中に保管場所がある保管場所・アイテムが置かれている保管場所は削除できない
+CREATE TABLE IF NOT EXISTS locations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    parent_id BIGINT NULL COMMENT 'Enclosing location, NULL for top-level locations',
+    name VARCHAR(100) NOT NULL COMMENT 'Display name',
+    kind VARCHAR(20) NOT NULL COMMENT 'building, room or container',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_locations_parent (parent_id),
+    CONSTRAINT fk_locations_parent FOREIGN KEY (parent_id) REFERENCES locations (id) ON UPDATE RESTRICT ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Storage locations of items';
+
+-- アイテムの現在の保管場所（POST /items/{id}/move でのみ変更する）
+ALTER TABLE items
+    ADD COLUMN location_id BIGINT NULL COMMENT 'Current storage location' AFTER attributes,
+    ADD CONSTRAINT fk_items_location FOREIGN KEY (location_id) REFERENCES locations (id) ON UPDATE RESTRICT ON DELETE RESTRICT;
+
+-- アイテムの移動履歴（アイテムを完全に削除した場合は履歴も削除する）
+-- 保管場所を削除した場合、その保管場所を指していた列は NULL になる
+CREATE TABLE IF NOT EXISTS item_moves (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Moved item',
+    from_location_id BIGINT NULL COMMENT 'Location before the move, NULL if the item had no location',
+    to_location_id BIGINT NULL COMMENT 'Location after the move',
+    note VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Free-form note',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who recorded the move (X-Actor header)',
+    moved_at DATETIME NOT NULL COMMENT 'When the item was actually moved',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the move was recorded',
+
+    INDEX idx_item_moves_item (item_id, moved_at),
+    CONSTRAINT fk_item_moves_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_moves_from FOREIGN KEY (from_location_id) REFERENCES locations (id) ON DELETE SET NULL,
+    CONSTRAINT fk_item_moves_to FOREIGN KEY (to_location_id) REFERENCES locations (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Location history of items';
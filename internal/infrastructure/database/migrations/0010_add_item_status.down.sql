@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS item_status_events;
+ALTER TABLE items DROP INDEX idx_items_status, DROP COLUMN status;
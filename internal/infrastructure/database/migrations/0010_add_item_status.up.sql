@@ -0,0 +1,20 @@
+-- アイテムの所有状況（owned, on_loan, in_repair, consigned, sold, lost, stolen, disposed）
+-- POST /items/{id}/status でのみ変更する
+ALTER TABLE items
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'owned' COMMENT 'Lifecycle status' AFTER location_id,
+    ADD INDEX idx_items_status (status);
+
+-- 所有状況の変更の記録（アイテムを完全に削除した場合は記録も削除する）
+CREATE TABLE IF NOT EXISTS item_status_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item whose status changed',
+    from_status VARCHAR(20) NOT NULL COMMENT 'Status before the change',
+    to_status VARCHAR(20) NOT NULL COMMENT 'Status after the change',
+    reason VARCHAR(500) NOT NULL COMMENT 'Why the status changed',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who recorded the change (X-Actor header)',
+    changed_at DATETIME NOT NULL COMMENT 'When the status actually changed',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the change was recorded',
+
+    INDEX idx_item_status_events_item (item_id, changed_at),
+    CONSTRAINT fk_item_status_events_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Status history of items';
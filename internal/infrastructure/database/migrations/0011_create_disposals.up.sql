@@ -0,0 +1,17 @@
+-- アイテムの売却の記録（1アイテムにつき1件。売却したアイテムは所有状況が sold になる）
+-- 売却損益は保存せず、items.purchase_price から求める
+CREATE TABLE IF NOT EXISTS disposals (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Sold item',
+    sold_on DATE NOT NULL COMMENT 'Sale date',
+    sale_price INT NOT NULL COMMENT 'Sale price in yen',
+    fees INT NOT NULL DEFAULT 0 COMMENT 'Selling fees and shipping in yen',
+    channel VARCHAR(100) NOT NULL COMMENT 'Where the item was sold',
+    note VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Free-form note',
+    actor VARCHAR(100) NOT NULL COMMENT 'Who recorded the sale (X-Actor header)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the sale was recorded',
+
+    UNIQUE KEY uq_disposals_item (item_id),
+    INDEX idx_disposals_sold_on (sold_on),
+    CONSTRAINT fk_disposals_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Sale records of items';
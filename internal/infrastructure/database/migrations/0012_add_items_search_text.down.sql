@@ -0,0 +1,2 @@
+-- 新しいデータベースでは search_text は 0001 で作成されるため、ここでは取り除かない
+DO 0;
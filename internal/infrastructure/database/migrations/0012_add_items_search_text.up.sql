@@ -0,0 +1,22 @@
+-- 0001 は CREATE TABLE IF NOT EXISTS のため、以前の sql/init.sql で作成した items には search_text がない
+-- MySQL には ADD COLUMN IF NOT EXISTS がないため、information_schema で確認してから追加する
+-- 既存の行の search_text は起動時にアプリケーションが search.Fold で設定する（ItemRepository.BackfillSearchText）
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.COLUMNS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'items' AND COLUMN_NAME = 'search_text') = 0,
+    'ALTER TABLE items ADD COLUMN search_text VARCHAR(255) NOT NULL DEFAULT '''' COMMENT ''Normalized name and brand for full-text search''',
+    'DO 0'
+);
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF(
+    (SELECT COUNT(*) FROM information_schema.STATISTICS
+     WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'items' AND INDEX_NAME = 'ft_search_text') = 0,
+    'ALTER TABLE items ADD FULLTEXT INDEX ft_search_text (search_text) WITH PARSER ngram',
+    'DO 0'
+);
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,3 @@
+-- 外部キーを戻す前に、完全に削除したアイテムの変更履歴を削除する
+DELETE FROM item_revisions WHERE item_id NOT IN (SELECT id FROM items);
+ALTER TABLE item_revisions ADD CONSTRAINT fk_item_revisions_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+-- アイテムを完全に削除（purge）しても変更履歴を残すため、外部キーを外す
+-- 完全に削除したことは purge の変更履歴として記録する
+ALTER TABLE item_revisions DROP FOREIGN KEY fk_item_revisions_item;
@@ -0,0 +1,2 @@
+ALTER TABLE disposals DROP FOREIGN KEY fk_disposals_item;
+ALTER TABLE disposals ADD CONSTRAINT fk_disposals_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE;
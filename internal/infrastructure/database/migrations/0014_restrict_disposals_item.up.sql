@@ -0,0 +1,4 @@
+-- 売却の記録は譲渡所得の計算に必要なため、アイテムを完全に削除（purge）しても消えないようにする
+-- 売却の記録があるアイテムは削除できない（アプリケーションは ErrItemSold を返す）
+ALTER TABLE disposals DROP FOREIGN KEY fk_disposals_item;
+ALTER TABLE disposals ADD CONSTRAINT fk_disposals_item FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE RESTRICT;
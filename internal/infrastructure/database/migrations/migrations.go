@@ -0,0 +1,10 @@
+// Package migrations はスキーママイグレーションのSQLファイルをバイナリに埋め込む
+//
+// ファイル名は {バージョン}_{名前}.up.sql / {バージョン}_{名前}.down.sql の形式で、
+// バージョンの昇順に適用される。各SQL文は行末の ; で区切ること
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,56 @@
+package databaseInfra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/infrastructure/database/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	loaded, err := loadMigrations(migrations.FS)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+	for i, migration := range loaded {
+		// バージョンは1から欠番なく並ぶ
+		assert.Equal(t, int64(i+1), migration.Version, migration.Name)
+		assert.NotEmpty(t, splitStatements(migration.Up), migration.Name)
+		assert.NotEmpty(t, migration.Down, migration.Name)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "正常系: 行末の ; で分割し、コメント行と空行を除く",
+			body: "-- comment\nCREATE TABLE a (id INT);\n\nINSERT INTO a VALUES (1);\n",
+			want: []string{"CREATE TABLE a (id INT)", "INSERT INTO a VALUES (1)"},
+		},
+		{
+			name: "正常系: 複数行の文と文字列中の引用符",
+			body: "SET @ddl = IF(\n    1 = 0,\n    'ALTER TABLE a ADD COLUMN b VARCHAR(10) NOT NULL DEFAULT ''''',\n    'DO 0'\n);\nPREPARE stmt FROM @ddl;",
+			want: []string{
+				"SET @ddl = IF(\n    1 = 0,\n    'ALTER TABLE a ADD COLUMN b VARCHAR(10) NOT NULL DEFAULT ''''',\n    'DO 0'\n)",
+				"PREPARE stmt FROM @ddl",
+			},
+		},
+		{
+			name: "正常系: 最後の文に ; がない",
+			body: "DO 0",
+			want: []string{"DO 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitStatements(tt.body))
+		})
+	}
+}
@@ -0,0 +1,12 @@
+-- Insert sample data for testing
+-- itemsテーブルが空の場合のみ登録する（何度実行しても重複しない）
+-- search_text はアプリケーションの search.Fold で正規化した「名前 + ブランド」
+INSERT INTO items (name, category, brand, purchase_price, purchase_date, search_text)
+SELECT seed.* FROM (
+    SELECT 'ロレックス デイトナ' AS name, '時計' AS category, 'ROLEX' AS brand, 1500000 AS purchase_price, DATE '2023-01-15' AS purchase_date, 'ロレックス デイトナ rolex' AS search_text
+    UNION ALL SELECT 'エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, DATE '2023-02-20', 'エルメス バーキン hermes'
+    UNION ALL SELECT 'ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, DATE '2023-03-10', 'ティファニー ネックレス tiffany & co.'
+    UNION ALL SELECT 'ルブタン パンプス', '靴', 'Christian Louboutin', 150000, DATE '2023-04-05', 'ルブタン パンプス christian louboutin'
+    UNION ALL SELECT 'アップルウォッチ', 'その他', 'Apple', 50000, DATE '2023-05-12', 'アップルウォッチ apple'
+) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM items);
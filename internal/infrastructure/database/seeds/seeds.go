@@ -0,0 +1,9 @@
+// Package seeds は動作確認用のサンプルデータのSQLファイルをバイナリに埋め込む
+//
+// シードはマイグレーションとは別に任意で実行する。何度実行しても同じ結果になるように書くこと
+package seeds
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
 
 	_ "github.com/go-sql-driver/mysql"
 
@@ -16,7 +15,9 @@ type MySqlHandler struct {
 	Conn *sql.DB
 }
 
-func NewSqlHandler() database.SqlHandler {
+// NewSqlHandler はDBに接続する
+// スキーマの作成・更新は Migrator で行う
+func NewSqlHandler() *MySqlHandler {
 	dsn := config.GetDSN()
 	conn, err := sql.Open("mysql", dsn)
 	if err != nil {
@@ -30,46 +31,95 @@ func NewSqlHandler() database.SqlHandler {
 
 	fmt.Println("✅ Successfully connected to the database!")
 
-	sqlBytes, err := os.ReadFile("sql/init.sql")
+	return &MySqlHandler{Conn: conn}
+}
+
+// トランザクションを context.Context に格納するためのキー
+type txContextKey struct{}
+
+// sqlExecutor は *sql.DB と *sql.Tx の共通部分
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// executor は ctx にトランザクションがあればそれを、なければ接続プールを返す
+func (h *MySqlHandler) executor(ctx context.Context) sqlExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return h.Conn
+}
+
+func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	return execute(ctx, h.executor(ctx), statement, args...)
+}
+
+func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return query(ctx, h.executor(ctx), statement, args...)
+}
+
+func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return queryRow(ctx, h.executor(ctx), statement, args...)
+}
+
+func (h *MySqlHandler) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	// 既にトランザクション内であれば、そのトランザクションに参加する
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := h.Conn.BeginTx(ctx, nil)
 	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
-	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
-			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
-		} else {
-			fmt.Println("✅ Successfully initialized database from init.sql")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
 		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
 	}
 
-	return &MySqlHandler{Conn: conn}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
 }
 
-func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+func (h *MySqlHandler) Close() error {
+	if h.Conn != nil {
+		return h.Conn.Close()
+	}
+	return nil
+}
+
+func execute(ctx context.Context, e sqlExecutor, statement string, args ...interface{}) (database.Result, error) {
+	result, err := e.ExecContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
 	return &mysqlResult{result: result}, nil
 }
 
-func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+func query(ctx context.Context, e sqlExecutor, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := e.QueryContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
 	return &mysqlRows{rows: rows}, nil
 }
 
-func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
-	return &mysqlRow{row: row}
-}
-
-func (h *MySqlHandler) Close() error {
-	if h.Conn != nil {
-		return h.Conn.Close()
-	}
-	return nil
+func queryRow(ctx context.Context, e sqlExecutor, statement string, args ...interface{}) database.Row {
+	return &mysqlRow{row: e.QueryRowContext(ctx, statement, args...)}
 }
 
 type mysqlResult struct {
@@ -0,0 +1,211 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver はトランザクションの開始・コミット・ロールバックと実行したSQLを記録するテスト用のドライバー
+type fakeDriver struct {
+	mu        sync.Mutex
+	begins    int
+	commits   int
+	rollbacks int
+	execs     []string // トランザクション内で実行したSQLは "tx: " 、それ以外は "db: " を先頭に付ける
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) { return &fakeConn{driver: d}, nil }
+func (d *fakeDriver) Driver() driver.Driver                        { return d }
+func (d *fakeDriver) Open(string) (driver.Conn, error)             { return &fakeConn{driver: d}, nil }
+
+type fakeConn struct {
+	driver *fakeDriver
+	inTx   bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.begins++
+	c.inTx = true
+	return &fakeTx{conn: c}, nil
+}
+
+type fakeTx struct {
+	conn *fakeConn
+}
+
+func (t *fakeTx) Commit() error {
+	t.conn.driver.mu.Lock()
+	defer t.conn.driver.mu.Unlock()
+	t.conn.driver.commits++
+	t.conn.inTx = false
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.conn.driver.mu.Lock()
+	defer t.conn.driver.mu.Unlock()
+	t.conn.driver.rollbacks++
+	t.conn.inTx = false
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	prefix := "db: "
+	if s.conn.inTx {
+		prefix = "tx: "
+	}
+	s.conn.driver.execs = append(s.conn.driver.execs, prefix+s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, fmt.Errorf("query is not supported")
+}
+
+func newFakeHandler(t *testing.T) (*MySqlHandler, *fakeDriver) {
+	d := &fakeDriver{}
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { db.Close() })
+	return &MySqlHandler{Conn: db}, d
+}
+
+func TestMySqlHandler_WithTx(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name          string
+		fn            func(h *MySqlHandler) func(ctx context.Context) error
+		wantErr       error
+		wantCommits   int
+		wantRollbacks int
+		wantExecs     []string
+	}{
+		{
+			name: "正常系: fn の ctx で実行したSQLは同じトランザクションでコミットされる",
+			fn: func(h *MySqlHandler) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					_, err := h.Execute(ctx, "INSERT 1")
+					return err
+				}
+			},
+			wantCommits: 1,
+			wantExecs:   []string{"tx: INSERT 1"},
+		},
+		{
+			name: "正常系: 入れ子の WithTx は外側のトランザクションに参加する",
+			fn: func(h *MySqlHandler) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if _, err := h.Execute(ctx, "INSERT 1"); err != nil {
+						return err
+					}
+					return h.WithTx(ctx, func(ctx context.Context) error {
+						_, err := h.Execute(ctx, "INSERT 2")
+						return err
+					})
+				}
+			},
+			wantCommits: 1,
+			wantExecs:   []string{"tx: INSERT 1", "tx: INSERT 2"},
+		},
+		{
+			name: "異常系: fn がエラーを返した場合はロールバックする",
+			fn: func(h *MySqlHandler) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if _, err := h.Execute(ctx, "INSERT 1"); err != nil {
+						return err
+					}
+					return errFailed
+				}
+			},
+			wantErr:       errFailed,
+			wantRollbacks: 1,
+			wantExecs:     []string{"tx: INSERT 1"},
+		},
+		{
+			name: "異常系: 入れ子の WithTx のエラーで外側のトランザクションごとロールバックする",
+			fn: func(h *MySqlHandler) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if _, err := h.Execute(ctx, "INSERT 1"); err != nil {
+						return err
+					}
+					return h.WithTx(ctx, func(ctx context.Context) error {
+						return errFailed
+					})
+				}
+			},
+			wantErr:       errFailed,
+			wantRollbacks: 1,
+			wantExecs:     []string{"tx: INSERT 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, d := newFakeHandler(t)
+
+			err := h.WithTx(context.Background(), tt.fn(h))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, 1, d.begins)
+			assert.Equal(t, tt.wantCommits, d.commits)
+			assert.Equal(t, tt.wantRollbacks, d.rollbacks)
+			assert.Equal(t, tt.wantExecs, d.execs)
+		})
+	}
+
+	t.Run("異常系: fn がパニックした場合はロールバックしてパニックを伝える", func(t *testing.T) {
+		h, d := newFakeHandler(t)
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = h.WithTx(context.Background(), func(ctx context.Context) error {
+				if _, err := h.Execute(ctx, "INSERT 1"); err != nil {
+					return err
+				}
+				panic("boom")
+			})
+		})
+
+		assert.Equal(t, 0, d.commits)
+		assert.Equal(t, 1, d.rollbacks)
+	})
+
+	t.Run("正常系: トランザクションの外ではトランザクションを使わない", func(t *testing.T) {
+		h, d := newFakeHandler(t)
+
+		_, err := h.Execute(context.Background(), "INSERT 1")
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, d.begins)
+		assert.Equal(t, []string{"db: INSERT 1"}, d.execs)
+	})
+}
@@ -10,9 +10,18 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"aicon-coding-test/internal/domain/tax"
+	"aicon-coding-test/internal/infrastructure/config"
 	databaseInfra "aicon-coding-test/internal/infrastructure/database"
+	"aicon-coding-test/internal/infrastructure/database/migrations"
+	"aicon-coding-test/internal/infrastructure/database/seeds"
+	categoryController "aicon-coding-test/internal/interfaces/controller/categories"
 	itemController "aicon-coding-test/internal/interfaces/controller/items"
+	locationController "aicon-coding-test/internal/interfaces/controller/locations"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	reportController "aicon-coding-test/internal/interfaces/controller/reports"
 	"aicon-coding-test/internal/interfaces/controller/system"
+	tagController "aicon-coding-test/internal/interfaces/controller/tags"
 	itemDatabase "aicon-coding-test/internal/interfaces/database"
 	"aicon-coding-test/internal/usecase"
 )
@@ -28,18 +37,105 @@ func NewServer() *Server {
 func (s *Server) Run(ctx context.Context) error {
 	e := echo.New()
 
+	// エラーは application/problem+json で返し、リクエストIDで追跡できるようにする
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Use(problem.RequestIDMiddleware)
+
 	// 依存性注入
 	dbHandler := databaseInfra.NewSqlHandler()
 	defer dbHandler.Close()
 
+	if err := prepareDatabase(ctx, dbHandler); err != nil {
+		return err
+	}
+
 	itemRepo := &itemDatabase.ItemRepository{
 		SqlHandler: dbHandler,
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	// search_text を追加する前に登録したアイテムを検索できるようにする
+	if filled, err := itemRepo.BackfillSearchText(ctx); err != nil {
+		fmt.Printf("⚠️  Failed to backfill search text: %v\n", err)
+	} else if filled > 0 {
+		fmt.Printf("✅ Backfilled search text for %d items\n", filled)
+	}
+
+	transactor := &itemDatabase.Transactor{
+		SqlHandler: dbHandler,
+	}
+
+	itemRevisionRepo := &itemDatabase.ItemRevisionRepository{
+		SqlHandler: dbHandler,
+	}
+
+	categoryRepo := &itemDatabase.CategoryRepository{
+		SqlHandler: dbHandler,
+	}
+
+	tagRepo := &itemDatabase.TagRepository{
+		SqlHandler: dbHandler,
+	}
+
+	locationRepo := &itemDatabase.LocationRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemStatusEventRepo := &itemDatabase.ItemStatusEventRepository{
+		SqlHandler: dbHandler,
+	}
+
+	disposalRepo := &itemDatabase.DisposalRepository{
+		SqlHandler: dbHandler,
+	}
+
+	// アイテムのカテゴリーは categories テーブルをキャッシュしたもので検証する
+	categoryCache := usecase.NewCategoryCache(categoryRepo, config.CategoryCacheTTL)
+	if err := categoryCache.Load(ctx); err != nil {
+		return fmt.Errorf("failed to load categories: %w", err)
+	}
+
+	itemUsecase := usecase.NewItemUsecase(itemRepo, itemRevisionRepo, categoryRepo, categoryCache, tagRepo, locationRepo, itemStatusEventRepo, disposalRepo, transactor)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, categoryCache, transactor)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, transactor)
+	locationUsecase := usecase.NewLocationUsecase(locationRepo, transactor)
+
+	// 譲渡所得の計算ルールは TAX_RULES_FILE で差し替えられる
+	taxRules := tax.DefaultRules()
+	if config.TaxRulesFile != "" {
+		data, err := os.ReadFile(config.TaxRulesFile)
+		if err != nil {
+			return fmt.Errorf("failed to read TAX_RULES_FILE: %w", err)
+		}
+		if taxRules, err = tax.ParseRules(data); err != nil {
+			return fmt.Errorf("invalid TAX_RULES_FILE: %w", err)
+		}
+	}
+	transferIncomeUsecase := usecase.NewTransferIncomeUsecase(disposalRepo, categoryRepo, taxRules)
+
+	// 保存期間を過ぎたゴミ箱のアイテムをバックグラウンドで完全に削除する
+	if config.TrashRetentionDays > 0 {
+		sweepCtx, stopSweeper := context.WithCancel(ctx)
+		defer stopSweeper()
+		retention := time.Duration(config.TrashRetentionDays) * 24 * time.Hour
+		go runTrashSweeper(sweepCtx, itemUsecase, retention, config.TrashSweepInterval)
+	}
 
 	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	priceBuckets, err := usecase.NewPriceBuckets(usecase.DefaultPriceBoundaries)
+	if err != nil {
+		return err
+	}
+	if config.FacetPriceBuckets != "" {
+		if priceBuckets, err = usecase.ParsePriceBuckets(config.FacetPriceBuckets); err != nil {
+			return fmt.Errorf("invalid FACET_PRICE_BUCKETS: %w", err)
+		}
+	}
+
+	itemHandler := itemController.NewItemHandler(itemUsecase, usecase.NewCursorCodec(config.CursorSecret), priceBuckets)
+	categoryHandler := categoryController.NewCategoryHandler(categoryUsecase)
+	tagHandler := tagController.NewTagHandler(tagUsecase)
+	locationHandler := locationController.NewLocationHandler(locationUsecase)
+	reportHandler := reportController.NewReportHandler(transferIncomeUsecase)
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
@@ -47,20 +143,120 @@ func (s *Server) Run(ctx context.Context) error {
 		return nil
 	})
 
+	// エラーの種類の説明（problem+json の type が指すURI）
+	e.GET("/problems", problem.GetCatalog)
+	e.GET("/problems/:slug", problem.GetType)
+
 	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
+	registerItemRoutes(e, itemHandler)
+
+	// カテゴリーに関するエンドポイント
+	categoriesGroup := e.Group("/categories")
 	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+		categoriesGroup.GET("", categoryHandler.GetCategories)         // GET /categories
+		categoriesGroup.POST("", categoryHandler.CreateCategory)       // POST /categories
+		categoriesGroup.GET("/:id", categoryHandler.GetCategory)       // GET /categories/{id}
+		categoriesGroup.PATCH("/:id", categoryHandler.UpdateCategory)  // PATCH /categories/{id}
+		categoriesGroup.DELETE("/:id", categoryHandler.DeleteCategory) // DELETE /categories/{id}
+	}
+
+	// タグに関するエンドポイント（アイテムへの付け外しは PUT /items/{id}/tags）
+	tagsGroup := e.Group("/tags")
+	{
+		tagsGroup.GET("", tagHandler.GetTags)              // GET /tags
+		tagsGroup.PATCH("/:id", tagHandler.RenameTag)      // PATCH /tags/{id}
+		tagsGroup.POST("/:id/merge", tagHandler.MergeTags) // POST /tags/{id}/merge
+	}
+
+	// 保管場所に関するエンドポイント（アイテムの移動は POST /items/{id}/move）
+	locationsGroup := e.Group("/locations")
+	{
+		locationsGroup.GET("", locationHandler.GetLocations)           // GET /locations
+		locationsGroup.POST("", locationHandler.CreateLocation)        // POST /locations
+		locationsGroup.GET("/:id", locationHandler.GetLocation)        // GET /locations/{id}
+		locationsGroup.PATCH("/:id", locationHandler.UpdateLocation)   // PATCH /locations/{id}
+		locationsGroup.DELETE("/:id", locationHandler.DeleteLocation)  // DELETE /locations/{id}
+		locationsGroup.GET("/:id/items", itemHandler.GetLocationItems) // GET /locations/{id}/items
+	}
+
+	// 帳票に関するエンドポイント
+	reportsGroup := e.Group("/reports")
+	{
+		reportsGroup.GET("/transfer-income", reportHandler.GetTransferIncome) // GET /reports/transfer-income
 	}
 
 	return s.startWithGracefulShutdown(ctx, e)
 }
 
+// registerItemRoutes はアイテムに関するエンドポイントを登録する
+// X-Actor ヘッダーの操作者を変更履歴に記録する
+func registerItemRoutes(e *echo.Echo, itemHandler *itemController.ItemHandler) {
+	itemsGroup := e.Group("/items", itemController.ActorMiddleware)
+	{
+		itemsGroup.GET("", itemHandler.GetItems)                              // GET /items
+		itemsGroup.POST("", itemHandler.CreateItem)                           // POST /items
+		itemsGroup.POST("/import", itemHandler.ImportItems)                   // POST /items/import
+		itemsGroup.GET("/export", itemHandler.ExportItems)                    // GET /items/export?format=
+		itemsGroup.GET("/search", itemHandler.SearchItems)                    // GET /items/search?q=
+		itemsGroup.GET("/trash", itemHandler.GetTrash)                        // GET /items/trash
+		itemsGroup.GET("/:id", itemHandler.GetItem)                           // GET /items/{id}
+		itemsGroup.PUT("/:id", itemHandler.ReplaceItem)                       // PUT /items/{id}
+		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)                      // PATCH /items/{id}
+		itemsGroup.PUT("/:id/tags", itemHandler.SetItemTags)                  // PUT /items/{id}/tags
+		itemsGroup.POST("/:id/move", itemHandler.MoveItem)                    // POST /items/{id}/move
+		itemsGroup.GET("/:id/moves", itemHandler.GetMoves)                    // GET /items/{id}/moves
+		itemsGroup.POST("/:id/status", itemHandler.ChangeStatus)              // POST /items/{id}/status
+		itemsGroup.GET("/:id/status-events", itemHandler.GetStatusEvents)     // GET /items/{id}/status-events
+		itemsGroup.POST("/:id/sell", itemHandler.SellItem)                    // POST /items/{id}/sell
+		itemsGroup.GET("/:id/sale", itemHandler.GetSale)                      // GET /items/{id}/sale
+		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)                     // DELETE /items/{id}
+		itemsGroup.POST("/:id/restore", itemHandler.RestoreItem)              // POST /items/{id}/restore
+		itemsGroup.DELETE("/:id/purge", itemHandler.PurgeItem)                // DELETE /items/{id}/purge
+		itemsGroup.GET("/:id/revisions", itemHandler.GetRevisions)            // GET /items/{id}/revisions
+		itemsGroup.GET("/:id/revisions/:rev", itemHandler.GetRevision)        // GET /items/{id}/revisions/{rev}
+		itemsGroup.POST("/:id/revisions/:rev/revert", itemHandler.RevertItem) // POST /items/{id}/revisions/{rev}/revert
+		itemsGroup.GET("/summary", itemHandler.GetSummary)                    // GET /items/summary (bonus)
+		itemsGroup.GET("/realized-gains", itemHandler.GetRealizedGains)       // GET /items/realized-gains?year=
+	}
+}
+
+// prepareDatabase は設定に応じて起動時にマイグレーションとシードを実行する
+func prepareDatabase(ctx context.Context, dbHandler *databaseInfra.MySqlHandler) error {
+	migrator, err := databaseInfra.NewMigrator(dbHandler.Conn, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if config.DBAutoMigrate {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		for _, m := range applied {
+			fmt.Printf("✅ Applied migration %04d_%s\n", m.Version, m.Name)
+		}
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			fmt.Printf("⚠️  Pending migration %04d_%s\n", status.Version, status.Name)
+		}
+	}
+
+	if config.DBSeed {
+		if _, err := migrator.Seed(ctx, seeds.FS); err != nil {
+			return fmt.Errorf("failed to seed database: %w", err)
+		}
+		fmt.Println("✅ Seeded sample data")
+	}
+
+	return nil
+}
+
 func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
 	go func() {
 		port := ":8080"
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	itemController "aicon-coding-test/internal/interfaces/controller/items"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// stubItemUsecase は StreamItems と GetItemByID だけを差し替えたテスト用のユースケース（それ以外を呼ぶとパニックする）
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	items []*entity.Item
+}
+
+func (s *stubItemUsecase) StreamItems(ctx context.Context, criteria usecase.ItemCriteria) (iter.Seq2[*entity.Item, error], error) {
+	return func(yield func(*entity.Item, error) bool) {
+		for _, item := range s.items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (s *stubItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	for _, item := range s.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestRegisterItemRoutes(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Status: entity.StatusOwned, Version: 1},
+		{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMES", PurchasePrice: 2000000, PurchaseDate: "2023-02-01", Status: entity.StatusOwned, Version: 1},
+	}
+
+	tests := []struct {
+		name            string
+		target          string
+		wantStatus      int
+		wantContentType string
+		wantBody        func(t *testing.T, body string)
+	}{
+		{
+			name:            "正常系: /items/export はアイテムのIDとして扱わずエクスポートする",
+			target:          "/items/export?format=csv&columns=id,name",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: func(t *testing.T, body string) {
+				records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				assert.Equal(t, [][]string{{"id", "name"}, {"1", "ロレックス デイトナ"}, {"2", "エルメス バーキン"}}, records)
+			},
+		},
+		{
+			name:            "異常系: /items/export の不正なクエリパラメータは400",
+			target:          "/items/export?format=pdf",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: problem.MIMEApplicationProblemJSON,
+		},
+		{
+			name:            "正常系: /items/{id} は個別取得のまま",
+			target:          "/items/1",
+			wantStatus:      http.StatusOK,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, `"name":"ロレックス デイトナ"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = problem.HTTPErrorHandler
+			registerItemRoutes(e, itemController.NewItemHandler(&stubItemUsecase{items: items}, usecase.NewCursorCodec([]byte("secret")), nil))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.wantContentType, rec.Header().Get(echo.HeaderContentType))
+			if tt.wantBody != nil {
+				tt.wantBody(t, rec.Body.String())
+			}
+		})
+	}
+}
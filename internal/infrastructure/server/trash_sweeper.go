@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"aicon-coding-test/internal/usecase"
+)
+
+// trashSweeperActor は自動で完全に削除したときに変更履歴に記録する操作者
+const trashSweeperActor = "trash-sweeper"
+
+// runTrashSweeper は interval ごとに保存期間を過ぎたゴミ箱のアイテムを完全に削除する
+// ctx がキャンセルされるまで実行し続ける（起動直後にも1回実行する）
+func runTrashSweeper(ctx context.Context, itemUsecase usecase.ItemUsecase, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := itemUsecase.PurgeExpiredTrash(usecase.WithActor(ctx, trashSweeperActor), retention)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("⚠️  Failed to purge expired trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("🗑️  Purged %d expired item(s) from trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package categories
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// CategoryHandler はカテゴリー管理のエンドポイント
+// ハンドラーはエラーをそのまま返し、レスポンスへの変換は problem.HTTPErrorHandler が行う
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{categoryUsecase: categoryUsecase}
+}
+
+var (
+	errInvalidCategoryID = problem.InvalidRequest(i18n.MsgInvalidCategoryID)
+	errInvalidBodyFormat = problem.InvalidRequest(i18n.MsgInvalidRequestFormat)
+)
+
+// parseCategoryID はパスパラメータの id を取得する
+func parseCategoryID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errInvalidCategoryID
+	}
+	return id, nil
+}
+
+// GetCategories はカテゴリーを並び順に返す
+// GET /categories?include_inactive=true に対応（デフォルトは有効なカテゴリーのみ）
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	var includeInactive bool
+	if raw := c.QueryParam("include_inactive"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return problem.InvalidRequest(i18n.MsgInvalidQueryParams, &domainErrors.InputError{
+				Code:    domainErrors.CodeNotBoolean,
+				Params:  map[string]interface{}{"name": "include_inactive"},
+				Message: "include_inactive must be a boolean",
+			})
+		}
+		includeInactive = v
+	}
+
+	categories, err := h.categoryUsecase.GetCategories(c.Request().Context(), includeInactive)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"categories": categories})
+}
+
+// GetCategory はカテゴリーを返す
+// GET /categories/{id} に対応
+func (h *CategoryHandler) GetCategory(c echo.Context) error {
+	id, err := parseCategoryID(c)
+	if err != nil {
+		return err
+	}
+
+	category, err := h.categoryUsecase.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// CreateCategory はカテゴリーを登録する
+// POST /categories に対応（コードが重複する場合は409）
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var input usecase.CreateCategoryInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	category, err := h.categoryUsecase.CreateCategory(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory は表示名・並び順・有効フラグを部分更新する
+// PATCH /categories/{id} に対応（使われているカテゴリーを無効にしようとした場合は409）
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	id, err := parseCategoryID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.UpdateCategoryInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	category, err := h.categoryUsecase.UpdateCategory(c.Request().Context(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory はカテゴリーを削除する
+// DELETE /categories/{id} に対応（使われているカテゴリーは409）
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	id, err := parseCategoryID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.categoryUsecase.DeleteCategory(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// メッセージのキー
+// 同じキーの文言を言語ごとに bundles に定義する
+const (
+	MsgItemNotFound          = "error.item_not_found"
+	MsgRevisionNotFound      = "error.revision_not_found"
+	MsgCategoryNotFound      = "error.category_not_found"
+	MsgCategoryInUse         = "error.category_in_use"
+	MsgCategoryHasChildren   = "error.category_has_children"
+	MsgTagNotFound           = "error.tag_not_found"
+	MsgLocationNotFound      = "error.location_not_found"
+	MsgLocationInUse         = "error.location_in_use"
+	MsgLocationHasChildren   = "error.location_has_children"
+	MsgDisposalNotFound      = "error.disposal_not_found"
+	MsgItemSold              = "error.item_sold"
+	MsgVersionConflict       = "error.version_conflict"
+	MsgDuplicateEntry        = "error.duplicate_entry"
+	MsgFieldsInvalid         = "error.fields_invalid"
+	MsgInputInvalid          = "error.input_invalid"
+	MsgRouteNotFound         = "error.route_not_found"
+	MsgMethodNotAllowed      = "error.method_not_allowed"
+	MsgPayloadTooLarge       = "error.payload_too_large"
+	MsgInvalidItemID         = "error.invalid_item_id"
+	MsgInvalidCategoryID     = "error.invalid_category_id"
+	MsgInvalidTagID          = "error.invalid_tag_id"
+	MsgInvalidLocationID     = "error.invalid_location_id"
+	MsgInvalidRevisionParams = "error.invalid_revision_params"
+	MsgInvalidRequestFormat  = "error.invalid_request_format"
+	MsgInvalidQueryParams    = "error.invalid_query_parameters"
+	MsgInvalidIfMatch        = "error.invalid_if_match"
+	MsgInvalidImportRequest  = "error.invalid_import_request"
+	MsgProblemTypeNotFound   = "error.problem_type_not_found"
+)
+
+// bundles は言語ごとのメッセージ
+var bundles = map[Locale]map[string]string{
+	English:  messagesEN,
+	Japanese: messagesJA,
+}
+
+// T は key に対応するメッセージを locale の言語で返す
+// メッセージ中の {name} は params の値で置き換える
+// locale に文言がない場合は英語、英語にもない場合は key をそのまま返す
+func T(locale Locale, key string, params map[string]interface{}) string {
+	message, ok := bundles[locale][key]
+	if !ok {
+		if message, ok = bundles[English][key]; !ok {
+			return key
+		}
+	}
+	return interpolate(locale, message, params)
+}
+
+// Has は key のメッセージが定義されているかを返す
+func Has(key string) bool {
+	_, ok := bundles[English][key]
+	return ok
+}
+
+// ValidationMessage はバリデーションエラー（フィールドとコード）のメッセージを返す
+// フィールド名も locale の言語で表示する。コードに対応する文言がない場合は ok=false を返す
+func ValidationMessage(locale Locale, field, code string, params map[string]interface{}) (string, bool) {
+	key := "validation." + code
+	if !Has(key) {
+		return "", false
+	}
+
+	values := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		values[k] = v
+	}
+	values["field"] = T(locale, "field."+field, nil)
+	if values["field"] == "field."+field {
+		values["field"] = field
+	}
+
+	return T(locale, key, values), true
+}
+
+// InputMessage はフィールド単位でない入力エラー（コード）のメッセージを返す
+// コードに対応する文言がない場合は ok=false を返す
+func InputMessage(locale Locale, code string, params map[string]interface{}) (string, bool) {
+	key := "input." + code
+	if !Has(key) {
+		return "", false
+	}
+	return T(locale, key, params), true
+}
+
+// interpolate は {name} を params の値で置き換える
+// スライスは言語に合わせた区切り文字で連結する
+func interpolate(locale Locale, message string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return message
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", formatParam(locale, value))
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+func formatParam(locale Locale, value interface{}) string {
+	separator := ", "
+	if locale == Japanese {
+		separator = "、"
+	}
+
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, separator)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = fmt.Sprint(p)
+		}
+		return strings.Join(parts, separator)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale はメッセージの言語
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+)
+
+// DefaultLocale は言語の指定がない、または対応していない言語のみ指定された場合に使う言語
+const DefaultLocale = English
+
+// SupportedLocales は対応している言語の一覧
+var SupportedLocales = []Locale{Japanese, English}
+
+// ParseLocale は "ja" / "en-US" などの言語タグを対応している言語に変換する
+func ParseLocale(tag string) (Locale, bool) {
+	primary := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(primary, "-_"); i >= 0 {
+		primary = primary[:i]
+	}
+	for _, locale := range SupportedLocales {
+		if string(locale) == primary {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate はリクエストからメッセージの言語を決める
+// lang クエリパラメータを優先し、なければ Accept-Language の品質値（q）が高い順に対応している言語を選ぶ
+func Negotiate(r *http.Request) Locale {
+	if locale, ok := ParseLocale(r.URL.Query().Get("lang")); ok {
+		return locale
+	}
+	if locale, ok := fromAcceptLanguage(r.Header.Get("Accept-Language")); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// fromAcceptLanguage は "ja,en-US;q=0.8" 形式のヘッダーから最も優先度の高い対応言語を返す
+func fromAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, ok := ParseLocale(tag)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{locale: locale, quality: quality})
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	// 品質値が同じ場合はヘッダーに書かれた順を優先する
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].locale, true
+}
@@ -0,0 +1,128 @@
+package i18n
+
+// messagesEN は英語のメッセージ
+var messagesEN = map[string]string{
+	// エラーの種類（problem+json の title）
+	"problem.invalid-request.title":       "Invalid request",
+	"problem.validation-failed.title":     "Validation failed",
+	"problem.not-found.title":             "Resource not found",
+	"problem.method-not-allowed.title":    "Method not allowed",
+	"problem.duplicate-entry.title":       "Duplicate entry",
+	"problem.category-in-use.title":       "Category in use",
+	"problem.category-has-children.title": "Category has children",
+	"problem.location-in-use.title":       "Location in use",
+	"problem.location-has-children.title": "Location has children",
+	"problem.item-sold.title":             "Item sold",
+	"problem.version-conflict.title":      "Precondition failed",
+	"problem.payload-too-large.title":     "Payload too large",
+	"problem.internal-error.title":        "Internal server error",
+
+	// エラーの詳細（problem+json の detail）
+	MsgItemNotFound:          "item not found",
+	MsgRevisionNotFound:      "revision not found",
+	MsgCategoryNotFound:      "category not found",
+	MsgCategoryInUse:         "the category is used by items and cannot be deactivated or deleted",
+	MsgCategoryHasChildren:   "the category has child categories and cannot be deleted",
+	MsgTagNotFound:           "tag not found",
+	MsgLocationNotFound:      "location not found",
+	MsgLocationInUse:         "items are stored in the location and it cannot be deleted",
+	MsgLocationHasChildren:   "the location contains other locations and cannot be deleted",
+	MsgDisposalNotFound:      "the item has no sale record",
+	MsgItemSold:              "the item has a sale record and cannot be purged",
+	MsgVersionConflict:       "item has been modified",
+	MsgDuplicateEntry:        "duplicate entry",
+	MsgFieldsInvalid:         "one or more fields are invalid",
+	MsgInputInvalid:          "the request contains invalid input",
+	MsgRouteNotFound:         "no endpoint matches the requested path",
+	MsgMethodNotAllowed:      "the endpoint does not support this method",
+	MsgPayloadTooLarge:       "request body is too large",
+	MsgInvalidItemID:         "invalid item ID",
+	MsgInvalidCategoryID:     "invalid category ID",
+	MsgInvalidTagID:          "invalid tag ID",
+	MsgInvalidLocationID:     "invalid location ID",
+	MsgInvalidRevisionParams: "invalid item ID or revision",
+	MsgInvalidRequestFormat:  "invalid request format",
+	MsgInvalidQueryParams:    "invalid query parameters",
+	MsgInvalidIfMatch:        "invalid If-Match header",
+	MsgInvalidImportRequest:  "invalid import request",
+	MsgProblemTypeNotFound:   "problem type not found",
+
+	// バリデーションエラー（{field} はフィールド名に置き換わる）
+	"validation.required":            "{field} is required",
+	"validation.too_long":            "{field} must be {max} characters or less",
+	"validation.too_small":           "{field} must be {min} or greater",
+	"validation.invalid_category":    "{field} must be one of: {allowed}",
+	"validation.invalid_format":      "{field} must be in {format} format",
+	"validation.invalid_characters":  "{field} must not contain control characters",
+	"validation.invalid_parent":      "{field} must be an existing parent other than the record itself and its descendants",
+	"validation.parent_has_items":    "{field} refers to a category used by {count} item(s); items can only be assigned to leaf categories",
+	"validation.too_large":           "{field} must be {max} or less",
+	"validation.invalid_type":        "{field} must be a value of type {type}",
+	"validation.invalid_option":      "{field} must be one of: {allowed}",
+	"validation.unknown_attribute":   "{field} is not defined for the category",
+	"validation.duplicate_key":       "{field} is defined more than once",
+	"validation.too_many":            "{field} must have {max} items or fewer",
+	"validation.invalid_parent_kind": "a {kind} can only be placed in: {allowed}",
+	"validation.invalid_location":    "{field} must be an existing location other than the current one",
+	"validation.invalid_transition":  "{field} cannot change from {from} to the requested status (allowed: {allowed})",
+
+	// フィールド単位でない入力エラー（{name} はパラメータ名に置き換わる）
+	"input.required":                   "{name} is required",
+	"input.too_small":                  "{name} must be {min} or greater",
+	"input.too_many":                   "{name} must be {max} or fewer",
+	"input.invalid_format":             "{name} must be in {format} format",
+	"input.invalid_option":             "{name} must be one of: {allowed}",
+	"input.no_fields_to_update":        "no fields to update",
+	"input.out_of_range":               "{name} must be between {min} and {max}",
+	"input.not_integer":                "{name} must be an integer",
+	"input.not_boolean":                "{name} must be true or false",
+	"input.invalid_range":              "{from} must be less than or equal to {to}",
+	"input.conflicting_params":         "{name} cannot be combined with {other}",
+	"input.invalid_cursor":             "cursor is invalid",
+	"input.cursor_sort_mismatch":       "cursor does not match the requested sort",
+	"input.cursor_filter_mismatch":     "cursor does not match the requested filters",
+	"input.unsupported_sort_field":     "sort field \"{field}\" is not supported",
+	"input.duplicate_sort_field":       "sort field \"{field}\" is specified more than once",
+	"input.relevance_requires_keyword": "sort by relevance requires a search keyword",
+	"input.invalid_tag_filter":         "tag filters must be 1 to {max_length} characters and {max} tags or fewer",
+	"input.invalid_attribute_filter":   "attribute filter \"{key}\" is not a valid attribute key",
+	"input.invalid_price_buckets":      "{name} must be comma-separated ascending integers",
+	"input.unknown_column":             "unknown column: {column}",
+	"input.no_tax_rule":                "no tax rule applies to year {year}",
+	"input.merge_into_self":            "cannot merge a tag into itself",
+	"input.multiple_entity_tags":       "If-Match must contain a single entity tag",
+	"input.unquoted_entity_tag":        "If-Match must be a quoted entity tag",
+	"input.invalid_mapping":            "mapping must be a JSON object of field name to column name",
+	"input.unknown_mapping_field":      "unknown field in mapping: {field}",
+	"input.unreadable_file":            "{name} could not be read",
+	"input.empty_csv":                  "csv is empty",
+	"input.invalid_csv":                "invalid csv at line {line}: {reason}",
+	"input.too_many_rows":              "csv must contain {max} rows or fewer",
+	"input.missing_column":             "column \"{column}\" for {field} not found",
+
+	// フィールド名
+	"field.name":             "name",
+	"field.category":         "category",
+	"field.brand":            "brand",
+	"field.purchase_price":   "purchase_price",
+	"field.purchase_date":    "purchase_date",
+	"field.code":             "code",
+	"field.name_ja":          "name_ja",
+	"field.name_en":          "name_en",
+	"field.sort_order":       "sort_order",
+	"field.parent_id":        "parent_id",
+	"field.attributes":       "attributes",
+	"field.attribute_schema": "attribute_schema",
+	"field.tags":             "tags",
+	"field.kind":             "kind",
+	"field.location_id":      "location_id",
+	"field.note":             "note",
+	"field.moved_at":         "moved_at",
+	"field.status":           "status",
+	"field.reason":           "reason",
+	"field.changed_at":       "changed_at",
+	"field.sold_on":          "sold_on",
+	"field.sale_price":       "sale_price",
+	"field.fees":             "fees",
+	"field.channel":          "channel",
+}
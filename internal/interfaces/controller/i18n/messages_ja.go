@@ -0,0 +1,128 @@
+package i18n
+
+// messagesJA は日本語のメッセージ
+var messagesJA = map[string]string{
+	// エラーの種類（problem+json の title）
+	"problem.invalid-request.title":       "リクエストが不正です",
+	"problem.validation-failed.title":     "入力内容に誤りがあります",
+	"problem.not-found.title":             "リソースが見つかりません",
+	"problem.method-not-allowed.title":    "許可されていないメソッドです",
+	"problem.duplicate-entry.title":       "重複しています",
+	"problem.category-in-use.title":       "カテゴリーが使用中です",
+	"problem.category-has-children.title": "子カテゴリーがあります",
+	"problem.location-in-use.title":       "保管場所が使用中です",
+	"problem.location-has-children.title": "中に保管場所があります",
+	"problem.item-sold.title":             "売却済みのアイテムです",
+	"problem.version-conflict.title":      "前提条件を満たしていません",
+	"problem.payload-too-large.title":     "リクエストが大きすぎます",
+	"problem.internal-error.title":        "サーバーエラー",
+
+	// エラーの詳細（problem+json の detail）
+	MsgItemNotFound:          "アイテムが見つかりません",
+	MsgRevisionNotFound:      "変更履歴が見つかりません",
+	MsgCategoryNotFound:      "カテゴリーが見つかりません",
+	MsgCategoryInUse:         "このカテゴリーはアイテムで使われているため、無効化・削除できません",
+	MsgCategoryHasChildren:   "このカテゴリーには子カテゴリーがあるため、削除できません",
+	MsgTagNotFound:           "タグが見つかりません",
+	MsgLocationNotFound:      "保管場所が見つかりません",
+	MsgLocationInUse:         "この保管場所にはアイテムが置かれているため、削除できません",
+	MsgLocationHasChildren:   "この保管場所の中には別の保管場所があるため、削除できません",
+	MsgDisposalNotFound:      "このアイテムの売却の記録はありません",
+	MsgItemSold:              "売却の記録があるアイテムは完全に削除できません",
+	MsgVersionConflict:       "アイテムは他のリクエストで更新されています。取得し直してから再度実行してください",
+	MsgDuplicateEntry:        "同じ内容のデータがすでに存在します",
+	MsgFieldsInvalid:         "一部の項目の入力内容に誤りがあります",
+	MsgInputInvalid:          "リクエストの内容に誤りがあります",
+	MsgRouteNotFound:         "指定されたパスのエンドポイントはありません",
+	MsgMethodNotAllowed:      "このエンドポイントは指定されたメソッドに対応していません",
+	MsgPayloadTooLarge:       "リクエストボディが大きすぎます",
+	MsgInvalidItemID:         "アイテムIDが不正です",
+	MsgInvalidCategoryID:     "カテゴリーIDが不正です",
+	MsgInvalidTagID:          "タグIDが不正です",
+	MsgInvalidLocationID:     "保管場所IDが不正です",
+	MsgInvalidRevisionParams: "アイテムIDまたは変更履歴の番号が不正です",
+	MsgInvalidRequestFormat:  "リクエストの形式が不正です",
+	MsgInvalidQueryParams:    "クエリパラメータが不正です",
+	MsgInvalidIfMatch:        "If-Match ヘッダーが不正です",
+	MsgInvalidImportRequest:  "インポートのリクエストが不正です",
+	MsgProblemTypeNotFound:   "エラーの種類が見つかりません",
+
+	// バリデーションエラー（{field} はフィールド名に置き換わる）
+	"validation.required":            "{field}は必須です",
+	"validation.too_long":            "{field}は{max}文字以内で入力してください",
+	"validation.too_small":           "{field}は{min}以上で入力してください",
+	"validation.invalid_category":    "{field}は次のいずれかを指定してください: {allowed}",
+	"validation.invalid_format":      "{field}は{format}形式で入力してください",
+	"validation.invalid_characters":  "{field}に使用できない文字（制御文字）が含まれています",
+	"validation.invalid_parent":      "{field}には自身と子孫以外の登録済みの親を指定してください",
+	"validation.parent_has_items":    "{field}に指定したカテゴリーは{count}件のアイテムで使われています。アイテムは末端のカテゴリーにのみ設定できます",
+	"validation.too_large":           "{field}は{max}以下で入力してください",
+	"validation.invalid_type":        "{field}は{type}型の値で入力してください",
+	"validation.invalid_option":      "{field}は次のいずれかを指定してください: {allowed}",
+	"validation.unknown_attribute":   "{field}はこのカテゴリーで定義されていない属性です",
+	"validation.duplicate_key":       "{field}が重複しています",
+	"validation.too_many":            "{field}は{max}個以内で指定してください",
+	"validation.invalid_parent_kind": "{kind}は次の中にのみ置けます: {allowed}",
+	"validation.invalid_location":    "{field}には現在の場所以外の登録済みの保管場所を指定してください",
+	"validation.invalid_transition":  "{field}は{from}から指定の状態に変更できません（変更できる状態: {allowed}）",
+
+	// フィールド単位でない入力エラー（{name} はパラメータ名に置き換わる）
+	"input.required":                   "{name}は必須です",
+	"input.too_small":                  "{name}は{min}以上で指定してください",
+	"input.too_many":                   "{name}は{max}個以内で指定してください",
+	"input.invalid_format":             "{name}は{format}形式で指定してください",
+	"input.invalid_option":             "{name}は次のいずれかを指定してください: {allowed}",
+	"input.no_fields_to_update":        "更新する項目が指定されていません",
+	"input.out_of_range":               "{name}は{min}以上{max}以下で指定してください",
+	"input.not_integer":                "{name}は整数で指定してください",
+	"input.not_boolean":                "{name}は true または false で指定してください",
+	"input.invalid_range":              "{from}は{to}以下で指定してください",
+	"input.conflicting_params":         "{name}と{other}は同時に指定できません",
+	"input.invalid_cursor":             "カーソルが不正です",
+	"input.cursor_sort_mismatch":       "カーソルを作成したときと並び順が異なります",
+	"input.cursor_filter_mismatch":     "カーソルを作成したときと絞り込み条件が異なります",
+	"input.unsupported_sort_field":     "「{field}」では並び替えできません",
+	"input.duplicate_sort_field":       "並び替えに「{field}」が複数回指定されています",
+	"input.relevance_requires_keyword": "関連度で並び替えるには検索キーワードを指定してください",
+	"input.invalid_tag_filter":         "タグの絞り込みは1〜{max_length}文字のタグを{max}個以内で指定してください",
+	"input.invalid_attribute_filter":   "カスタム属性の絞り込み「{key}」は属性のキーとして不正です",
+	"input.invalid_price_buckets":      "{name}は昇順の整数をカンマ区切りで指定してください",
+	"input.unknown_column":             "「{column}」は出力できない列です",
+	"input.no_tax_rule":                "{year}年に適用できる税のルールがありません",
+	"input.merge_into_self":            "タグを自身に統合することはできません",
+	"input.multiple_entity_tags":       "If-Match にはエンティティタグを1つだけ指定してください",
+	"input.unquoted_entity_tag":        "If-Match のエンティティタグは引用符で囲んでください",
+	"input.invalid_mapping":            "mapping はフィールド名とCSVのヘッダー名の対応を JSON のオブジェクトで指定してください",
+	"input.unknown_mapping_field":      "mapping にインポートできないフィールドがあります: {field}",
+	"input.unreadable_file":            "{name}を読み込めませんでした",
+	"input.empty_csv":                  "CSVが空です",
+	"input.invalid_csv":                "CSVの{line}行目の形式が不正です: {reason}",
+	"input.too_many_rows":              "CSVは{max}行以内にしてください",
+	"input.missing_column":             "{field}の列「{column}」が見つかりません",
+
+	// フィールド名
+	"field.name":             "名前",
+	"field.category":         "カテゴリー",
+	"field.brand":            "ブランド",
+	"field.purchase_price":   "購入価格",
+	"field.purchase_date":    "購入日",
+	"field.code":             "コード",
+	"field.name_ja":          "日本語名",
+	"field.name_en":          "英語名",
+	"field.sort_order":       "並び順",
+	"field.parent_id":        "親",
+	"field.attributes":       "カスタム属性",
+	"field.attribute_schema": "属性の定義",
+	"field.tags":             "タグ",
+	"field.kind":             "種類",
+	"field.location_id":      "保管場所",
+	"field.note":             "メモ",
+	"field.moved_at":         "移動日時",
+	"field.status":           "所有状況",
+	"field.reason":           "理由",
+	"field.changed_at":       "変更日時",
+	"field.sold_on":          "売却日",
+	"field.sale_price":       "売却価格",
+	"field.fees":             "売却費用",
+	"field.channel":          "売却先",
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/usecase"
+)
+
+// 変更履歴に記録する操作者の最大文字数（item_revisions.actor のカラム長）
+const maxActorLength = 100
+
+// ActorMiddleware は X-Actor ヘッダーの値を操作者としてリクエストの context に設定する
+// 設定された操作者はユースケース層で変更履歴に記録される
+func ActorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		actor := strings.TrimSpace(c.Request().Header.Get("X-Actor"))
+		if actor != "" {
+			if utf8.RuneCountInString(actor) > maxActorLength {
+				actor = string([]rune(actor)[:maxActorLength])
+			}
+			req := c.Request()
+			c.SetRequest(req.WithContext(usecase.WithActor(req.Context(), actor)))
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+)
+
+// errWeakETag は If-Match に弱いETag（W/"..."）が指定された場合のエラー
+// If-Match は強い比較を行うため、弱いETagは常に一致せず、バージョンの不一致と同じ412になる
+var errWeakETag = fmt.Errorf("%w: weak entity tags never match If-Match", domainErrors.ErrVersionConflict)
+
+// errInvalidETag は If-Match にこのAPIが発行していない形式のETag（バージョンの数値でない）が指定された場合のエラー
+// どのバージョンとも一致しないため、バージョンの不一致と同じ412になる
+var errInvalidETag = fmt.Errorf("%w: entity tag does not match any version", domainErrors.ErrVersionConflict)
+
+// itemETag はアイテムのバージョンから強いETagを作成する
+func itemETag(item *entity.Item) string {
+	return strconv.Quote(strconv.Itoa(item.Version))
+}
+
+// setItemETag はレスポンスヘッダーにアイテムのETagを設定する
+func setItemETag(c echo.Context, item *entity.Item) {
+	c.Response().Header().Set("ETag", itemETag(item))
+}
+
+// parseIfMatch は If-Match ヘッダーから更新・削除の前提となるバージョンを取得する
+// ヘッダーがない、または "*" の場合は nil（バージョンを問わない）を返す
+func parseIfMatch(c echo.Context) (*int, error) {
+	value := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	if strings.Contains(value, ",") {
+		return nil, problem.InvalidRequest(i18n.MsgInvalidIfMatch, &domainErrors.InputError{
+			Code:    domainErrors.CodeMultipleEntityTags,
+			Message: "If-Match must contain a single entity tag",
+		})
+	}
+	if strings.HasPrefix(value, "W/") {
+		return nil, errWeakETag
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, problem.InvalidRequest(i18n.MsgInvalidIfMatch, &domainErrors.InputError{
+			Code:    domainErrors.CodeUnquotedEntityTag,
+			Message: "If-Match must be a quoted entity tag",
+		})
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return nil, errInvalidETag
+	}
+
+	return &version, nil
+}
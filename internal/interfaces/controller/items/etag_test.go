@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	version := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		header  string
+		want    *int
+		wantErr error
+	}{
+		{
+			name:   "正常系: ヘッダーがない場合はバージョンを問わない",
+			header: "",
+		},
+		{
+			name:   "正常系: * はバージョンを問わない",
+			header: "*",
+		},
+		{
+			name:   "正常系: 強いETag",
+			header: `"3"`,
+			want:   version(3),
+		},
+		{
+			name:    "異常系: 弱いETag",
+			header:  `W/"3"`,
+			wantErr: errWeakETag,
+		},
+		{
+			name:    "異常系: バージョンの数値でない強いETag",
+			header:  `"abc"`,
+			wantErr: errInvalidETag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			got, err := parseIfMatch(c)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// 何行ごとにレスポンスをクライアントへ送るか
+const exportFlushInterval = 100
+
+// exportColumn はエクスポートできる列
+type exportColumn struct {
+	name  string
+	value func(item *entity.Item) interface{}
+}
+
+// exportColumns はエクスポートできる列の一覧（columns パラメータで指定する名前）
+var exportColumns = []exportColumn{
+	{"id", func(i *entity.Item) interface{} { return i.ID }},
+	{"name", func(i *entity.Item) interface{} { return i.Name }},
+	{"category", func(i *entity.Item) interface{} { return i.Category }},
+	{"brand", func(i *entity.Item) interface{} { return i.Brand }},
+	{"purchase_price", func(i *entity.Item) interface{} { return i.PurchasePrice }},
+	{"purchase_date", func(i *entity.Item) interface{} { return i.PurchaseDate }},
+	{"created_at", func(i *entity.Item) interface{} { return i.CreatedAt.Format(time.RFC3339) }},
+	{"updated_at", func(i *entity.Item) interface{} { return i.UpdatedAt.Format(time.RFC3339) }},
+	// カスタム属性をまとめて1列にする（CSV・Excel では JSON の文字列、NDJSON ではオブジェクト）
+	{"attributes", func(i *entity.Item) interface{} {
+		if i.Attributes == nil {
+			return entity.Attributes{}
+		}
+		return i.Attributes
+	}},
+	// タグをまとめて1列にする（CSV・Excel では JSON の配列の文字列、NDJSON では配列）
+	{"tags", func(i *entity.Item) interface{} { return exportTags(i.Tags) }},
+	{"status", func(i *entity.Item) interface{} { return string(i.Status) }},
+	// 保管場所のID（未設定の場合は空）
+	{"location_id", func(i *entity.Item) interface{} {
+		if i.LocationID == nil {
+			return nil
+		}
+		return *i.LocationID
+	}},
+}
+
+// exportTags はタグの列の値
+// CSV・Excel でもインポートの tags 列と同じ JSON の配列の文字列になるようにする
+type exportTags []string
+
+func (t exportTags) String() string {
+	if t == nil {
+		t = exportTags{}
+	}
+	data, _ := json.Marshal([]string(t))
+	return string(data)
+}
+
+func (t exportTags) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(t))
+}
+
+// columns を指定しない場合にエクスポートする列
+var defaultExportColumns = []string{"id", "name", "category", "brand", "purchase_price", "purchase_date", "attributes", "tags"}
+
+// カスタム属性を1つずつ列にする場合の列名の接頭辞（例: attr.movement）
+// 属性が設定されていないアイテムは空欄（NDJSON では null）になる
+const exportAttributePrefix = "attr."
+
+// itemExporter は1つの出力形式でアイテムを書き出す
+type itemExporter interface {
+	Begin(columns []exportColumn) error
+	Write(item *entity.Item) error
+	Flush() error
+	End() error
+}
+
+// exportFormat は出力形式ごとの Content-Type・拡張子・書き出し処理
+type exportFormat struct {
+	contentType string
+	extension   string
+	newExporter func(w io.Writer, bom bool) itemExporter
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		extension:   "csv",
+		newExporter: func(w io.Writer, bom bool) itemExporter { return &csvExporter{w: w, bom: bom} },
+	},
+	"ndjson": {
+		contentType: "application/x-ndjson",
+		extension:   "ndjson",
+		newExporter: func(w io.Writer, bom bool) itemExporter { return &ndjsonExporter{w: w} },
+	},
+	"xlsx": {
+		contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		extension:   "xlsx",
+		newExporter: func(w io.Writer, bom bool) itemExporter { return &xlsxExporter{w: w} },
+	},
+}
+
+// ExportItems は一覧と同じ絞り込み・並び替え条件で、すべてのアイテムをファイルとして返す
+// GET /items/export?format=csv|ndjson|xlsx に対応
+// リポジトリから1件ずつ読み取りながら書き出すため、件数が多くてもメモリに溜めない
+//   - columns: 出力する列（カンマ区切り、created_at / updated_at や attr.<キー> も指定可能）
+//   - bom:     true の場合はCSVの先頭にBOMを付ける（Excel で文字化けしないようにする）
+func (h *ItemHandler) ExportItems(c echo.Context) error {
+	criteria, errs := h.parseItemCriteria(c)
+
+	formatName := strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if formatName == "" {
+		formatName = "csv"
+	}
+	format, ok := exportFormats[formatName]
+	if !ok {
+		errs.Add(domainErrors.CodeInvalidOption, "format must be one of: csv, ndjson, xlsx", map[string]interface{}{"name": "format", "allowed": []string{"csv", "ndjson", "xlsx"}})
+	}
+
+	columns, err := parseExportColumns(c.QueryParam("columns"))
+	var columnErr *domainErrors.InputError
+	if errors.As(err, &columnErr) {
+		errs = append(errs, columnErr)
+	}
+
+	bom := false
+	if raw := c.QueryParam("bom"); raw != "" {
+		if bom, err = strconv.ParseBool(raw); err != nil {
+			errs.Add(domainErrors.CodeNotBoolean, "bom must be true or false", map[string]interface{}{"name": "bom"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return invalidQueryParams(errs)
+	}
+
+	items, err := h.itemUsecase.StreamItems(c.Request().Context(), criteria)
+	if err != nil {
+		return err
+	}
+
+	// クエリの実行エラーをエラーレスポンスで返せるよう、レスポンスヘッダーは最初の1件を書き出す直前に送る
+	res := c.Response()
+	exporter := format.newExporter(res, bom)
+	started := false
+	count := 0
+	start := func() error {
+		started = true
+		filename := fmt.Sprintf("items-%s.%s", time.Now().Format("20060102-150405"), format.extension)
+		res.Header().Set(echo.HeaderContentType, format.contentType)
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+		res.WriteHeader(http.StatusOK)
+		return exporter.Begin(columns)
+	}
+
+	for item, err := range items {
+		if err == nil && !started {
+			err = start()
+		}
+		if err == nil {
+			err = exporter.Write(item)
+		}
+		if err == nil {
+			count++
+			if count%exportFlushInterval == 0 {
+				if err = exporter.Flush(); err == nil {
+					res.Flush()
+				}
+			}
+		}
+		if err == nil {
+			continue
+		}
+
+		if !started {
+			return err
+		}
+		// 書き出しを始めた後はステータスコードを変えられないため、途中で打ち切る
+		log.Printf("⚠️  Export aborted after %d item(s): %v", count, err)
+		return nil
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	return exporter.End()
+}
+
+// parseExportColumns は columns パラメータを列の定義に変換する
+// 不正な場合は *domainErrors.InputError を返す
+func parseExportColumns(raw string) ([]exportColumn, error) {
+	names := defaultExportColumns
+	if strings.TrimSpace(raw) != "" {
+		names = strings.Split(raw, ",")
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		column, ok := findExportColumn(name)
+		if !ok {
+			return nil, &domainErrors.InputError{
+				Code:    domainErrors.CodeUnknownColumn,
+				Params:  map[string]interface{}{"column": name},
+				Message: fmt.Sprintf("unknown column: %s", name),
+			}
+		}
+		seen[name] = true
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, &domainErrors.InputError{
+			Code:    domainErrors.CodeRequired,
+			Params:  map[string]interface{}{"name": "columns"},
+			Message: "columns must not be empty",
+		}
+	}
+
+	return columns, nil
+}
+
+func findExportColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	if key, ok := strings.CutPrefix(name, exportAttributePrefix); ok && entity.IsValidAttributeKey(key) {
+		return exportColumn{name, func(i *entity.Item) interface{} { return i.Attributes[key] }}, true
+	}
+	return exportColumn{}, false
+}
+
+// csvExporter はCSV（ヘッダー行あり）で書き出す
+type csvExporter struct {
+	w       io.Writer
+	bom     bool
+	cw      *csv.Writer
+	columns []exportColumn
+}
+
+func (e *csvExporter) Begin(columns []exportColumn) error {
+	e.columns = columns
+	if e.bom {
+		if _, err := e.w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+			return err
+		}
+	}
+	e.cw = csv.NewWriter(e.w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	return e.cw.Write(header)
+}
+
+func (e *csvExporter) Write(item *entity.Item) error {
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		if value := column.value(item); value != nil {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return e.cw.Write(record)
+}
+
+func (e *csvExporter) Flush() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+func (e *csvExporter) End() error {
+	return e.Flush()
+}
+
+// ndjsonExporter は1行に1件のJSONオブジェクトで書き出す（キーは columns の順）
+type ndjsonExporter struct {
+	w       io.Writer
+	columns []exportColumn
+	buf     bytes.Buffer
+}
+
+func (e *ndjsonExporter) Begin(columns []exportColumn) error {
+	e.columns = columns
+	return nil
+}
+
+func (e *ndjsonExporter) Write(item *entity.Item) error {
+	e.buf.Reset()
+	e.buf.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column.name)
+		value, err := json.Marshal(column.value(item))
+		if err != nil {
+			return err
+		}
+		e.buf.Write(key)
+		e.buf.WriteByte(':')
+		e.buf.Write(value)
+	}
+	e.buf.WriteString("}\n")
+	_, err := e.w.Write(e.buf.Bytes())
+	return err
+}
+
+func (e *ndjsonExporter) Flush() error { return nil }
+
+func (e *ndjsonExporter) End() error { return nil }
+
+// xlsxExporter はExcelファイル（1行目がヘッダー）で書き出す
+type xlsxExporter struct {
+	w       io.Writer
+	xw      *xlsxWriter
+	columns []exportColumn
+}
+
+func (e *xlsxExporter) Begin(columns []exportColumn) error {
+	e.columns = columns
+	xw, err := newXLSXWriter(e.w)
+	if err != nil {
+		return err
+	}
+	e.xw = xw
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	return e.xw.WriteRow(header)
+}
+
+func (e *xlsxExporter) Write(item *entity.Item) error {
+	row := make([]interface{}, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = column.value(item)
+	}
+	return e.xw.WriteRow(row)
+}
+
+func (e *xlsxExporter) Flush() error {
+	return e.xw.Flush()
+}
+
+func (e *xlsxExporter) End() error {
+	return e.xw.Close()
+}
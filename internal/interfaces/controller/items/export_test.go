@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+)
+
+func exportTestItems() []*entity.Item {
+	locationID := int64(3)
+	return []*entity.Item{
+		{
+			ID:            1,
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 1500000,
+			PurchaseDate:  "2023-01-15",
+			Attributes:    entity.Attributes{"movement": "automatic"},
+			Tags:          []string{"gift", "vintage"},
+			Status:        entity.StatusOwned,
+			LocationID:    &locationID,
+			CreatedAt:     time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC),
+			UpdatedAt:     time.Date(2023, 1, 16, 11, 30, 0, 0, time.UTC),
+		},
+		{
+			// CSV の区切り文字・引用符・改行と、XML で特別な意味を持つ文字を含む
+			ID:            2,
+			Name:          `Chair "Poäng", <oak> & birch`,
+			Category:      "家具",
+			Brand:         "IKEA\n2nd line",
+			PurchasePrice: 12900,
+			PurchaseDate:  "2023-02-01",
+			Status:        entity.StatusOwned,
+			CreatedAt:     time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC),
+			UpdatedAt:     time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// runExporter は columns の列で items を書き出して終える
+func runExporter(t *testing.T, exporter itemExporter, columns []exportColumn, items []*entity.Item) {
+	t.Helper()
+	require.NoError(t, exporter.Begin(columns))
+	for _, item := range items {
+		require.NoError(t, exporter.Write(item))
+	}
+	require.NoError(t, exporter.End())
+}
+
+func TestParseExportColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "正常系: 指定しない場合はデフォルトの列",
+			raw:  "",
+			want: defaultExportColumns,
+		},
+		{
+			name: "正常系: 指定した順に並ぶ",
+			raw:  "purchase_price,name,id",
+			want: []string{"purchase_price", "name", "id"},
+		},
+		{
+			name: "正常系: 登録日時・更新日時とカスタム属性の列",
+			raw:  "id, created_at ,updated_at,attr.movement",
+			want: []string{"id", "created_at", "updated_at", "attr.movement"},
+		},
+		{
+			name: "正常系: 重複と空の列は無視する",
+			raw:  "name,,name,id",
+			want: []string{"name", "id"},
+		},
+		{
+			name:    "異常系: 存在しない列",
+			raw:     "name,price",
+			wantErr: "unknown column: price",
+		},
+		{
+			name:    "異常系: 無効な属性のキー",
+			raw:     "attr.",
+			wantErr: "unknown column: attr.",
+		},
+		{
+			name:    "異常系: 列が空",
+			raw:     " , ",
+			wantErr: "columns must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := parseExportColumns(tt.raw)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, len(columns))
+			for i, column := range columns {
+				names[i] = column.name
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestCSVExporter(t *testing.T) {
+	tests := []struct {
+		name    string
+		bom     bool
+		columns string
+		want    [][]string
+	}{
+		{
+			name:    "正常系: デフォルトの列",
+			columns: "",
+			want: [][]string{
+				{"id", "name", "category", "brand", "purchase_price", "purchase_date", "attributes", "tags"},
+				{"1", "ロレックス デイトナ", "時計", "ROLEX", "1500000", "2023-01-15", `{"movement":"automatic"}`, `["gift","vintage"]`},
+				{"2", `Chair "Poäng", <oak> & birch`, "家具", "IKEA\n2nd line", "12900", "2023-02-01", "", "[]"},
+			},
+		},
+		{
+			name:    "正常系: BOM付きで指定した列を指定した順に書き出す",
+			bom:     true,
+			columns: "updated_at,id,created_at,location_id,attr.movement",
+			want: [][]string{
+				{"updated_at", "id", "created_at", "location_id", "attr.movement"},
+				{"2023-01-16T11:30:00Z", "1", "2023-01-15T10:00:00Z", "3", "automatic"},
+				{"2023-02-01T09:00:00Z", "2", "2023-02-01T09:00:00Z", "", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := parseExportColumns(tt.columns)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+
+			runExporter(t, &csvExporter{w: &buf, bom: tt.bom}, columns, exportTestItems())
+
+			data := buf.Bytes()
+			if tt.bom {
+				require.True(t, bytes.HasPrefix(data, []byte("\xEF\xBB\xBF")), "BOM がない")
+				data = data[3:]
+			} else {
+				assert.False(t, bytes.HasPrefix(data, []byte("\xEF\xBB\xBF")), "BOM を付けていない")
+			}
+			records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, records)
+		})
+	}
+}
+
+// xlsxCell はシートのXMLのセル（数値は v、文字列は is/t に値が入る）
+type xlsxCell struct {
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestXLSXExporter(t *testing.T) {
+	columns, err := parseExportColumns("id,name,brand,purchase_price,created_at,tags,location_id")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+
+	runExporter(t, &xlsxExporter{w: &buf}, columns, exportTestItems())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err, "zip として開けない")
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		require.Contains(t, files, name)
+		rc, err := files[name].Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		// すべてのパーツが整形式のXMLになっている
+		var v struct{}
+		assert.NoError(t, xml.Unmarshal(body, &v), name)
+
+		if name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		var sheet xlsxSheet
+		require.NoError(t, xml.Unmarshal(body, &sheet))
+
+		var got [][]string
+		for _, row := range sheet.Rows {
+			var values []string
+			for _, cell := range row.Cells {
+				if cell.Type == "n" {
+					values = append(values, "n:"+cell.Value)
+				} else {
+					assert.Equal(t, "inlineStr", cell.Type)
+					values = append(values, cell.Inline)
+				}
+			}
+			got = append(got, values)
+		}
+		assert.Equal(t, [][]string{
+			{"id", "name", "brand", "purchase_price", "created_at", "tags", "location_id"},
+			{"n:1", "ロレックス デイトナ", "ROLEX", "n:1500000", "2023-01-15T10:00:00Z", `["gift","vintage"]`, "n:3"},
+			{"n:2", `Chair "Poäng", <oak> & birch`, "IKEA\n2nd line", "n:12900", "2023-02-01T09:00:00Z", "[]", ""},
+		}, got)
+	}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// アップロードできるCSVの最大サイズ（10MB）
+const maxImportBytes = 10 << 20
+
+// errImportTooLarge はアップロードされたCSVが maxImportBytes を超えた場合のエラー
+var errImportTooLarge = problem.New(problem.TypePayloadTooLarge, i18n.MsgPayloadTooLarge)
+
+// isBodyTooLarge はリクエストボディの読み込みが http.MaxBytesReader の上限を超えて失敗したかを返す
+func isBodyTooLarge(err error) bool {
+	return errors.As(err, new(*http.MaxBytesError))
+}
+
+// ImportItems はCSVからアイテムを一括登録する
+// POST /items/import に対応
+//
+// CSVは multipart/form-data の file フィールド、または text/csv のリクエストボディで受け取る
+// 以下はクエリパラメータまたはフォームの値で指定する
+//   - mapping:  フィールド名とCSVのヘッダー名の対応（JSON）例: {"name":"品名","purchase_price":"購入価格"}
+//   - encoding: auto（デフォルト）/ utf-8 / shift_jis
+//   - dry_run:  true の場合は検証のみ行う
+func (h *ItemHandler) ImportItems(c echo.Context) error {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxImportBytes)
+
+	var errs domainErrors.InputErrors
+	input := usecase.ImportItemsInput{
+		Encoding: c.FormValue("encoding"),
+	}
+
+	if raw := c.FormValue("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs.Add(domainErrors.CodeNotBoolean, "dry_run must be true or false", map[string]interface{}{"name": "dry_run"})
+		}
+		input.DryRun = dryRun
+	}
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &input.Mapping); err != nil {
+			errs.Add(domainErrors.CodeInvalidMapping, "mapping must be a JSON object of field name to column name", nil)
+		}
+	}
+
+	var body io.Reader
+	if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		file, err := c.FormFile("file")
+		if isBodyTooLarge(err) {
+			return errImportTooLarge
+		}
+		if err != nil {
+			errs.Add(domainErrors.CodeRequired, "file is required", map[string]interface{}{"name": "file"})
+		} else {
+			f, err := file.Open()
+			if err != nil {
+				errs.Add(domainErrors.CodeUnreadableFile, "file could not be read", map[string]interface{}{"name": "file"})
+			} else {
+				defer f.Close()
+				body = f
+			}
+		}
+	} else {
+		body = req.Body
+	}
+
+	if len(errs) > 0 {
+		return problem.InvalidRequest(i18n.MsgInvalidImportRequest, errs...)
+	}
+	input.CSV = body
+
+	report, err := h.itemUsecase.ImportItems(req.Context(), input)
+	if isBodyTooLarge(err) {
+		return errImportTooLarge
+	}
+	if err != nil {
+		return err
+	}
+
+	// 失敗した行があり何も登録しなかった場合は 422 で行ごとの結果を返す
+	if !report.DryRun && !report.Committed {
+		return c.JSON(http.StatusUnprocessableEntity, report)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
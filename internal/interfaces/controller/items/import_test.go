@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// emptyCategoryRepository はカテゴリーが1件もないテスト用のリポジトリ（FindAll 以外を呼ぶとパニックする）
+type emptyCategoryRepository struct {
+	usecase.CategoryRepository
+}
+
+func (emptyCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	return nil, nil
+}
+
+func TestItemHandler_ImportItems_TooLarge(t *testing.T) {
+	// ヘッダーの後に、上限を1バイト超えるまで続く値を置く
+	header := "name,category,brand,purchase_price,purchase_date\n"
+	oversized := header + strings.Repeat("a", maxImportBytes+1-len(header))
+
+	multipartBody := func(t *testing.T) (string, string) {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		part, err := w.CreateFormFile("file", "items.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(oversized))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.String(), w.FormDataContentType()
+	}
+
+	tests := []struct {
+		name string
+		body func(t *testing.T) (string, string) // ボディと Content-Type
+		url  string
+	}{
+		{
+			name: "異常系: 文字コードを自動判定するCSVのボディが上限を超える",
+			body: func(t *testing.T) (string, string) { return oversized, "text/csv" },
+			url:  "/items/import?dry_run=true",
+		},
+		{
+			name: "異常系: UTF-8 を指定したCSVのボディが上限を超える",
+			body: func(t *testing.T) (string, string) { return oversized, "text/csv" },
+			url:  "/items/import?dry_run=true&encoding=utf-8",
+		},
+		{
+			name: "異常系: multipart のファイルが上限を超える",
+			body: multipartBody,
+			url:  "/items/import?dry_run=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = problem.HTTPErrorHandler
+			// ボディの読み込みで失敗するため、カテゴリー以外のリポジトリは使わない
+			categories := usecase.NewCategoryCache(emptyCategoryRepository{}, time.Hour)
+			handler := NewItemHandler(usecase.NewItemUsecase(nil, nil, nil, categories, nil, nil, nil, nil, nil), nil, nil)
+			e.POST("/items/import", handler.ImportItems)
+
+			body, contentType := tt.body(t)
+			req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, contentType)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+			assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var p problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, problem.TypePayloadTooLarge.URI(), p.Type)
+		})
+	}
+}
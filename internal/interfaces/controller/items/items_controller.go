@@ -5,57 +5,113 @@ import (
 	"strconv"
 
 	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
 	"aicon-coding-test/internal/usecase"
 
 	"github.com/labstack/echo/v4"
 )
 
+// ハンドラーはエラーをそのまま返し、レスポンスへの変換は problem.HTTPErrorHandler が行う
 type ItemHandler struct {
-	itemUsecase usecase.ItemUsecase
+	itemUsecase  usecase.ItemUsecase
+	cursorCodec  *usecase.CursorCodec
+	priceBuckets []usecase.PriceBucket // ファセット集計のデフォルトの価格帯
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
+func NewItemHandler(itemUsecase usecase.ItemUsecase, cursorCodec *usecase.CursorCodec, priceBuckets []usecase.PriceBucket) *ItemHandler {
 	return &ItemHandler{
-		itemUsecase: itemUsecase,
+		itemUsecase:  itemUsecase,
+		cursorCodec:  cursorCodec,
+		priceBuckets: priceBuckets,
 	}
 }
 
-// エラーレスポンスの形式
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
+// よく使うリクエスト形式のエラー
+var (
+	errInvalidItemID     = problem.InvalidRequest(i18n.MsgInvalidItemID)
+	errInvalidBodyFormat = problem.InvalidRequest(i18n.MsgInvalidRequestFormat)
+)
+
+// invalidQueryParams はクエリパラメータの形式が不正な場合のエラーを作成する
+func invalidQueryParams(details domainErrors.InputErrors) error {
+	return problem.InvalidRequest(i18n.MsgInvalidQueryParams, details...)
+}
+
+// parseItemID はパスパラメータの id を取得する
+func parseItemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, errInvalidItemID
+	}
+	return id, nil
+}
+
+// 一覧レスポンスの形式
+// next_cursor は次ページがある場合のみ含まれる不透明なトークン
+type itemListResponse struct {
+	*usecase.ItemList
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
+// GetItems はアイテム一覧を取得する
+// category, brand, min_price, max_price, purchased_from, purchased_to で絞り込み、
+// sort（例: -purchase_price,name）で並び替え、limit/offset または cursor でページングする
+// Accept: application/x-ndjson または stream=true の場合は、ページングせずに全件をストリーミングで返す
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	criteria, paramErrors := h.parseItemCriteria(c)
+	if len(paramErrors) > 0 {
+		return invalidQueryParams(paramErrors)
+	}
+
+	if format := requestedStreamFormat(c); format != streamNone {
+		return h.streamItems(c, criteria, format)
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), criteria)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, items)
+	response := itemListResponse{ItemList: items}
+	if items.NextCursor != nil {
+		response.NextCursor = h.cursorCodec.Encode(items.NextCursor)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// SearchItems は名前・ブランドを全文検索する
+// q に検索キーワードを指定し、GetItems と同じ絞り込み・ページングのパラメータも使用できる
+func (h *ItemHandler) SearchItems(c echo.Context) error {
+	criteria, paramErrors := h.parseItemCriteria(c)
+	if len(paramErrors) > 0 {
+		return invalidQueryParams(paramErrors)
+	}
+	criteria.Keyword = c.QueryParam("q")
+
+	result, err := h.itemUsecase.SearchItems(c.Request().Context(), criteria)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
 }
 
 func (h *ItemHandler) GetItem(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseItemID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return err
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return err
+	}
+
+	setItemETag(c, item)
+	if match := c.Request().Header.Get("If-None-Match"); match != "" && match == itemETag(item) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	return c.JSON(http.StatusOK, item)
@@ -64,139 +120,173 @@ func (h *ItemHandler) GetItem(c echo.Context) error {
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
-	}
-
-	// バリデーション
-	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return errInvalidBodyFormat
 	}
 
+	// バリデーションはエンティティで行い、エラーはフィールドごとに返される
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+		return err
 	}
 
+	setItemETag(c, item)
 	return c.JSON(http.StatusCreated, item)
 }
 
 // UpdateItem はアイテムの部分更新を行うPATCHエンドポイント
 // PATCH /items/{id} に対応
-// name, brand, purchase_price のみ更新可能（部分更新対応）
+// 送信されたフィールドのみ更新する（部分更新対応）
 func (h *ItemHandler) UpdateItem(c echo.Context) error {
 	// URLパラメータからアイテムIDを取得
-	idStr := c.Param("id")
-	// 文字列のIDを64bit整数に変換
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseItemID(c)
 	if err != nil {
-		// IDが無効な場合は400エラーを返す
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return err
 	}
 
 	// リクエストボディをUpdateItemInput構造体にバインド（JSONをGoの構造体に変換）
 	var input usecase.UpdateItemInput
 	if err := c.Bind(&input); err != nil {
-		// JSONの形式が不正な場合は400エラーを返す
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return errInvalidBodyFormat
+	}
+
+	// If-Match ヘッダーがあれば、そのバージョンのときのみ更新する
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
 	}
 
 	// ユースケース層のUpdateItem関数を呼び出してアイテムを更新
-	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
-	if err != nil {
-		// アイテムが見つからない場合は404エラー
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		// バリデーションエラーの場合は400エラー
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
-		}
-		// その他のエラーは500エラー
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to update item",
-		})
+	// 見つからない場合は404、先に更新されていた場合は412、バリデーションエラーは400になる
+	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input, expectedVersion)
+	if err != nil {
+		return err
 	}
 
 	// 更新成功時は200ステータスで更新されたアイテムをJSONで返す
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// ReplaceItem はアイテムの内容を丸ごと置き換えるPUTエンドポイント
+// PUT /items/{id} に対応
+// 全フィールドが必須で、id と created_at は変わらない
+func (h *ItemHandler) ReplaceItem(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.ReplaceItemInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.itemUsecase.ReplaceItem(c.Request().Context(), id, input, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, item)
 	return c.JSON(http.StatusOK, item)
 }
 
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := parseItemID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return err
 	}
 
-	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
+	expectedVersion, err := parseIfMatch(c)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		return err
+	}
+
+	if err := h.itemUsecase.DeleteItem(c.Request().Context(), id, expectedVersion); err != nil {
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-func (h *ItemHandler) GetSummary(c echo.Context) error {
-	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
+// GetTrash はゴミ箱にあるアイテムの一覧を返す
+// GET /items/trash?limit=&offset= に対応
+func (h *ItemHandler) GetTrash(c echo.Context) error {
+	var errs domainErrors.InputErrors
+	limit, limitErr := queryIntPtr(c, "limit")
+	if limitErr != nil {
+		errs = append(errs, limitErr)
+	}
+	offset, offsetErr := queryIntPtr(c, "offset")
+	if offsetErr != nil {
+		errs = append(errs, offsetErr)
+	}
+	if limit != nil && *limit == 0 {
+		errs = append(errs, limitTooSmall())
+	}
+	if len(errs) > 0 {
+		return invalidQueryParams(errs)
+	}
+
+	var l, o int
+	if limit != nil {
+		l = *limit
+	}
+	if offset != nil {
+		o = *offset
+	}
+
+	list, err := h.itemUsecase.GetTrashedItems(c.Request().Context(), l, o)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, summary)
+	return c.JSON(http.StatusOK, list)
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
-	var errs []string
-
-	// Basic required field validation
-	if input.Name == "" {
-		errs = append(errs, "name is required")
+// RestoreItem はゴミ箱にあるアイテムを元に戻す
+// POST /items/{id}/restore に対応
+func (h *ItemHandler) RestoreItem(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
 	}
-	if input.Category == "" {
-		errs = append(errs, "category is required")
+
+	item, err := h.itemUsecase.RestoreItem(c.Request().Context(), id)
+	if err != nil {
+		return err
 	}
-	if input.Brand == "" {
-		errs = append(errs, "brand is required")
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// PurgeItem はゴミ箱にあるアイテムを完全に削除する（元に戻せない）
+// DELETE /items/{id}/purge に対応（売却の記録があるアイテムは409）
+func (h *ItemHandler) PurgeItem(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
 	}
-	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+
+	if err := h.itemUsecase.PurgeItem(c.Request().Context(), id); err != nil {
+		return err
 	}
-	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSummary はカテゴリー別集計を返す
+// status（カンマ区切り）を省略した場合は現在の所有資産（owned, on_loan, in_repair, consigned）のみを集計する
+func (h *ItemHandler) GetSummary(c echo.Context) error {
+	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context(), queryStatuses(c))
+	if err != nil {
+		return err
 	}
 
-	return errs
+	return c.JSON(http.StatusOK, summary)
 }
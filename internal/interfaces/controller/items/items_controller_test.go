@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// stubItemUsecase は UpdateItem・GetAllItems だけを差し替えたテスト用のユースケース（それ以外を呼ぶとパニックする）
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	updateItem  func(id int64, input usecase.UpdateItemInput) (*entity.Item, error)
+	getAllItems func(criteria usecase.ItemCriteria) (*usecase.ItemList, error)
+}
+
+func (s *stubItemUsecase) GetAllItems(ctx context.Context, criteria usecase.ItemCriteria) (*usecase.ItemList, error) {
+	return s.getAllItems(criteria)
+}
+
+func (s *stubItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput, expectedVersion *int) (*entity.Item, error) {
+	return s.updateItem(id, input)
+}
+
+func TestItemHandler_UpdateItem_ValidationErrors(t *testing.T) {
+	var fieldErrs domainErrors.ValidationErrors
+	fieldErrs.Add("name", "required", "name is required", nil)
+
+	tests := []struct {
+		name        string
+		body        string
+		err         error
+		wantStatus  int
+		wantType    string
+		wantDetail  string
+		wantDetails []string
+		wantFields  []string
+	}{
+		{
+			name:        "異常系: フィールド単位でないバリデーションエラー（更新するフィールドがない）",
+			body:        `{}`,
+			err:         domainErrors.NewInputError(domainErrors.CodeNoFieldsToUpdate, "no fields to update", nil),
+			wantStatus:  http.StatusBadRequest,
+			wantType:    problem.TypeValidationFailed.URI(),
+			wantDetail:  "the request contains invalid input",
+			wantDetails: []string{"no fields to update"},
+		},
+		{
+			name:       "異常系: 詳細のないバリデーションエラー",
+			body:       `{"name": "時計"}`,
+			err:        domainErrors.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+			wantType:   problem.TypeValidationFailed.URI(),
+			wantDetail: "invalid input",
+		},
+		{
+			name:       "異常系: フィールド単位のバリデーションエラー",
+			body:       `{"name": ""}`,
+			err:        fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, fieldErrs),
+			wantStatus: http.StatusBadRequest,
+			wantType:   problem.TypeValidationFailed.URI(),
+			wantFields: []string{"name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = problem.HTTPErrorHandler
+			handler := NewItemHandler(&stubItemUsecase{
+				updateItem: func(id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+					return nil, tt.err
+				},
+			}, nil, nil)
+			e.PATCH("/items/:id", handler.UpdateItem)
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Accept-Language", "en")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var body problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantType, body.Type)
+			assert.Equal(t, tt.wantStatus, body.Status)
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, body.Detail)
+			}
+			assert.Equal(t, tt.wantDetails, body.Details)
+			var fields []string
+			for _, fe := range body.Errors {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestItemHandler_GetItems_LocalizedErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		wantType       string
+		wantDetail     string
+		wantDetails    []string
+	}{
+		{
+			name:           "異常系: 形式が不正なクエリパラメータを日本語で返す",
+			target:         "/items?min_price=abc&limit=x",
+			acceptLanguage: "ja",
+			wantType:       problem.TypeInvalidRequest.URI(),
+			wantDetail:     "クエリパラメータが不正です",
+			wantDetails:    []string{"min_priceは整数で指定してください", "limitは整数で指定してください"},
+		},
+		{
+			name:           "異常系: 並び替えに使えないフィールドを日本語で返す",
+			target:         "/items?sort=price",
+			acceptLanguage: "ja",
+			wantType:       problem.TypeInvalidRequest.URI(),
+			wantDetail:     "クエリパラメータが不正です",
+			wantDetails:    []string{"「price」では並び替えできません"},
+		},
+		{
+			name:           "異常系: 一覧の条件のエラーを日本語で返す",
+			target:         "/items?limit=1000&offset=-1",
+			acceptLanguage: "ja",
+			wantType:       problem.TypeValidationFailed.URI(),
+			wantDetail:     "リクエストの内容に誤りがあります",
+			wantDetails:    []string{"limitは1以上100以下で指定してください", "offsetは0以上で指定してください"},
+		},
+		{
+			name:           "異常系: 一覧の条件のエラーを英語で返す",
+			target:         "/items?limit=1000",
+			acceptLanguage: "en",
+			wantType:       problem.TypeValidationFailed.URI(),
+			wantDetail:     "the request contains invalid input",
+			wantDetails:    []string{"limit must be between 1 and 100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = problem.HTTPErrorHandler
+			handler := NewItemHandler(&stubItemUsecase{
+				getAllItems: func(criteria usecase.ItemCriteria) (*usecase.ItemList, error) {
+					return nil, criteria.Normalize()
+				},
+			}, nil, nil)
+			e.GET("/items", handler.GetItems)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tt.acceptLanguage, rec.Header().Get("Content-Language"))
+			var body problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantType, body.Type)
+			assert.Equal(t, tt.wantDetail, body.Detail)
+			assert.Equal(t, tt.wantDetails, body.Details)
+		})
+	}
+}
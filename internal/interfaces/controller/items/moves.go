@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/domain/entity"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+var errInvalidLocationID = problem.InvalidRequest(i18n.MsgInvalidLocationID)
+
+// locationItemsResponse は GET /locations/{id}/items のレスポンス
+// 一覧の項目は GET /items と同じで、保管場所と最上位からのパスを加える
+type locationItemsResponse struct {
+	Location *entity.Location `json:"location"`
+	Path     []string         `json:"path"`
+	itemListResponse
+}
+
+// MoveItem はアイテムを別の保管場所に移動し、移動履歴を記録する
+// POST /items/{id}/move に対応（If-Match 対応）
+func (h *ItemHandler) MoveItem(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.MoveItemInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.itemUsecase.MoveItem(c.Request().Context(), id, input, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// GetMoves はアイテムの移動履歴を移動日時の新しい順に返す
+// GET /items/{id}/moves に対応
+func (h *ItemHandler) GetMoves(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	moves, err := h.itemUsecase.GetItemMoves(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"moves": moves,
+	})
+}
+
+// GetLocationItems は保管場所の中（中にある保管場所を含む）に置かれているアイテムを返す
+// GET /locations/{id}/items に対応（GET /items と同じ絞り込み・並び替え・ページングを使用できる）
+func (h *ItemHandler) GetLocationItems(c echo.Context) error {
+	locationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || locationID <= 0 {
+		return errInvalidLocationID
+	}
+
+	criteria, paramErrors := h.parseItemCriteria(c)
+	if len(paramErrors) > 0 {
+		return invalidQueryParams(paramErrors)
+	}
+
+	result, err := h.itemUsecase.GetLocationItems(c.Request().Context(), locationID, criteria)
+	if err != nil {
+		return err
+	}
+
+	response := locationItemsResponse{
+		Location:         result.Location,
+		Path:             result.Path,
+		itemListResponse: itemListResponse{ItemList: result.Items},
+	}
+	if result.Items.NextCursor != nil {
+		response.NextCursor = h.cursorCodec.Encode(result.Items.NextCursor)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
+)
+
+// カスタム属性で絞り込むクエリパラメータの接頭辞
+const attributeParamPrefix = "attr."
+
+// parseItemCriteria はクエリパラメータから一覧取得条件を組み立てる
+// 形式が不正なパラメータはまとめてエラーとして返す
+func (h *ItemHandler) parseItemCriteria(c echo.Context) (usecase.ItemCriteria, domainErrors.InputErrors) {
+	var criteria usecase.ItemCriteria
+	var errs domainErrors.InputErrors
+
+	criteria.Category = strings.TrimSpace(c.QueryParam("category"))
+	criteria.Brand = strings.TrimSpace(c.QueryParam("brand"))
+	criteria.PurchasedFrom = strings.TrimSpace(c.QueryParam("purchased_from"))
+	criteria.PurchasedTo = strings.TrimSpace(c.QueryParam("purchased_to"))
+
+	// attr.<キー>=<値> でカスタム属性を絞り込む（例: attr.movement=automatic）
+	for name, values := range c.QueryParams() {
+		key, ok := strings.CutPrefix(name, attributeParamPrefix)
+		if !ok {
+			continue
+		}
+		if criteria.Attributes == nil {
+			criteria.Attributes = make(map[string]string)
+		}
+		criteria.Attributes[key] = strings.TrimSpace(values[0])
+	}
+
+	// tags_any はいずれかのタグ、tags_all はすべてのタグが付いたアイテムに絞り込む
+	// タグ名にはカンマを含められるため、複数指定はパラメータを繰り返す（例: tags_any=旅行&tags_any=限定品）
+	criteria.TagsAny = c.QueryParams()["tags_any"]
+	criteria.TagsAll = c.QueryParams()["tags_all"]
+	criteria.Statuses = queryStatuses(c)
+
+	if v, err := queryIntPtr(c, "min_price"); err != nil {
+		errs = append(errs, err)
+	} else {
+		criteria.MinPrice = v
+	}
+	if v, err := queryIntPtr(c, "max_price"); err != nil {
+		errs = append(errs, err)
+	} else {
+		criteria.MaxPrice = v
+	}
+	// location_id は中にある保管場所に置かれたアイテムも含めて絞り込む
+	if raw := strings.TrimSpace(c.QueryParam("location_id")); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			errs.Add(domainErrors.CodeNotInteger, "location_id must be an integer", map[string]interface{}{"name": "location_id"})
+		} else {
+			criteria.LocationID = &v
+		}
+	}
+	if v, err := queryIntPtr(c, "limit"); err != nil {
+		errs = append(errs, err)
+	} else if v != nil {
+		criteria.Limit = *v
+		if *v == 0 {
+			errs = append(errs, limitTooSmall())
+		}
+	}
+	if v, err := queryIntPtr(c, "offset"); err != nil {
+		errs = append(errs, err)
+	} else if v != nil {
+		criteria.Offset = *v
+	}
+
+	if sort := c.QueryParam("sort"); sort != "" {
+		fields, err := usecase.ParseSortFields(sort)
+		if err != nil {
+			// どのフィールドが不正かをそのまま返す（例: sort field "price" is not supported）
+			sortErrs, _ := domainErrors.AsInputErrors(err)
+			errs = append(errs, sortErrs...)
+		} else {
+			criteria.Sort = fields
+		}
+	}
+
+	if token := strings.TrimSpace(c.QueryParam("cursor")); token != "" {
+		cursor, err := h.cursorCodec.Decode(token)
+		if err != nil {
+			errs.Add(domainErrors.CodeInvalidCursor, "cursor is invalid", nil)
+		} else {
+			criteria.After = cursor
+		}
+	}
+
+	// facets=true の場合はファセット集計を含める（price_buckets で価格帯の区切りを変更できる）
+	if facets, _ := strconv.ParseBool(c.QueryParam("facets")); facets {
+		options := &usecase.FacetOptions{PriceBuckets: h.priceBuckets}
+		if raw := c.QueryParam("price_buckets"); raw != "" {
+			buckets, err := usecase.ParsePriceBuckets(raw)
+			if err != nil {
+				errs.Add(domainErrors.CodeInvalidPriceBuckets, "price_buckets must be comma-separated ascending integers", map[string]interface{}{"name": "price_buckets"})
+			} else {
+				options.PriceBuckets = buckets
+			}
+		}
+		criteria.Facets = options
+	}
+
+	return criteria, errs
+}
+
+// queryStatuses は所有状況のクエリパラメータを取得する
+// カンマ区切り・パラメータの繰り返しのどちらでも複数指定できる（例: status=owned,on_loan）
+func queryStatuses(c echo.Context) []string {
+	var statuses []string
+	for _, raw := range c.QueryParams()["status"] {
+		for _, status := range strings.Split(raw, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	return statuses
+}
+
+// queryIntPtr は整数のクエリパラメータを取得する（未指定の場合はnil）
+func queryIntPtr(c echo.Context, name string) (*int, *domainErrors.InputError) {
+	raw := strings.TrimSpace(c.QueryParam(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, &domainErrors.InputError{
+			Code:    domainErrors.CodeNotInteger,
+			Params:  map[string]interface{}{"name": name},
+			Message: fmt.Sprintf("%s must be an integer", name),
+		}
+	}
+	return &v, nil
+}
+
+// limitTooSmall は limit に0を指定した場合のエラー
+func limitTooSmall() *domainErrors.InputError {
+	return &domainErrors.InputError{
+		Code:    domainErrors.CodeTooSmall,
+		Params:  map[string]interface{}{"name": "limit", "min": 1},
+		Message: "limit must be 1 or greater",
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+)
+
+// GetRevisions はアイテムの変更履歴を新しい順に返す
+// GET /items/{id}/revisions に対応
+func (h *ItemHandler) GetRevisions(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.itemUsecase.GetItemRevisions(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"revisions": revisions,
+	})
+}
+
+// GetRevision は指定した連番の変更履歴を返す
+// GET /items/{id}/revisions/{rev} に対応
+func (h *ItemHandler) GetRevision(c echo.Context) error {
+	id, rev, err := parseRevisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	revision, err := h.itemUsecase.GetItemRevision(c.Request().Context(), id, rev)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, revision)
+}
+
+// RevertItem はアイテムの内容を指定した変更履歴の時点に戻す
+// POST /items/{id}/revisions/{rev}/revert に対応（If-Match 対応）
+func (h *ItemHandler) RevertItem(c echo.Context) error {
+	id, rev, err := parseRevisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.itemUsecase.RevertItem(c.Request().Context(), id, rev, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// parseRevisionParams はURLパラメータからアイテムIDと変更履歴の連番を取得する
+func parseRevisionParams(c echo.Context) (int64, int, error) {
+	invalid := problem.InvalidRequest(i18n.MsgInvalidRevisionParams)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		return 0, 0, invalid
+	}
+	return id, rev, nil
+}
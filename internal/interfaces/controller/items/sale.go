@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
+)
+
+// SellItem はアイテムの売却を記録し、所有状況を sold に変更する
+// POST /items/{id}/sell に対応（If-Match 対応。売却できない所有状況の場合は400）
+func (h *ItemHandler) SellItem(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.SellItemInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	sale, err := h.itemUsecase.SellItem(c.Request().Context(), id, input, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, sale.Item)
+	return c.JSON(http.StatusOK, sale)
+}
+
+// GetSale はアイテムの売却の記録を返す
+// GET /items/{id}/sale に対応（売却していないアイテムの場合は404）
+func (h *ItemHandler) GetSale(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	disposal, err := h.itemUsecase.GetItemDisposal(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, disposal)
+}
+
+// GetRealizedGains は売却損益を売却した年・カテゴリーごとに返す
+// GET /items/realized-gains?year= に対応（year を省略した場合はすべての年）
+func (h *ItemHandler) GetRealizedGains(c echo.Context) error {
+	year, paramErr := queryIntPtr(c, "year")
+	if paramErr != nil {
+		return invalidQueryParams(domainErrors.InputErrors{paramErr})
+	}
+
+	report, err := h.itemUsecase.GetRealizedGains(c.Request().Context(), year)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
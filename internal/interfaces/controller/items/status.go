@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/usecase"
+)
+
+// ChangeStatus はアイテムの所有状況を変更する
+// POST /items/{id}/status に対応（If-Match 対応。遷移できない状態の場合は400）
+func (h *ItemHandler) ChangeStatus(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.ChangeItemStatusInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.itemUsecase.ChangeItemStatus(c.Request().Context(), id, input, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// GetStatusEvents はアイテムの所有状況の変更の記録を変更日時の新しい順に返す
+// GET /items/{id}/status-events に対応
+func (h *ItemHandler) GetStatusEvents(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.itemUsecase.GetItemStatusEvents(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"events": events,
+	})
+}
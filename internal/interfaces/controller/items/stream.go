@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/usecase"
+)
+
+// ストリーミング時に何件ごとにレスポンスをクライアントへ送るか
+const streamFlushInterval = 100
+
+const mimeApplicationNDJSON = "application/x-ndjson"
+
+// streamFormat は一覧をストリーミングで返す形式
+type streamFormat int
+
+const (
+	streamNone streamFormat = iota
+	streamJSONArray
+	streamNDJSON
+)
+
+// requestedStreamFormat はリクエストがストリーミングを求めているかを判定する
+// Accept: application/x-ndjson の場合は NDJSON、stream=true の場合は JSON 配列で返す
+func requestedStreamFormat(c echo.Context) streamFormat {
+	for _, accept := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accept, ";", 2)[0])
+		if strings.EqualFold(mediaType, mimeApplicationNDJSON) {
+			return streamNDJSON
+		}
+	}
+	if stream, _ := strconv.ParseBool(c.QueryParam("stream")); stream {
+		return streamJSONArray
+	}
+	return streamNone
+}
+
+// streamItems は条件に一致するすべてのアイテムを1件ずつレスポンスに書き出す
+// ページングの指定は無視し、一定件数ごとにフラッシュする
+// クライアントが切断するとリクエストの context がキャンセルされ、データベースの読み取りも止まる
+func (h *ItemHandler) streamItems(c echo.Context, criteria usecase.ItemCriteria, format streamFormat) error {
+	ctx := c.Request().Context()
+
+	items, err := h.itemUsecase.StreamItems(ctx, criteria)
+	if err != nil {
+		return err
+	}
+
+	// クエリの実行エラーをエラーレスポンスで返せるよう、レスポンスヘッダーは最初の1件を書き出す直前に送る
+	res := c.Response()
+	encoder := json.NewEncoder(res)
+	started := false
+	count := 0
+	start := func() error {
+		started = true
+		if format == streamNDJSON {
+			res.Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+			res.WriteHeader(http.StatusOK)
+			return nil
+		}
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		res.WriteHeader(http.StatusOK)
+		_, err := res.Write([]byte("["))
+		return err
+	}
+
+	for item, err := range items {
+		if err == nil && !started {
+			err = start()
+		}
+		if err == nil && format == streamJSONArray && count > 0 {
+			_, err = res.Write([]byte(","))
+		}
+		if err == nil {
+			// Encode は末尾に改行を付けるため、NDJSON ではそのまま1行になる
+			err = encoder.Encode(item)
+		}
+		if err == nil {
+			count++
+			if count%streamFlushInterval == 0 {
+				res.Flush()
+			}
+			continue
+		}
+
+		if !started {
+			return err
+		}
+		// 書き出しを始めた後はステータスコードを変えられないため、途中で打ち切る
+		if ctx.Err() != nil {
+			log.Printf("ℹ️  Item stream canceled by client after %d item(s)", count)
+		} else {
+			log.Printf("⚠️  Item stream aborted after %d item(s): %v", count, err)
+		}
+		return nil
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	if format == streamJSONArray {
+		if _, err := res.Write([]byte("]\n")); err != nil {
+			return err
+		}
+	}
+	res.Flush()
+	return nil
+}
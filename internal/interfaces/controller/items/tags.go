@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setItemTagsRequest は PUT /items/{id}/tags のリクエストボディ
+// tags を省略した場合はタグをすべて外すのではなく、リクエスト形式のエラーにする
+type setItemTagsRequest struct {
+	Tags *[]string `json:"tags"`
+}
+
+// SetItemTags はアイテムのタグを丸ごと置き換える
+// PUT /items/{id}/tags に対応（If-Match 対応。空の配列ですべてのタグを外す）
+func (h *ItemHandler) SetItemTags(c echo.Context) error {
+	id, err := parseItemID(c)
+	if err != nil {
+		return err
+	}
+
+	var req setItemTagsRequest
+	if err := c.Bind(&req); err != nil || req.Tags == nil {
+		return errInvalidBodyFormat
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.itemUsecase.SetItemTags(c.Request().Context(), id, *req.Tags, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,115 @@
+package locations
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// LocationHandler は保管場所の管理のエンドポイント
+// 保管場所にあるアイテムの一覧（GET /locations/{id}/items）は items.ItemHandler が扱う
+type LocationHandler struct {
+	locationUsecase usecase.LocationUsecase
+}
+
+func NewLocationHandler(locationUsecase usecase.LocationUsecase) *LocationHandler {
+	return &LocationHandler{locationUsecase: locationUsecase}
+}
+
+var (
+	errInvalidLocationID = problem.InvalidRequest(i18n.MsgInvalidLocationID)
+	errInvalidBodyFormat = problem.InvalidRequest(i18n.MsgInvalidRequestFormat)
+)
+
+// parseLocationID はパスパラメータの id を取得する
+func parseLocationID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errInvalidLocationID
+	}
+	return id, nil
+}
+
+// GetLocations はすべての保管場所を名前順に返す
+// GET /locations に対応
+func (h *LocationHandler) GetLocations(c echo.Context) error {
+	locations, err := h.locationUsecase.GetLocations(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"locations": locations})
+}
+
+// GetLocation は保管場所を返す
+// GET /locations/{id} に対応
+func (h *LocationHandler) GetLocation(c echo.Context) error {
+	id, err := parseLocationID(c)
+	if err != nil {
+		return err
+	}
+
+	location, err := h.locationUsecase.GetLocation(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, location)
+}
+
+// CreateLocation は保管場所を登録する
+// POST /locations に対応（同じ親の中に同じ名前がある場合は409）
+func (h *LocationHandler) CreateLocation(c echo.Context) error {
+	var input usecase.CreateLocationInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	location, err := h.locationUsecase.CreateLocation(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, location)
+}
+
+// UpdateLocation は名前・種類・親を部分更新する
+// PATCH /locations/{id} に対応
+func (h *LocationHandler) UpdateLocation(c echo.Context) error {
+	id, err := parseLocationID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.UpdateLocationInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	location, err := h.locationUsecase.UpdateLocation(c.Request().Context(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, location)
+}
+
+// DeleteLocation は保管場所を削除する
+// DELETE /locations/{id} に対応（中に保管場所がある場合・アイテムが置かれている場合は409）
+func (h *LocationHandler) DeleteLocation(c echo.Context) error {
+	id, err := parseLocationID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.locationUsecase.DeleteLocation(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,151 @@
+package problem
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+)
+
+// エラーの種類を表すURIの接頭辞（GET /problems/{slug} で説明を取得できる）
+const typeBaseURI = "/problems/"
+
+// Type はエラーの種類（RFC 7807 の type / title / status に対応する）
+type Type struct {
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// URI は problem+json の type に設定するURIを返す
+func (t Type) URI() string {
+	return typeBaseURI + t.Slug
+}
+
+// エラーの種類の一覧
+// クライアントは type（または slug）でエラーを判別するため、一度公開した slug は変更しない
+var (
+	TypeInvalidRequest = Type{
+		Slug:        "invalid-request",
+		Title:       "Invalid request",
+		Status:      http.StatusBadRequest,
+		Description: "リクエストの形式が不正です（JSONの構文、パスパラメータ、クエリパラメータ、ヘッダーなど）。details に不正な項目が含まれます。",
+	}
+	TypeValidationFailed = Type{
+		Slug:        "validation-failed",
+		Title:       "Validation failed",
+		Status:      http.StatusBadRequest,
+		Description: "入力値がバリデーションを満たしていません。フィールド単位のエラーは errors に field / code / params / message の一覧で含まれます。",
+	}
+	TypeNotFound = Type{
+		Slug:        "not-found",
+		Title:       "Resource not found",
+		Status:      http.StatusNotFound,
+		Description: "指定されたアイテム・変更履歴・カテゴリー・タグ・保管場所、またはエンドポイントが存在しません。",
+	}
+	TypeMethodNotAllowed = Type{
+		Slug:        "method-not-allowed",
+		Title:       "Method not allowed",
+		Status:      http.StatusMethodNotAllowed,
+		Description: "エンドポイントは指定されたHTTPメソッドに対応していません。",
+	}
+	TypeDuplicateEntry = Type{
+		Slug:        "duplicate-entry",
+		Title:       "Duplicate entry",
+		Status:      http.StatusConflict,
+		Description: "同じ内容のリソースがすでに存在します。",
+	}
+	TypeCategoryInUse = Type{
+		Slug:        "category-in-use",
+		Title:       "Category in use",
+		Status:      http.StatusConflict,
+		Description: "アイテム（ゴミ箱のアイテムを含む）で使われているカテゴリーは無効化・削除できません。アイテムのカテゴリーを変更してから再度実行してください。",
+	}
+	TypeCategoryHasChildren = Type{
+		Slug:        "category-has-children",
+		Title:       "Category has children",
+		Status:      http.StatusConflict,
+		Description: "子カテゴリーがあるカテゴリーは削除できません。子カテゴリーを削除するか、別の親に移動してから再度実行してください。",
+	}
+	TypeLocationInUse = Type{
+		Slug:        "location-in-use",
+		Title:       "Location in use",
+		Status:      http.StatusConflict,
+		Description: "アイテム（ゴミ箱のアイテムを含む）が置かれている保管場所は削除できません。アイテムを別の保管場所に移動してから再度実行してください。",
+	}
+	TypeLocationHasChildren = Type{
+		Slug:        "location-has-children",
+		Title:       "Location has children",
+		Status:      http.StatusConflict,
+		Description: "中に別の保管場所（部屋・収納など）がある保管場所は削除できません。中の保管場所を削除するか、別の場所に移動してから再度実行してください。",
+	}
+	TypeItemSold = Type{
+		Slug:        "item-sold",
+		Title:       "Item sold",
+		Status:      http.StatusConflict,
+		Description: "売却の記録があるアイテムは、譲渡所得の計算に必要なため完全に削除できません。ゴミ箱に残すか、元に戻してください。",
+	}
+	TypeVersionConflict = Type{
+		Slug:        "version-conflict",
+		Title:       "Precondition failed",
+		Status:      http.StatusPreconditionFailed,
+		Description: "If-Match で指定したバージョンが現在のバージョンと一致しません。アイテムを取得し直してから再度実行してください。",
+	}
+	TypePayloadTooLarge = Type{
+		Slug:        "payload-too-large",
+		Title:       "Payload too large",
+		Status:      http.StatusRequestEntityTooLarge,
+		Description: "リクエストボディが上限を超えています。",
+	}
+	TypeInternalError = Type{
+		Slug:        "internal-error",
+		Title:       "Internal server error",
+		Status:      http.StatusInternalServerError,
+		Description: "サーバー内部でエラーが発生しました。問い合わせの際は request_id を伝えてください。",
+	}
+)
+
+// Catalog は公開しているエラーの種類の一覧
+var Catalog = []Type{
+	TypeInvalidRequest,
+	TypeValidationFailed,
+	TypeNotFound,
+	TypeMethodNotAllowed,
+	TypeDuplicateEntry,
+	TypeCategoryInUse,
+	TypeCategoryHasChildren,
+	TypeLocationInUse,
+	TypeLocationHasChildren,
+	TypeItemSold,
+	TypeVersionConflict,
+	TypePayloadTooLarge,
+	TypeInternalError,
+}
+
+// FindType は slug に対応するエラーの種類を返す
+func FindType(slug string) (Type, bool) {
+	for _, t := range Catalog {
+		if t.Slug == slug {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// GetCatalog はエラーの種類の一覧を返す
+// GET /problems に対応
+func GetCatalog(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string][]Type{"problems": Catalog})
+}
+
+// GetType は type のURIが指すエラーの種類の説明を返す
+// GET /problems/{slug} に対応
+func GetType(c echo.Context) error {
+	t, ok := FindType(c.Param("slug"))
+	if !ok {
+		return New(TypeNotFound, i18n.MsgProblemTypeNotFound)
+	}
+	return c.JSON(http.StatusOK, t)
+}
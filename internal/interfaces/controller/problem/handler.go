@@ -0,0 +1,51 @@
+package problem
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+)
+
+// HTTPErrorHandler はハンドラーが返したエラーを application/problem+json のレスポンスに変換する
+// Echo の HTTPErrorHandler に設定して使う
+// title / detail / errors のメッセージは lang クエリパラメータまたは Accept-Language の言語で返す
+func HTTPErrorHandler(err error, c echo.Context) {
+	requestID := RequestID(c)
+
+	// ストリーミングなどでレスポンスを書き始めた後はステータスコードを変更できない
+	if c.Response().Committed {
+		log.Printf("⚠️  [%s] error after response was committed: %v", requestID, err)
+		return
+	}
+
+	locale := i18n.Negotiate(c.Request())
+	p := Resolve(err, locale)
+	p.Instance = c.Request().URL.Path
+	p.RequestID = requestID
+	if p.Status >= 500 {
+		log.Printf("❌ [%s] %s %s: %v", requestID, c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	body, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		log.Printf("❌ [%s] failed to encode problem: %v", requestID, marshalErr)
+		c.NoContent(p.Status)
+		return
+	}
+
+	header := c.Response().Header()
+	header.Set("Content-Language", string(locale))
+	header.Add(echo.HeaderVary, "Accept-Language")
+
+	if c.Request().Method == http.MethodHead {
+		c.NoContent(p.Status)
+		return
+	}
+	if writeErr := c.Blob(p.Status, MIMEApplicationProblemJSON, body); writeErr != nil {
+		log.Printf("⚠️  [%s] failed to write problem: %v", requestID, writeErr)
+	}
+}
@@ -0,0 +1,187 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+)
+
+// MIMEApplicationProblemJSON は RFC 7807 のエラーレスポンスの Content-Type
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem は RFC 7807 形式のエラーレスポンス
+// request_id / details / errors はこのAPI独自の拡張メンバー
+type Problem struct {
+	Type      string                        `json:"type"`
+	Title     string                        `json:"title"`
+	Status    int                           `json:"status"`
+	Detail    string                        `json:"detail,omitempty"`
+	Instance  string                        `json:"instance,omitempty"`
+	RequestID string                        `json:"request_id,omitempty"`
+	Details   []string                      `json:"details,omitempty"`
+	Errors    domainErrors.ValidationErrors `json:"errors,omitempty"`
+}
+
+// Error はハンドラーがエラーの種類と詳細を明示して返すためのエラー
+// ドメインのエラーはそのまま返せば HTTPErrorHandler が種類を判別する
+// detail はレスポンスを返すときにリクエストの言語で表示するため、メッセージのキー（i18n.Msg...）で指定する
+// details も同じ理由で、文言ではなくコードと params を持つ InputError で指定する
+type Error struct {
+	Type    Type
+	Key     string
+	Details domainErrors.InputErrors
+}
+
+func (e *Error) Error() string {
+	return i18n.T(i18n.English, e.Key, nil)
+}
+
+// New は指定した種類のエラーを作成する
+func New(t Type, key string, details ...*domainErrors.InputError) *Error {
+	return &Error{Type: t, Key: key, Details: details}
+}
+
+// InvalidRequest はリクエストの形式が不正な場合のエラーを作成する
+func InvalidRequest(key string, details ...*domainErrors.InputError) *Error {
+	return New(TypeInvalidRequest, key, details...)
+}
+
+// Resolve はエラーを locale の言語のレスポンスの内容に変換する
+// instance と request_id は HTTPErrorHandler が設定する
+func Resolve(err error, locale i18n.Locale) Problem {
+	var pe *Error
+	if errors.As(err, &pe) {
+		return newProblem(locale, pe.Type, i18n.T(locale, pe.Key, nil), localizeInputErrors(pe.Details, locale))
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return fromHTTPError(he, locale)
+	}
+
+	switch {
+	case domainErrors.IsConflictError(err):
+		return newProblem(locale, TypeVersionConflict, i18n.T(locale, i18n.MsgVersionConflict, nil), nil)
+	case domainErrors.IsNotFoundError(err):
+		return newProblem(locale, TypeNotFound, i18n.T(locale, notFoundKey(err), nil), nil)
+	case errors.Is(err, domainErrors.ErrCategoryInUse):
+		return newProblem(locale, TypeCategoryInUse, i18n.T(locale, i18n.MsgCategoryInUse, nil), nil)
+	case errors.Is(err, domainErrors.ErrCategoryHasChildren):
+		return newProblem(locale, TypeCategoryHasChildren, i18n.T(locale, i18n.MsgCategoryHasChildren, nil), nil)
+	case errors.Is(err, domainErrors.ErrLocationInUse):
+		return newProblem(locale, TypeLocationInUse, i18n.T(locale, i18n.MsgLocationInUse, nil), nil)
+	case errors.Is(err, domainErrors.ErrLocationHasChildren):
+		return newProblem(locale, TypeLocationHasChildren, i18n.T(locale, i18n.MsgLocationHasChildren, nil), nil)
+	case errors.Is(err, domainErrors.ErrItemSold):
+		return newProblem(locale, TypeItemSold, i18n.T(locale, i18n.MsgItemSold, nil), nil)
+	case errors.Is(err, domainErrors.ErrDuplicateEntry):
+		return newProblem(locale, TypeDuplicateEntry, i18n.T(locale, i18n.MsgDuplicateEntry, nil), nil)
+	case domainErrors.IsValidationError(err):
+		if errs, ok := domainErrors.AsValidationErrors(err); ok {
+			p := newProblem(locale, TypeValidationFailed, i18n.T(locale, i18n.MsgFieldsInvalid, nil), nil)
+			p.Errors = localizeValidationErrors(errs, locale)
+			return p
+		}
+		if errs, ok := domainErrors.AsInputErrors(err); ok {
+			return newProblem(locale, TypeValidationFailed, i18n.T(locale, i18n.MsgInputInvalid, nil), localizeInputErrors(errs, locale))
+		}
+		return newProblem(locale, TypeValidationFailed, err.Error(), nil)
+	default:
+		// データベースのエラーなど内部の詳細はクライアントに返さない
+		return newProblem(locale, TypeInternalError, "", nil)
+	}
+}
+
+func newProblem(locale i18n.Locale, t Type, detail string, details []string) Problem {
+	return Problem{
+		Type:    t.URI(),
+		Title:   i18n.T(locale, "problem."+t.Slug+".title", nil),
+		Status:  t.Status,
+		Detail:  detail,
+		Details: details,
+	}
+}
+
+// notFoundKey はどのリソースが見つからなかったかのメッセージのキーを返す
+func notFoundKey(err error) string {
+	if errors.Is(err, domainErrors.ErrRevisionNotFound) {
+		return i18n.MsgRevisionNotFound
+	}
+	if errors.Is(err, domainErrors.ErrCategoryNotFound) {
+		return i18n.MsgCategoryNotFound
+	}
+	if errors.Is(err, domainErrors.ErrTagNotFound) {
+		return i18n.MsgTagNotFound
+	}
+	if errors.Is(err, domainErrors.ErrLocationNotFound) {
+		return i18n.MsgLocationNotFound
+	}
+	if errors.Is(err, domainErrors.ErrDisposalNotFound) {
+		return i18n.MsgDisposalNotFound
+	}
+	return i18n.MsgItemNotFound
+}
+
+// localizeValidationErrors はフィールド単位のエラーのメッセージを locale の言語に置き換える
+// エンティティが返すメッセージは言語に依存しない既定の文言のため、コードと params から作り直す
+func localizeValidationErrors(errs domainErrors.ValidationErrors, locale i18n.Locale) domainErrors.ValidationErrors {
+	localized := make(domainErrors.ValidationErrors, len(errs))
+	for i, e := range errs {
+		copied := *e
+		if message, ok := i18n.ValidationMessage(locale, e.Field, e.Code, e.Params); ok {
+			copied.Message = message
+		}
+		localized[i] = &copied
+	}
+	return localized
+}
+
+// localizeInputErrors はフィールド単位でないエラーを locale の言語のメッセージの一覧にする
+// カタログに文言がないコードは既定の文言（英語）のまま返す
+func localizeInputErrors(errs domainErrors.InputErrors, locale i18n.Locale) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+		if message, ok := i18n.InputMessage(locale, e.Code, e.Params); ok {
+			messages[i] = message
+		}
+	}
+	return messages
+}
+
+// fromHTTPError は Echo が返すエラー（ルーティング・ボディの読み取りなど）を変換する
+func fromHTTPError(he *echo.HTTPError, locale i18n.Locale) Problem {
+	detail := http.StatusText(he.Code)
+	if message, ok := he.Message.(string); ok {
+		detail = message
+	}
+
+	switch he.Code {
+	case http.StatusBadRequest:
+		return newProblem(locale, TypeInvalidRequest, detail, nil)
+	case http.StatusNotFound:
+		return newProblem(locale, TypeNotFound, i18n.T(locale, i18n.MsgRouteNotFound, nil), nil)
+	case http.StatusMethodNotAllowed:
+		return newProblem(locale, TypeMethodNotAllowed, i18n.T(locale, i18n.MsgMethodNotAllowed, nil), nil)
+	case http.StatusRequestEntityTooLarge:
+		return newProblem(locale, TypePayloadTooLarge, i18n.T(locale, i18n.MsgPayloadTooLarge, nil), nil)
+	case http.StatusInternalServerError:
+		return newProblem(locale, TypeInternalError, "", nil)
+	}
+
+	// カタログにないステータスは RFC 7807 の既定値 about:blank で返す
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(he.Code),
+		Status: he.Code,
+		Detail: detail,
+	}
+}
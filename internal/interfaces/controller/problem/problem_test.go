@@ -0,0 +1,351 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+)
+
+func TestResolve(t *testing.T) {
+	var fieldErrs domainErrors.ValidationErrors
+	fieldErrs.Add("name", "required", "name is required", nil)
+	limitErr := &domainErrors.InputError{
+		Code:    domainErrors.CodeNotInteger,
+		Params:  map[string]interface{}{"name": "limit"},
+		Message: "limit must be an integer",
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		locale      i18n.Locale
+		wantType    string
+		wantStatus  int
+		wantTitle   string
+		wantDetail  string
+		wantDetails []string
+		wantErrors  []string // field: message
+	}{
+		{
+			name:       "正常系: バージョンの競合",
+			err:        fmt.Errorf("failed to update item: %w", domainErrors.ErrVersionConflict),
+			locale:     i18n.English,
+			wantType:   TypeVersionConflict.URI(),
+			wantStatus: http.StatusPreconditionFailed,
+			wantTitle:  "Precondition failed",
+			wantDetail: "item has been modified",
+		},
+		{
+			name:       "正常系: アイテムが見つからない",
+			err:        domainErrors.ErrItemNotFound,
+			locale:     i18n.English,
+			wantType:   TypeNotFound.URI(),
+			wantStatus: http.StatusNotFound,
+			wantTitle:  "Resource not found",
+			wantDetail: "item not found",
+		},
+		{
+			name:       "正常系: 変更履歴が見つからない（日本語）",
+			err:        domainErrors.ErrRevisionNotFound,
+			locale:     i18n.Japanese,
+			wantType:   TypeNotFound.URI(),
+			wantStatus: http.StatusNotFound,
+			wantTitle:  "リソースが見つかりません",
+			wantDetail: "変更履歴が見つかりません",
+		},
+		{
+			name:       "正常系: 使用中のカテゴリー",
+			err:        domainErrors.ErrCategoryInUse,
+			locale:     i18n.English,
+			wantType:   TypeCategoryInUse.URI(),
+			wantStatus: http.StatusConflict,
+			wantTitle:  "Category in use",
+			wantDetail: "the category is used by items and cannot be deactivated or deleted",
+		},
+		{
+			name:       "正常系: 売却の記録があるアイテムの完全な削除",
+			err:        fmt.Errorf("failed to purge item: %w", domainErrors.ErrItemSold),
+			locale:     i18n.Japanese,
+			wantType:   TypeItemSold.URI(),
+			wantStatus: http.StatusConflict,
+			wantTitle:  "売却済みのアイテムです",
+			wantDetail: "売却の記録があるアイテムは完全に削除できません",
+		},
+		{
+			name:       "正常系: 重複",
+			err:        domainErrors.ErrDuplicateEntry,
+			locale:     i18n.English,
+			wantType:   TypeDuplicateEntry.URI(),
+			wantStatus: http.StatusConflict,
+			wantTitle:  "Duplicate entry",
+			wantDetail: "duplicate entry",
+		},
+		{
+			name:       "正常系: フィールド単位のバリデーションエラーは errors に言語に合わせたメッセージで入る",
+			err:        fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, fieldErrs),
+			locale:     i18n.Japanese,
+			wantType:   TypeValidationFailed.URI(),
+			wantStatus: http.StatusBadRequest,
+			wantTitle:  "入力内容に誤りがあります",
+			wantDetail: "一部の項目の入力内容に誤りがあります",
+			wantErrors: []string{"name: " + mustValidationMessage(t, i18n.Japanese, "name", "required")},
+		},
+		{
+			name:        "正常系: フィールド単位でないバリデーションエラーは details に入る",
+			err:         domainErrors.NewInputError(domainErrors.CodeNoFieldsToUpdate, "no fields to update", nil),
+			locale:      i18n.English,
+			wantType:    TypeValidationFailed.URI(),
+			wantStatus:  http.StatusBadRequest,
+			wantTitle:   "Validation failed",
+			wantDetail:  "the request contains invalid input",
+			wantDetails: []string{"no fields to update"},
+		},
+		{
+			name: "正常系: フィールド単位でないバリデーションエラーは details に言語に合わせたメッセージで入る",
+			err: fmt.Errorf("failed to list items: %w", domainErrors.NewInputError(
+				domainErrors.CodeOutOfRange,
+				"limit must be between 1 and 100",
+				map[string]interface{}{"name": "limit", "min": 1, "max": 100},
+			)),
+			locale:      i18n.Japanese,
+			wantType:    TypeValidationFailed.URI(),
+			wantStatus:  http.StatusBadRequest,
+			wantTitle:   "入力内容に誤りがあります",
+			wantDetail:  "リクエストの内容に誤りがあります",
+			wantDetails: []string{"limitは1以上100以下で指定してください"},
+		},
+		{
+			name:        "正常系: カタログにないコードは既定の文言のまま details に入る",
+			err:         domainErrors.NewInputError("unknown_code", "something is wrong", nil),
+			locale:      i18n.Japanese,
+			wantType:    TypeValidationFailed.URI(),
+			wantStatus:  http.StatusBadRequest,
+			wantDetail:  "リクエストの内容に誤りがあります",
+			wantDetails: []string{"something is wrong"},
+		},
+		{
+			name:       "正常系: 詳細のない ErrInvalidInput はエラーの文言を detail に入れる",
+			err:        domainErrors.ErrInvalidInput,
+			locale:     i18n.English,
+			wantType:   TypeValidationFailed.URI(),
+			wantStatus: http.StatusBadRequest,
+			wantTitle:  "Validation failed",
+			wantDetail: "invalid input",
+		},
+		{
+			name:        "正常系: ハンドラーが返したリクエスト形式のエラー",
+			err:         InvalidRequest(i18n.MsgInvalidQueryParams, limitErr),
+			locale:      i18n.English,
+			wantType:    TypeInvalidRequest.URI(),
+			wantStatus:  http.StatusBadRequest,
+			wantTitle:   "Invalid request",
+			wantDetail:  "invalid query parameters",
+			wantDetails: []string{"limit must be an integer"},
+		},
+		{
+			name:        "正常系: ハンドラーが返したリクエスト形式のエラー（日本語）",
+			err:         InvalidRequest(i18n.MsgInvalidQueryParams, limitErr),
+			locale:      i18n.Japanese,
+			wantType:    TypeInvalidRequest.URI(),
+			wantStatus:  http.StatusBadRequest,
+			wantTitle:   "リクエストが不正です",
+			wantDetail:  "クエリパラメータが不正です",
+			wantDetails: []string{"limitは整数で指定してください"},
+		},
+		{
+			name:       "正常系: Echo の 400 はメッセージを detail に入れる",
+			err:        echo.NewHTTPError(http.StatusBadRequest, "missing boundary"),
+			locale:     i18n.English,
+			wantType:   TypeInvalidRequest.URI(),
+			wantStatus: http.StatusBadRequest,
+			wantTitle:  "Invalid request",
+			wantDetail: "missing boundary",
+		},
+		{
+			name:       "正常系: Echo の 404 はエンドポイントがない",
+			err:        echo.ErrNotFound,
+			locale:     i18n.English,
+			wantType:   TypeNotFound.URI(),
+			wantStatus: http.StatusNotFound,
+			wantTitle:  "Resource not found",
+			wantDetail: "no endpoint matches the requested path",
+		},
+		{
+			name:       "正常系: Echo の 405",
+			err:        echo.ErrMethodNotAllowed,
+			locale:     i18n.English,
+			wantType:   TypeMethodNotAllowed.URI(),
+			wantStatus: http.StatusMethodNotAllowed,
+			wantTitle:  "Method not allowed",
+			wantDetail: "the endpoint does not support this method",
+		},
+		{
+			name:       "正常系: Echo の 413",
+			err:        echo.ErrStatusRequestEntityTooLarge,
+			locale:     i18n.English,
+			wantType:   TypePayloadTooLarge.URI(),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantDetail: "request body is too large",
+		},
+		{
+			name:       "正常系: カタログにない Echo のステータスは about:blank",
+			err:        echo.ErrUnsupportedMediaType,
+			locale:     i18n.English,
+			wantType:   "about:blank",
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantTitle:  "Unsupported Media Type",
+			wantDetail: "Unsupported Media Type",
+		},
+		{
+			name:       "異常系: データベースのエラーは内部の詳細を返さない",
+			err:        fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError),
+			locale:     i18n.English,
+			wantType:   TypeInternalError.URI(),
+			wantStatus: http.StatusInternalServerError,
+			wantTitle:  "Internal server error",
+		},
+		{
+			name:       "異常系: 不明なエラーは500",
+			err:        errors.New("boom"),
+			locale:     i18n.Japanese,
+			wantType:   TypeInternalError.URI(),
+			wantStatus: http.StatusInternalServerError,
+			wantTitle:  "サーバーエラー",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Resolve(tt.err, tt.locale)
+
+			assert.Equal(t, tt.wantType, p.Type)
+			assert.Equal(t, tt.wantStatus, p.Status)
+			if tt.wantTitle != "" {
+				assert.Equal(t, tt.wantTitle, p.Title)
+			}
+			assert.Equal(t, tt.wantDetail, p.Detail)
+			assert.Equal(t, tt.wantDetails, p.Details)
+			var errs []string
+			for _, e := range p.Errors {
+				errs = append(errs, e.Field+": "+e.Message)
+			}
+			assert.Equal(t, tt.wantErrors, errs)
+		})
+	}
+}
+
+func mustValidationMessage(t *testing.T, locale i18n.Locale, field, code string) string {
+	t.Helper()
+	message, ok := i18n.ValidationMessage(locale, field, code, nil)
+	require.True(t, ok)
+	return message
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		acceptLanguage  string
+		requestID       string
+		commit          bool
+		wantStatus      int
+		wantContentType string
+		wantLanguage    string
+		wantBody        bool
+		wantRequestID   string
+	}{
+		{
+			name:            "正常系: problem+json で返し、instance と request_id を設定する",
+			method:          http.MethodGet,
+			target:          "/items/999",
+			requestID:       "req-123",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: MIMEApplicationProblemJSON,
+			wantLanguage:    "en",
+			wantBody:        true,
+			wantRequestID:   "req-123",
+		},
+		{
+			name:            "正常系: Accept-Language の言語で返す",
+			method:          http.MethodGet,
+			target:          "/items/999",
+			acceptLanguage:  "ja,en;q=0.5",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: MIMEApplicationProblemJSON,
+			wantLanguage:    "ja",
+			wantBody:        true,
+		},
+		{
+			name:            "正常系: lang クエリパラメータを優先する",
+			method:          http.MethodGet,
+			target:          "/items/999?lang=en",
+			acceptLanguage:  "ja",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: MIMEApplicationProblemJSON,
+			wantLanguage:    "en",
+			wantBody:        true,
+		},
+		{
+			name:         "正常系: HEAD はボディを返さない",
+			method:       http.MethodHead,
+			target:       "/items/999",
+			wantStatus:   http.StatusNotFound,
+			wantLanguage: "en",
+		},
+		{
+			name:       "異常系: レスポンスを書き始めた後は何も書き込まない",
+			method:     http.MethodGet,
+			target:     "/items/export",
+			commit:     true,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.requestID != "" {
+				c.Set(requestIDContextKey, tt.requestID)
+			}
+			if tt.commit {
+				c.Response().WriteHeader(http.StatusOK)
+			}
+
+			HTTPErrorHandler(domainErrors.ErrItemNotFound, c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tt.wantLanguage, rec.Header().Get("Content-Language"))
+			if !tt.wantBody {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), "Accept-Language")
+			var body Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, TypeNotFound.URI(), body.Type)
+			assert.Equal(t, http.StatusNotFound, body.Status)
+			assert.Equal(t, req.URL.Path, body.Instance)
+			assert.Equal(t, tt.wantRequestID, body.RequestID)
+			assert.Equal(t, i18n.T(i18n.Locale(tt.wantLanguage), i18n.MsgItemNotFound, nil), body.Detail)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package problem
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+const requestIDContextKey = "request_id"
+
+// クライアントが指定したリクエストIDとして受け付ける形式（ログやヘッダーを壊さない文字のみ）
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware はリクエストごとのIDを X-Request-ID ヘッダーで返す
+// クライアントが X-Request-ID を指定した場合は、形式が正しければそのまま使う
+// エラーレスポンスの request_id にも同じIDが入るため、ログとの突き合わせに使える
+func RequestIDMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Request().Header.Get(echo.HeaderXRequestID)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDContextKey, id)
+		c.Response().Header().Set(echo.HeaderXRequestID, id)
+		return next(c)
+	}
+}
+
+// RequestID はリクエストIDを返す（ミドルウェアを通っていない場合は空文字）
+func RequestID(c echo.Context) string {
+	id, _ := c.Get(requestIDContextKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package problem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string // 空の場合は新しく発行したIDになる
+	}{
+		{
+			name:   "正常系: 形式が正しいIDはそのまま使う",
+			header: "abc-123_DEF.456",
+			want:   "abc-123_DEF.456",
+		},
+		{
+			name:   "正常系: 指定がない場合は発行する",
+			header: "",
+		},
+		{
+			name:   "異常系: 空白を含むIDは使わない",
+			header: "abc 123",
+		},
+		{
+			name:   "異常系: 改行を含むID（ログの偽装）は使わない",
+			header: "abc\r\nX-Injected: 1",
+		},
+		{
+			name:   "異常系: 記号を含むIDは使わない",
+			header: "<script>",
+		},
+		{
+			name:   "異常系: 64文字を超えるIDは使わない",
+			header: strings.Repeat("a", 65),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = HTTPErrorHandler
+			e.Use(RequestIDMiddleware)
+			var seen string
+			e.GET("/items/:id", func(c echo.Context) error {
+				seen = RequestID(c)
+				return domainErrors.ErrItemNotFound
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.header != "" {
+				req.Header[echo.HeaderXRequestID] = []string{tt.header}
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(echo.HeaderXRequestID)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, id)
+			} else {
+				assert.Regexp(t, `^[0-9a-f]{32}$`, id)
+			}
+			// ハンドラーとエラーレスポンスにも同じIDが入る
+			assert.Equal(t, id, seen)
+			require.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"request_id":"`+id+`"`)
+		})
+	}
+}
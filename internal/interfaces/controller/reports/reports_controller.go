@@ -0,0 +1,123 @@
+package reports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/domain/tax"
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// ReportHandler は申告用の帳票のエンドポイント
+type ReportHandler struct {
+	transferIncomeUsecase usecase.TransferIncomeUsecase
+}
+
+func NewReportHandler(transferIncomeUsecase usecase.TransferIncomeUsecase) *ReportHandler {
+	return &ReportHandler{transferIncomeUsecase: transferIncomeUsecase}
+}
+
+// GetTransferIncome は1年分の譲渡所得の計算書を返す
+// GET /reports/transfer-income?year=&format=json|csv に対応
+//   - year:   対象の年（必須）
+//   - format: json（デフォルト）/ csv
+//   - bom:    true の場合はCSVの先頭にBOMを付ける（Excel で文字化けしないようにする）
+func (h *ReportHandler) GetTransferIncome(c echo.Context) error {
+	var errs domainErrors.InputErrors
+
+	year, err := strconv.Atoi(strings.TrimSpace(c.QueryParam("year")))
+	if err != nil {
+		errs.Add(domainErrors.CodeNotInteger, "year is required and must be an integer", map[string]interface{}{"name": "year"})
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.QueryParam("format")))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		errs.Add(domainErrors.CodeInvalidOption, "format must be one of: json, csv", map[string]interface{}{"name": "format", "allowed": []string{"json", "csv"}})
+	}
+
+	bom := false
+	if raw := c.QueryParam("bom"); raw != "" {
+		if bom, err = strconv.ParseBool(raw); err != nil {
+			errs.Add(domainErrors.CodeNotBoolean, "bom must be true or false", map[string]interface{}{"name": "bom"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return problem.InvalidRequest(i18n.MsgInvalidQueryParams, errs...)
+	}
+
+	worksheet, err := h.transferIncomeUsecase.GetWorksheet(c.Request().Context(), year)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		return c.JSON(http.StatusOK, worksheet)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="transfer-income-%d.csv"`, year))
+	res.WriteHeader(http.StatusOK)
+	if bom {
+		if _, err := res.Write([]byte("\xEF\xBB\xBF")); err != nil {
+			return err
+		}
+	}
+	return writeWorksheetCSV(csv.NewWriter(res), worksheet)
+}
+
+// writeWorksheetCSV は売却ごとの明細の後に、空行を挟んで短期・長期の集計を書き出す
+func writeWorksheetCSV(w *csv.Writer, ws *tax.Worksheet) error {
+	rows := [][]string{
+		{"item_id", "name", "category", "purchase_date", "sold_on", "term", "taxable", "exempt_reason", "sale_price", "cost", "estimated_cost", "fees", "gain"},
+	}
+	for _, line := range ws.Lines {
+		rows = append(rows, []string{
+			strconv.FormatInt(line.ItemID, 10),
+			line.Name,
+			line.Category,
+			line.PurchaseDate,
+			line.SoldOn,
+			string(line.Term),
+			strconv.FormatBool(line.Taxable),
+			line.ExemptReason,
+			strconv.Itoa(line.SalePrice),
+			strconv.Itoa(line.Cost),
+			strconv.FormatBool(line.EstimatedCost),
+			strconv.Itoa(line.Fees),
+			strconv.Itoa(line.Gain),
+		})
+	}
+
+	rows = append(rows, []string{}, []string{"summary", "short_term", "long_term"})
+	for _, total := range []struct {
+		name        string
+		short, long int
+	}{
+		{"count", ws.ShortTerm.Count, ws.LongTerm.Count},
+		{"sale_price", ws.ShortTerm.SalePrice, ws.LongTerm.SalePrice},
+		{"cost", ws.ShortTerm.Cost, ws.LongTerm.Cost},
+		{"fees", ws.ShortTerm.Fees, ws.LongTerm.Fees},
+		{"gain", ws.ShortTerm.Gain, ws.LongTerm.Gain},
+		{"net_gain", ws.ShortTerm.NetGain, ws.LongTerm.NetGain},
+		{"deduction", ws.ShortTerm.Deduction, ws.LongTerm.Deduction},
+		{"income", ws.ShortTerm.Income, ws.LongTerm.Income},
+	} {
+		rows = append(rows, []string{total.name, strconv.Itoa(total.short), strconv.Itoa(total.long)})
+	}
+	rows = append(rows, []string{"taxable_income", strconv.Itoa(ws.TaxableIncome)})
+
+	return w.WriteAll(rows)
+}
@@ -0,0 +1,89 @@
+package tags
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"aicon-coding-test/internal/interfaces/controller/i18n"
+	"aicon-coding-test/internal/interfaces/controller/problem"
+	"aicon-coding-test/internal/usecase"
+)
+
+// TagHandler はタグクラウドと、タグの名前の変更・統合のエンドポイント
+// ハンドラーはエラーをそのまま返し、レスポンスへの変換は problem.HTTPErrorHandler が行う
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{tagUsecase: tagUsecase}
+}
+
+var (
+	errInvalidTagID      = problem.InvalidRequest(i18n.MsgInvalidTagID)
+	errInvalidBodyFormat = problem.InvalidRequest(i18n.MsgInvalidRequestFormat)
+)
+
+// parseTagID はパスパラメータの id を取得する
+func parseTagID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errInvalidTagID
+	}
+	return id, nil
+}
+
+// GetTags はタグと付いているアイテムの件数を、件数の多い順に返す（タグクラウド）
+// GET /tags に対応
+func (h *TagHandler) GetTags(c echo.Context) error {
+	tags, err := h.tagUsecase.GetTags(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"tags": tags})
+}
+
+// RenameTag はタグの名前を変更する
+// PATCH /tags/{id} に対応（同じ名前のタグがある場合は409。統合には POST /tags/{id}/merge を使う）
+func (h *TagHandler) RenameTag(c echo.Context) error {
+	id, err := parseTagID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.RenameTagInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	tag, err := h.tagUsecase.RenameTag(c.Request().Context(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// MergeTags はタグを into のタグに統合し、統合先のタグと件数を返す
+// POST /tags/{id}/merge に対応（統合元のタグは削除される）
+func (h *TagHandler) MergeTags(c echo.Context) error {
+	id, err := parseTagID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.MergeTagsInput
+	if err := c.Bind(&input); err != nil {
+		return errInvalidBodyFormat
+	}
+
+	tag, err := h.tagUsecase.MergeTags(c.Request().Context(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
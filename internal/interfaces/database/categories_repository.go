@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// CategoryRepository はカテゴリー（categories テーブル）を扱う
+type CategoryRepository struct {
+	SqlHandler
+}
+
+const categoryColumns = "id, code, parent_id, name_ja, name_en, sort_order, active, attribute_schema, created_at, updated_at"
+
+// MySQL のエラー番号（ドライバーに依存しないようにメッセージで判定する）
+const (
+	mysqlErrDuplicateEntry  = "Error 1062"
+	mysqlErrRowIsReferenced = "Error 1451"
+)
+
+// FindAll は無効なものも含むすべてのカテゴリーを並び順に返す
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM categories
+        ORDER BY sort_order ASC, id ASC
+    `, categoryColumns)
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var categories []*entity.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return categories, nil
+}
+
+// FindByID はカテゴリーを返す
+func (r *CategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	return r.findByID(ctx, id, "")
+}
+
+// LockByID はカテゴリーの行を FOR UPDATE でロックして返す
+// 外部キーの確認でアイテムの登録・更新は共有ロックを取るため、ロック中はこのカテゴリーのアイテムが増えない
+func (r *CategoryRepository) LockByID(ctx context.Context, id int64) (*entity.Category, error) {
+	return r.findByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *CategoryRepository) findByID(ctx context.Context, id int64, lock string) (*entity.Category, error) {
+	query := fmt.Sprintf(`SELECT %s FROM categories WHERE id = ? %s`, categoryColumns, lock)
+
+	category, err := scanCategory(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return category, nil
+}
+
+// Create はカテゴリーを登録する
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	query := `
+        INSERT INTO categories (code, parent_id, name_ja, name_en, sort_order, active, attribute_schema)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+	schema, err := encodeAttributeSchema(category.AttributeSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *entity.Category
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query,
+			category.Code,
+			category.ParentID,
+			category.NameJa,
+			category.NameEn,
+			category.SortOrder,
+			category.Active,
+			schema,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), mysqlErrDuplicateEntry) {
+				return fmt.Errorf("%w: category %s already exists", domainErrors.ErrDuplicateEntry, category.Code)
+			}
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		created, err = r.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update は親・表示名・並び順・有効フラグ・属性のスキーマを保存する（コードは更新しない）
+func (r *CategoryRepository) Update(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	query := `
+        UPDATE categories
+        SET parent_id = ?, name_ja = ?, name_en = ?, sort_order = ?, active = ?, attribute_schema = ?, updated_at = NOW()
+        WHERE id = ?
+    `
+	schema, err := encodeAttributeSchema(category.AttributeSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *entity.Category
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Execute(ctx, query,
+			category.ParentID,
+			category.NameJa,
+			category.NameEn,
+			category.SortOrder,
+			category.Active,
+			schema,
+			category.ID,
+		); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 値が変わらない場合は RowsAffected が0になるため、存在確認は再取得で行う
+		var err error
+		updated, err = r.FindByID(ctx, category.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Delete はカテゴリーを削除する
+// ゴミ箱のものも含めアイテムや子カテゴリーが参照している場合は、外部キー制約で失敗する
+func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM categories WHERE id = ?`, id)
+	if err != nil {
+		if strings.Contains(err.Error(), mysqlErrRowIsReferenced) {
+			return fmt.Errorf("%w: category %d is referenced by items or child categories", domainErrors.ErrCategoryInUse, id)
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// CountItems はカテゴリーを使っているアイテムの件数を返す（ゴミ箱のアイテムも含む）
+func (r *CategoryRepository) CountItems(ctx context.Context, code string) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items WHERE category = ?`, code).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
+
+func scanCategory(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Category, error) {
+	var category entity.Category
+	var parentID sql.NullInt64
+	var schema []byte
+	if err := scanner.Scan(
+		&category.ID,
+		&category.Code,
+		&parentID,
+		&category.NameJa,
+		&category.NameEn,
+		&category.SortOrder,
+		&category.Active,
+		&schema,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		category.ParentID = &parentID.Int64
+	}
+	category.AttributeSchema = entity.AttributeSchema{}
+	if len(schema) > 0 {
+		if err := json.Unmarshal(schema, &category.AttributeSchema); err != nil {
+			return nil, fmt.Errorf("failed to decode attribute_schema: %w", err)
+		}
+	}
+	return &category, nil
+}
+
+// encodeAttributeSchema は属性のスキーマを JSON カラムに保存する値にする（定義がない場合は NULL）
+func encodeAttributeSchema(schema entity.AttributeSchema) (interface{}, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attribute_schema: %w", err)
+	}
+	return string(b), nil
+}
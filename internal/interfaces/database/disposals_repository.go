@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
+)
+
+// DisposalRepository はアイテムの売却の記録（disposals テーブル）を扱う
+type DisposalRepository struct {
+	SqlHandler
+}
+
+// 取得費はアイテムの現在の購入価格を使う
+const disposalColumns = "d.id, d.item_id, d.sold_on, d.sale_price, d.fees, d.channel, d.note, d.actor, d.created_at, i.purchase_price"
+
+// Create は売却の記録を保存し、ID と記録日時を設定する
+func (r *DisposalRepository) Create(ctx context.Context, disposal *entity.Disposal) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, `
+            INSERT INTO disposals (item_id, sold_on, sale_price, fees, channel, note, actor)
+            VALUES (?, ?, ?, ?, ?, ?, ?)
+        `, disposal.ItemID, disposal.SoldOn, disposal.SalePrice, disposal.Fees, disposal.Channel, disposal.Note, disposal.Actor)
+		if err != nil {
+			if strings.Contains(err.Error(), mysqlErrDuplicateEntry) {
+				return fmt.Errorf("%w: item %d has already been sold", domainErrors.ErrDuplicateEntry, disposal.ItemID)
+			}
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		query := fmt.Sprintf(`SELECT %s FROM disposals d JOIN items i ON i.id = d.item_id WHERE d.id = ?`, disposalColumns)
+		saved, err := scanDisposal(r.QueryRow(ctx, query, id))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		*disposal = *saved
+		return nil
+	})
+}
+
+// FindByItemID はアイテムの売却の記録を返す（記録がない場合は ErrDisposalNotFound）
+func (r *DisposalRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.Disposal, error) {
+	query := fmt.Sprintf(`SELECT %s FROM disposals d JOIN items i ON i.id = d.item_id WHERE d.item_id = ?`, disposalColumns)
+
+	disposal, err := scanDisposal(r.QueryRow(ctx, query, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrDisposalNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return disposal, nil
+}
+
+// soldOnRange は year の1月1日と12月31日を返す
+// 翌年の1月1日を上限にすると、9999年の場合に MySQL の DATE の範囲を超えるため、その年の末日までを範囲にする
+func soldOnRange(year int) (string, string) {
+	return fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year)
+}
+
+// SumByYearAndCategory は売却した年・アイテムのカテゴリーごとの件数と金額の合計を返す
+// year が nil の場合はすべての年を対象にする
+// 売却した事実はアイテムをゴミ箱に移動しても変わらないため、ゴミ箱のアイテムも含める
+func (r *DisposalRepository) SumByYearAndCategory(ctx context.Context, year *int) ([]usecase.RealizedGainTotal, error) {
+	var where string
+	var args []interface{}
+	if year != nil {
+		// sold_on のインデックスを使えるよう、年の範囲で絞り込む
+		from, to := soldOnRange(*year)
+		where = "WHERE d.sold_on BETWEEN ? AND ?"
+		args = append(args, from, to)
+	}
+
+	rows, err := r.Query(ctx, `
+        SELECT YEAR(d.sold_on), i.category, COUNT(*),
+               COALESCE(SUM(d.sale_price), 0), COALESCE(SUM(d.fees), 0), COALESCE(SUM(i.purchase_price), 0)
+        FROM disposals d
+        JOIN items i ON i.id = d.item_id
+        `+where+`
+        GROUP BY YEAR(d.sold_on), i.category
+        ORDER BY YEAR(d.sold_on), i.category
+    `, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var totals []usecase.RealizedGainTotal
+	for rows.Next() {
+		var total usecase.RealizedGainTotal
+		if err := rows.Scan(&total.Year, &total.Category, &total.Count, &total.SalePrice, &total.Fees, &total.Cost); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return totals, nil
+}
+
+// FindByYear は year に売却した記録をアイテムの名前・カテゴリー・購入日とともに売却日・ID の順に返す
+// SumByYearAndCategory と同じく、ゴミ箱のアイテムも含める
+func (r *DisposalRepository) FindByYear(ctx context.Context, year int) ([]*usecase.DisposedItem, error) {
+	query := fmt.Sprintf(`
+        SELECT %s, i.name, i.category, i.purchase_date
+        FROM disposals d
+        JOIN items i ON i.id = d.item_id
+        WHERE d.sold_on BETWEEN ? AND ?
+        ORDER BY d.sold_on, d.id
+    `, disposalColumns)
+
+	from, to := soldOnRange(year)
+	rows, err := r.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var items []*usecase.DisposedItem
+	for rows.Next() {
+		var item usecase.DisposedItem
+		var purchaseDate time.Time
+		disposal, err := scanDisposal(rows, &item.Name, &item.Category, &purchaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		item.Disposal = disposal
+		item.PurchaseDate = purchaseDate.Format("2006-01-02")
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return items, nil
+}
+
+// scanDisposal は disposalColumns の順に読み取る。extra には続けて選択した列の読み取り先を渡す
+func scanDisposal(scanner interface {
+	Scan(dest ...interface{}) error
+}, extra ...interface{}) (*entity.Disposal, error) {
+	var disposal entity.Disposal
+	var soldOn time.Time
+	var cost int
+	dest := []interface{}{
+		&disposal.ID,
+		&disposal.ItemID,
+		&soldOn,
+		&disposal.SalePrice,
+		&disposal.Fees,
+		&disposal.Channel,
+		&disposal.Note,
+		&disposal.Actor,
+		&disposal.CreatedAt,
+		&cost,
+	}
+	if err := scanner.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	disposal.SoldOn = soldOn.Format("2006-01-02")
+	disposal.SetCost(cost)
+	return &disposal, nil
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisposalRepository_YearRange(t *testing.T) {
+	tests := []struct {
+		name string
+		year int
+		want []interface{}
+	}{
+		{
+			name: "正常系: 年の1月1日から12月31日まで",
+			year: 2024,
+			want: []interface{}{"2024-01-01", "2024-12-31"},
+		},
+		{
+			name: "正常系: 最初の年",
+			year: 1,
+			want: []interface{}{"0001-01-01", "0001-12-31"},
+		},
+		{
+			name: "正常系: 最後の年でも DATE の範囲を超えない",
+			year: 9999,
+			want: []interface{}{"9999-01-01", "9999-12-31"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("FindByYear", func(t *testing.T) {
+				handler := &fakeSqlHandler{rows: &fakeRows{}}
+				repo := &DisposalRepository{SqlHandler: handler}
+
+				_, err := repo.FindByYear(context.Background(), tt.year)
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, handler.args)
+			})
+			t.Run("SumByYearAndCategory", func(t *testing.T) {
+				handler := &fakeSqlHandler{rows: &fakeRows{}}
+				repo := &DisposalRepository{SqlHandler: handler}
+				year := tt.year
+
+				_, err := repo.SumByYearAndCategory(context.Background(), &year)
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, handler.args)
+			})
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"aicon-coding-test/internal/domain/search"
+	"aicon-coding-test/internal/usecase"
+)
+
+// ngramパーサーのトークン長（MySQLの ngram_token_size のデフォルト値）
+// これより短い検索語は FULLTEXT インデックスでは検索できないため LIKE で検索する
+const ngramTokenSize = 2
+
+// itemSortColumn はAPIのソートフィールド名に対応するカラム名を返す
+// usecase でソート可能としていないフィールドはSQLに埋め込まない（SQLインジェクション対策）
+func itemSortColumn(field string) (string, bool) {
+	if !usecase.IsSortableItemField(field) {
+		return "", false
+	}
+	if field == "relevance" {
+		return "score", true // Search のSELECT句で計算する関連度スコアの別名
+	}
+	// relevance 以外はフィールド名とカラム名が同じ
+	return field, true
+}
+
+// ファセット集計に使えるフィールドとカラム名の対応表
+var itemFacetColumns = map[usecase.FacetField]string{
+	usecase.FacetCategory: "category",
+	usecase.FacetBrand:    "brand",
+}
+
+// buildItemWhere は検索条件からWHERE句とプレースホルダーの値を組み立てる
+// 値は必ずプレースホルダー経由で渡す
+// ゴミ箱に移動したアイテムは常に除外する
+func buildItemWhere(criteria usecase.ItemCriteria) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if criteria.Keyword != "" {
+		match, likes := buildSearchTerms(criteria.Keyword)
+		if match != "" {
+			conditions = append(conditions, "MATCH(search_text) AGAINST(? IN BOOLEAN MODE)")
+			args = append(args, match)
+		}
+		for _, like := range likes {
+			conditions = append(conditions, "search_text LIKE ?")
+			args = append(args, like)
+		}
+	}
+
+	if len(criteria.Categories) > 0 {
+		conditions = append(conditions, "category IN ("+placeholders(len(criteria.Categories))+")")
+		for _, category := range criteria.Categories {
+			args = append(args, category)
+		}
+	} else if criteria.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, criteria.Category)
+	}
+	if len(criteria.LocationIDs) > 0 {
+		conditions = append(conditions, "location_id IN ("+placeholders(len(criteria.LocationIDs))+")")
+		for _, id := range criteria.LocationIDs {
+			args = append(args, id)
+		}
+	} else if criteria.LocationID != nil {
+		conditions = append(conditions, "location_id = ?")
+		args = append(args, *criteria.LocationID)
+	}
+	if len(criteria.Statuses) > 0 {
+		conditions = append(conditions, "status IN ("+placeholders(len(criteria.Statuses))+")")
+		for _, status := range criteria.Statuses {
+			args = append(args, status)
+		}
+	}
+	if criteria.Brand != "" {
+		conditions = append(conditions, "brand = ?")
+		args = append(args, criteria.Brand)
+	}
+	if criteria.MinPrice != nil {
+		conditions = append(conditions, "purchase_price >= ?")
+		args = append(args, *criteria.MinPrice)
+	}
+	if criteria.MaxPrice != nil {
+		conditions = append(conditions, "purchase_price <= ?")
+		args = append(args, *criteria.MaxPrice)
+	}
+	if criteria.PurchasedFrom != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, criteria.PurchasedFrom)
+	}
+	if criteria.PurchasedTo != "" {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, criteria.PurchasedTo)
+	}
+	// タグ名の比較は照合順序に従う（大文字・小文字を区別しない）
+	if len(criteria.TagsAny) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id = items.id AND t.name IN ("+placeholders(len(criteria.TagsAny))+"))")
+		for _, tag := range criteria.TagsAny {
+			args = append(args, tag)
+		}
+	}
+	if len(criteria.TagsAll) > 0 {
+		conditions = append(conditions, "(SELECT COUNT(*) FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id = items.id AND t.name IN ("+placeholders(len(criteria.TagsAll))+")) = ?")
+		for _, tag := range criteria.TagsAll {
+			args = append(args, tag)
+		}
+		args = append(args, len(criteria.TagsAll))
+	}
+	// カスタム属性は値を文字列にして比較する（キーは usecase で検証済みだが、パスもプレースホルダーで渡す）
+	// 条件の順序でプレースホルダーの値がずれないよう、キーの昇順に組み立てる
+	for _, key := range sortedKeys(criteria.Attributes) {
+		conditions = append(conditions, "JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ?")
+		args = append(args, `$."`+key+`"`, criteria.Attributes[key])
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// placeholders は IN 句に使う n 個のプレースホルダーを返す
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildItemOrderBy はソート条件からORDER BY句を組み立てる
+// 同じ値の行の順序を安定させるため、最後に必ず id を追加する
+func buildItemOrderBy(sort []usecase.SortField) string {
+	parts := []string{}
+	hasID := false
+
+	for _, field := range sort {
+		column, ok := itemSortColumn(field.Field)
+		if !ok {
+			continue
+		}
+		if column == "id" {
+			hasID = true
+		}
+
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		parts = append(parts, column+" "+direction)
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, "created_at DESC")
+	}
+	if !hasID {
+		direction := "DESC"
+		if len(sort) > 0 && !sort[len(sort)-1].Desc {
+			direction = "ASC"
+		}
+		parts = append(parts, "id "+direction)
+	}
+
+	return "ORDER BY " + strings.Join(parts, ", ")
+}
+
+// buildItemSeek はキーセットページング用の条件を組み立てる
+// (sort_col, id) が after より後ろにある行だけを対象にする。
+// 行値コンストラクタではなく展開した形で書くことで idx_created_at / idx_purchase_date のレンジスキャンを効かせる
+func buildItemSeek(after *usecase.ItemCursor) (string, []interface{}, error) {
+	column, ok := itemSortColumn(after.Sort.Field)
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported cursor field: %s", after.Sort.Field)
+	}
+
+	var value interface{}
+	switch after.Sort.Field {
+	case "created_at":
+		t, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		value = t
+	case "id":
+		return "id " + seekOperator(after.Sort.Desc) + " ?", []interface{}{after.ID}, nil
+	default:
+		value = after.Value
+	}
+
+	op := seekOperator(after.Sort.Desc)
+	condition := fmt.Sprintf("%s %s= ? AND (%s %s ? OR id %s ?)", column, op, column, op, op)
+	return condition, []interface{}{value, value, after.ID}, nil
+}
+
+func seekOperator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+// buildSearchTerms は検索キーワードを FULLTEXT の BOOLEAN MODE 用の式と LIKE 用のパターンに変換する
+// 各検索語は必須（+）のフレーズとして扱い、ngramのトークン長より短い語は LIKE に回す
+func buildSearchTerms(keyword string) (string, []string) {
+	var phrases []string
+	var likes []string
+
+	for _, term := range search.Terms(keyword) {
+		if utf8.RuneCountInString(term) < ngramTokenSize {
+			likes = append(likes, "%"+escapeLike(term)+"%")
+			continue
+		}
+		phrases = append(phrases, `+"`+term+`"`)
+	}
+
+	return strings.Join(phrases, " "), likes
+}
+
+// escapeLike は LIKE のワイルドカード文字をエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// searchText は全文検索インデックスに格納する正規化済みテキストを返す
+func searchText(name, brand string) string {
+	return search.Fold(name + " " + brand)
+}
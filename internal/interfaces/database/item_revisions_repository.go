@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// ItemRevisionRepository はアイテムの変更履歴（item_revisions テーブル）を扱う
+type ItemRevisionRepository struct {
+	SqlHandler
+}
+
+const itemRevisionColumns = "id, item_id, revision, action, actor, snapshot, changes, created_at"
+
+// Create は変更履歴を保存する
+// 連番は同じアイテムの最大値 + 1 とし、採番と登録は同じトランザクションで行う
+func (r *ItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) error {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes: %w", err)
+	}
+
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		var next int
+		if err := r.QueryRow(ctx,
+			`SELECT COALESCE(MAX(revision), 0) + 1 FROM item_revisions WHERE item_id = ? FOR UPDATE`,
+			revision.ItemID,
+		).Scan(&next); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		result, err := r.Execute(ctx, `
+            INSERT INTO item_revisions (item_id, revision, action, actor, snapshot, changes)
+            VALUES (?, ?, ?, ?, ?, ?)
+        `, revision.ItemID, next, string(revision.Action), revision.Actor, string(snapshot), string(changes))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		revision.ID = id
+		revision.Revision = next
+		return nil
+	})
+}
+
+// FindByItemID はアイテムの変更履歴を新しい順にすべて返す
+func (r *ItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM item_revisions
+        WHERE item_id = ?
+        ORDER BY revision DESC
+    `, itemRevisionColumns)
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var revisions []*entity.ItemRevision
+	for rows.Next() {
+		revision, err := scanItemRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return revisions, nil
+}
+
+// FindByRevision は指定した連番の変更履歴を返す
+func (r *ItemRevisionRepository) FindByRevision(ctx context.Context, itemID int64, revision int) (*entity.ItemRevision, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM item_revisions
+        WHERE item_id = ? AND revision = ?
+    `, itemRevisionColumns)
+
+	rev, err := scanItemRevision(r.QueryRow(ctx, query, itemID, revision))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return rev, nil
+}
+
+// scanItemRevision は1行分の変更履歴を読み取り、JSONのカラムを復元する
+func scanItemRevision(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemRevision, error) {
+	var revision entity.ItemRevision
+	var action string
+	var snapshot, changes []byte
+
+	if err := scanner.Scan(
+		&revision.ID,
+		&revision.ItemID,
+		&revision.Revision,
+		&action,
+		&revision.Actor,
+		&snapshot,
+		&changes,
+		&revision.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	revision.Action = entity.RevisionAction(action)
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if err := json.Unmarshal(changes, &revision.Changes); err != nil {
+		return nil, fmt.Errorf("failed to decode changes: %w", err)
+	}
+
+	return &revision, nil
+}
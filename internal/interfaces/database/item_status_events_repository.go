@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// ItemStatusEventRepository はアイテムの所有状況の変更の記録（item_status_events テーブル）を扱う
+type ItemStatusEventRepository struct {
+	SqlHandler
+}
+
+const itemStatusEventColumns = "id, item_id, from_status, to_status, reason, actor, changed_at, created_at"
+
+// Create は変更の記録を保存する
+func (r *ItemStatusEventRepository) Create(ctx context.Context, event *entity.ItemStatusEvent) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, `
+            INSERT INTO item_status_events (item_id, from_status, to_status, reason, actor, changed_at)
+            VALUES (?, ?, ?, ?, ?, ?)
+        `, event.ItemID, string(event.FromStatus), string(event.ToStatus), event.Reason, event.Actor, event.ChangedAt)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		query := fmt.Sprintf(`SELECT %s FROM item_status_events WHERE id = ?`, itemStatusEventColumns)
+		saved, err := scanItemStatusEvent(r.QueryRow(ctx, query, id))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		event.ID = saved.ID
+		event.ChangedAt = saved.ChangedAt
+		event.CreatedAt = saved.CreatedAt
+		return nil
+	})
+}
+
+// FindByItemID はアイテムの変更の記録を変更日時の新しい順にすべて返す
+func (r *ItemStatusEventRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemStatusEvent, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM item_status_events
+        WHERE item_id = ?
+        ORDER BY changed_at DESC, id DESC
+    `, itemStatusEventColumns)
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var events []*entity.ItemStatusEvent
+	for rows.Next() {
+		event, err := scanItemStatusEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return events, nil
+}
+
+func scanItemStatusEvent(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemStatusEvent, error) {
+	var event entity.ItemStatusEvent
+	var from, to string
+	if err := scanner.Scan(
+		&event.ID,
+		&event.ItemID,
+		&from,
+		&to,
+		&event.Reason,
+		&event.Actor,
+		&event.ChangedAt,
+		&event.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	event.FromStatus = entity.ItemStatus(from)
+	event.ToStatus = entity.ItemStatus(to)
+	return &event, nil
+}
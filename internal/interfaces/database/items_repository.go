@@ -3,53 +3,180 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"iter"
+	"sort"
 	"strings"
 	"time"
 
 	"aicon-coding-test/internal/domain/entity"
 	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
 )
 
 type ItemRepository struct {
 	SqlHandler
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+// scanItem が読み取るカラム（順番を scanItem と合わせること）
+// タグは item_tags から JSON の配列で取得する（タグがない場合は NULL）
+const itemColumns = "id, name, category, brand, purchase_price, purchase_date, attributes, created_at, updated_at, version, deleted_at, location_id, status, " +
+	"(SELECT JSON_ARRAYAGG(t.name) FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id = items.id) AS tags"
+
+func (r *ItemRepository) FindAll(ctx context.Context, criteria usecase.ItemCriteria) ([]*entity.Item, error) {
+	where, args := buildItemWhere(criteria)
+	query := fmt.Sprintf(`
+        SELECT %s
         FROM items
-        ORDER BY created_at DESC
-    `
+        %s
+        %s
+        LIMIT ? OFFSET ?
+    `, itemColumns, where, buildItemOrderBy(criteria.Sort))
+	args = append(args, criteria.Limit, criteria.Offset)
+
+	return r.queryItems(ctx, query, args...)
+}
+
+// FindPage はキーセット（シーク）方式でページを取得する
+// OFFSET を使わないため、ページが深くなっても速度が落ちず、途中で行が追加されても重複・欠落が起きない
+func (r *ItemRepository) FindPage(ctx context.Context, criteria usecase.ItemCriteria, after *usecase.ItemCursor) ([]*entity.Item, error) {
+	where, args := buildItemWhere(criteria)
+	if after != nil {
+		seek, seekArgs, err := buildItemSeek(after)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		where += " AND " + seek
+		args = append(args, seekArgs...)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s
+        %s
+        LIMIT ?
+    `, itemColumns, where, buildItemOrderBy(criteria.Sort))
+	args = append(args, criteria.Limit)
 
-	rows, err := r.Query(ctx, query)
+	return r.queryItems(ctx, query, args...)
+}
+
+// Search は正規化済みテキスト（search_text）に対する ngram FULLTEXT インデックスで検索する
+// 関連度スコアは MATCH ... AGAINST の値で、短すぎて FULLTEXT で検索できない語のみの場合は 0 になる
+func (r *ItemRepository) Search(ctx context.Context, criteria usecase.ItemCriteria) ([]*usecase.ItemSearchHit, error) {
+	score := "0"
+	args := []interface{}{}
+	if match, _ := buildSearchTerms(criteria.Keyword); match != "" {
+		score = "MATCH(search_text) AGAINST(? IN BOOLEAN MODE)"
+		args = append(args, match)
+	}
+
+	where, whereArgs := buildItemWhere(criteria)
+	args = append(args, whereArgs...)
+
+	query := fmt.Sprintf(`
+        SELECT %s, %s AS score
+        FROM items
+        %s
+        %s
+        LIMIT ? OFFSET ?
+    `, itemColumns, score, where, buildItemOrderBy(criteria.Sort))
+	args = append(args, criteria.Limit, criteria.Offset)
+
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 	defer rows.Close()
 
-	var items []*entity.Item
+	var hits []*usecase.ItemSearchHit
 	for rows.Next() {
-		item, err := scanItem(rows)
+		var hit usecase.ItemSearchHit
+		item, err := scanItem(rows, &hit.Score)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 		}
-		items = append(items, item)
+		hit.Item = item
+		hits = append(hits, &hit)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return items, nil
+	return hits, nil
+}
+
+// Iterate は条件に一致するアイテムを1行ずつ読み取って返す
+// 結果をスライスに溜めないため、件数が多くてもメモリ使用量は一定
+// 呼び出し側がループを抜けるか ctx がキャンセルされると、その時点で読み取りをやめて接続を解放する
+func (r *ItemRepository) Iterate(ctx context.Context, criteria usecase.ItemCriteria) iter.Seq2[*entity.Item, error] {
+	where, args := buildItemWhere(criteria)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s
+        %s
+    `, itemColumns, where, buildItemOrderBy(criteria.Sort))
+
+	return func(yield func(*entity.Item, error) bool) {
+		rows, err := r.Query(ctx, query, args...)
+		if err != nil {
+			yield(nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error()))
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			item, err := scanItem(rows)
+			if err != nil {
+				yield(nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error()))
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			// クライアントの切断などで ctx がキャンセルされた場合はデータベースのエラーとして扱わない
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+			yield(nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error()))
+		}
+	}
+}
+
+func (r *ItemRepository) Count(ctx context.Context, criteria usecase.ItemCriteria) (int, error) {
+	where, args := buildItemWhere(criteria)
+	query := fmt.Sprintf(`
+        SELECT COUNT(*)
+        FROM items
+        %s
+    `, where)
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return count, nil
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
-	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+	query := fmt.Sprintf(`
+        SELECT %s
         FROM items
-        WHERE id = ?
-    `
+        WHERE id = ? AND deleted_at IS NULL
+    `, itemColumns)
 
 	row := r.QueryRow(ctx, query, id)
 
@@ -64,36 +191,156 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item,
 	return item, nil
 }
 
+// Create はアイテムを登録し、登録後の値（採番されたIDや作成日時）を再取得して返す
+// 登録と再取得は同じトランザクションで行う
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (name, category, brand, purchase_price, purchase_date, attributes, location_id, status, search_text)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
+	attributes, err := encodeAttributes(item.Attributes)
+	if err != nil {
+		return nil, err
+	}
 
-	result, err := r.Execute(ctx, query,
-		item.Name,
-		item.Category,
-		item.Brand,
-		item.PurchasePrice,
-		item.PurchaseDate,
-	)
+	var created *entity.Item
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.PurchaseDate,
+			attributes,
+			item.LocationID,
+			string(item.Status),
+			searchText(item.Name, item.Brand),
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		created, err = r.FindByID(ctx, id)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
-	id, err := result.LastInsertId()
+	return created, nil
+}
+
+// Delete はアイテムをゴミ箱に移動する（deleted_at を設定する論理削除）
+// expectedVersion が nil でない場合は、現在のバージョンと一致するときのみ削除する
+func (r *ItemRepository) Delete(ctx context.Context, id int64, expectedVersion *int) error {
+	query := `UPDATE items SET deleted_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL`
+	args := []interface{}{id}
+	if expectedVersion != nil {
+		query += ` AND version = ?`
+		args = append(args, *expectedVersion)
+	}
+
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		if rowsAffected == 0 {
+			return r.missingOrConflict(ctx, id)
+		}
+
+		return nil
+	})
+}
+
+// FindTrashed はゴミ箱にあるアイテムを削除日時の新しい順に取得する
+func (r *ItemRepository) FindTrashed(ctx context.Context, limit, offset int) ([]*entity.Item, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC, id DESC
+        LIMIT ? OFFSET ?
+    `, itemColumns)
+
+	return r.queryItems(ctx, query, limit, offset)
+}
+
+// CountTrashed はゴミ箱にあるアイテムの件数を返す
+func (r *ItemRepository) CountTrashed(ctx context.Context) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items WHERE deleted_at IS NOT NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return count, nil
+}
+
+// FindTrashedByID はゴミ箱にあるアイテムを取得する
+// ゴミ箱に該当するアイテムがない場合は ErrItemNotFound を返す
+func (r *ItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.Item, error) {
+	query := fmt.Sprintf(`SELECT %s FROM items WHERE id = ? AND deleted_at IS NOT NULL`, itemColumns)
+
+	item, err := scanItem(r.QueryRow(ctx, query, id))
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return r.FindByID(ctx, id)
+	return item, nil
 }
 
-func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM items WHERE id = ?`
+// Restore はゴミ箱にあるアイテムを元に戻し、復元後のアイテムを返す
+// ゴミ箱に該当するアイテムがない場合は ErrItemNotFound を返す
+func (r *ItemRepository) Restore(ctx context.Context, id int64) (*entity.Item, error) {
+	var restored *entity.Item
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx,
+			`UPDATE items SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`, id)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
 
-	result, err := r.Execute(ctx, query, id)
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if rowsAffected == 0 {
+			return domainErrors.ErrItemNotFound
+		}
+
+		restored, err = r.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// Purge はゴミ箱にあるアイテムを完全に削除する
+// 誤って削除しないよう、ゴミ箱に移動していないアイテムは対象にしない（ErrItemNotFound を返す）
+// 売却の記録があるアイテムは外部キーで削除できない（ErrItemSold を返す）
+func (r *ItemRepository) Purge(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM items WHERE id = ? AND deleted_at IS NOT NULL`, id)
 	if err != nil {
+		if strings.Contains(err.Error(), mysqlErrRowIsReferenced) {
+			return fmt.Errorf("%w: item %d has a sale record", domainErrors.ErrItemSold, id)
+		}
 		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
@@ -101,7 +348,6 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
-
 	if rowsAffected == 0 {
 		return domainErrors.ErrItemNotFound
 	}
@@ -109,117 +355,310 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-// Update はアイテムの特定フィールドを部分更新する関数
-// name, brand, purchasePrice のうち、nilでない（送信された）フィールドのみを更新する
-// *string, *int はポインタ型（nilにできる型）で、部分更新
-func (r *ItemRepository) Update(ctx context.Context, id int64, name, brand *string, purchasePrice *int) (*entity.Item, error) {
-	// SQLのUPDATE文のSET部分を動的に構築するためのスライス
-	setParts := []string{}
-	// SQLのプレースホルダー(?)に入る値を格納するスライス
-	args := []interface{}{}
+// FindTrashedBefore は before より前にゴミ箱に移動したアイテムを削除日時の古い順に取得する
+// 完全に削除できない売却の記録があるアイテムは含めない
+func (r *ItemRepository) FindTrashedBefore(ctx context.Context, before time.Time) ([]*entity.Item, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE deleted_at IS NOT NULL AND deleted_at < ?
+          AND NOT EXISTS (SELECT 1 FROM disposals d WHERE d.item_id = items.id)
+        ORDER BY deleted_at, id
+    `, itemColumns)
+
+	return r.queryItems(ctx, query, before)
+}
 
-	// nameがnilでない（更新対象）の場合、SET文と値を追加
-	if name != nil {
-		setParts = append(setParts, "name = ?")
-		args = append(args, *name) // *nameでポインタの中身を取得
+// BackfillSearchText は search_text が空の行（search_text を追加する前に登録したアイテム）に検索用テキストを設定し、設定した件数を返す
+// 名前は必須のため、登録済みのアイテムの search_text が空になることはない。updated_at と version は変更しない
+func (r *ItemRepository) BackfillSearchText(ctx context.Context) (int, error) {
+	rows, err := r.Query(ctx, `SELECT id, name, brand FROM items WHERE search_text = ''`)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	type target struct {
+		id          int64
+		name, brand string
+	}
+	var targets []target
+	for rows.Next() {
+		var t target
+		if err := rows.Scan(&t.id, &t.name, &t.brand); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		targets = append(targets, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	for _, t := range targets {
+		if _, err := r.Execute(ctx,
+			`UPDATE items SET search_text = ?, updated_at = updated_at WHERE id = ?`,
+			searchText(t.name, t.brand), t.id,
+		); err != nil {
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
 	}
-	// brandがnilでない場合、SET文と値を追加
-	if brand != nil {
-		setParts = append(setParts, "brand = ?")
-		args = append(args, *brand)
+
+	return len(targets), nil
+}
+
+// Update はアイテムの更新可能な全カラムを item の値で上書きする
+// item.Version が現在のバージョンと一致するときのみ更新し、バージョンを1つ進める（楽観的排他制御）
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	query := `
+        UPDATE items
+        SET name = ?, category = ?, brand = ?, purchase_price = ?, purchase_date = ?, attributes = ?, location_id = ?, status = ?, search_text = ?,
+            updated_at = NOW(), version = version + 1
+        WHERE id = ? AND version = ? AND deleted_at IS NULL
+    `
+	attributes, err := encodeAttributes(item.Attributes)
+	if err != nil {
+		return nil, err
 	}
-	// purchasePriceがnilでない場合、SET文と値を追加
-	if purchasePrice != nil {
-		setParts = append(setParts, "purchase_price = ?")
-		args = append(args, *purchasePrice)
+
+	// 更新と再取得を1トランザクションで行う
+	var updated *entity.Item
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, query,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.PurchaseDate,
+			attributes,
+			item.LocationID,
+			string(item.Status),
+			searchText(item.Name, item.Brand),
+			item.ID,
+			item.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 実際に更新された行数を取得
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 更新された行が0の場合はアイテムが存在しないか、バージョンが一致しない
+		if rowsAffected == 0 {
+			return r.missingOrConflict(ctx, item.ID)
+		}
+
+		// 更新後のアイテムを取得する
+		updated, err = r.FindByID(ctx, item.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 更新対象のフィールドが一つもない場合はエラー
-	if len(setParts) == 0 {
-		return nil, fmt.Errorf("%w: no fields to update", domainErrors.ErrInvalidInput)
+	return updated, nil
+}
+
+// missingOrConflict は条件付きの更新・削除で対象行がなかった理由を判定する
+// アイテム自体が存在しない（ゴミ箱にある場合を含む）なら ErrItemNotFound、存在すればバージョン不一致の ErrVersionConflict を返す
+func (r *ItemRepository) missingOrConflict(ctx context.Context, id int64) error {
+	var exists bool
+	if err := r.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM items WHERE id = ? AND deleted_at IS NULL)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if !exists {
+		return domainErrors.ErrItemNotFound
 	}
+	return domainErrors.ErrVersionConflict
+}
 
-	// updated_atフィールドは必ず現在時刻で更新
-	setParts = append(setParts, "updated_at = NOW()")
-	
-	// WHERE条件用のIDを引数の最後に追加
-	args = append(args, id)
+// CountByField は絞り込み条件のもとで category / brand の値ごとの件数を集計する
+func (r *ItemRepository) CountByField(ctx context.Context, criteria usecase.ItemCriteria, field usecase.FacetField, limit int) ([]usecase.FacetCount, error) {
+	column, ok := itemFacetColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported facet field: %s", domainErrors.ErrInvalidInput, field)
+	}
 
-	// 動的にUPDATE SQLを構築（strings.JoinでSET部分をカンマで連結）
+	where, args := buildItemWhere(criteria)
 	query := fmt.Sprintf(`
-		UPDATE items 
-		SET %s
-		WHERE id = ?
-	`, strings.Join(setParts, ", "))
+        SELECT %s, COUNT(*) as count
+        FROM items
+        %s
+        GROUP BY %s
+        ORDER BY count DESC, %s
+    `, column, where, column, column)
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
 
-	// SQLを実行（プレースホルダーに値をバインド）
-	result, err := r.Execute(ctx, query, args...)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
+	defer rows.Close()
 
-	// 実際に更新された行数を取得
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	var facets []usecase.FacetCount
+	for rows.Next() {
+		var facet usecase.FacetCount
+		if err := rows.Scan(&facet.Value, &facet.Count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		facets = append(facets, facet)
 	}
 
-	// 更新された行が0の場合はアイテムが存在しない
-	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	// 更新後のアイテムを取得して返す
-	return r.FindByID(ctx, id)
+	return facets, nil
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
-	query := `
-        SELECT category, COUNT(*) as count
+// SumByCategory はゴミ箱以外で statuses のいずれかの所有状況のアイテムのカテゴリーごとの件数と購入価格の合計を返す
+func (r *ItemRepository) SumByCategory(ctx context.Context, statuses []string) ([]usecase.CategoryTotal, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+	rows, err := r.Query(ctx, `
+        SELECT category, COUNT(*), COALESCE(SUM(purchase_price), 0)
         FROM items
+        WHERE deleted_at IS NULL AND status IN (`+placeholders(len(statuses))+`)
         GROUP BY category
-    `
+    `, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var totals []usecase.CategoryTotal
+	for rows.Next() {
+		var total usecase.CategoryTotal
+		if err := rows.Scan(&total.Category, &total.Count, &total.Value); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return totals, nil
+}
+
+// CountByPriceBuckets は絞り込み条件のもとで価格帯ごとの件数を1回のクエリで集計する
+func (r *ItemRepository) CountByPriceBuckets(ctx context.Context, criteria usecase.ItemCriteria, buckets []usecase.PriceBucket) ([]int, error) {
+	if len(buckets) == 0 {
+		return []int{}, nil
+	}
+
+	columns := make([]string, 0, len(buckets))
+	args := []interface{}{}
+	for _, bucket := range buckets {
+		if bucket.Max == nil {
+			columns = append(columns, "COALESCE(SUM(purchase_price >= ?), 0)")
+			args = append(args, bucket.Min)
+			continue
+		}
+		columns = append(columns, "COALESCE(SUM(purchase_price >= ? AND purchase_price < ?), 0)")
+		args = append(args, bucket.Min, *bucket.Max)
+	}
+
+	where, whereArgs := buildItemWhere(criteria)
+	args = append(args, whereArgs...)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s
+    `, strings.Join(columns, ", "), where)
 
-	rows, err := r.Query(ctx, query)
+	counts := make([]int, len(buckets))
+	dest := make([]interface{}, len(buckets))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := r.QueryRow(ctx, query, args...).Scan(dest...); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return counts, nil
+}
+
+// queryItems はアイテムを返すSELECT文を実行し、結果をスライスにまとめる
+func (r *ItemRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*entity.Item, error) {
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	var items []*entity.Item
 	for rows.Next() {
-		var category string
-		var count int
-		if err := rows.Scan(&category, &count); err != nil {
+		item, err := scanItem(rows)
+		if err != nil {
 			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 		}
-		summary[category] = count
+		items = append(items, item)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	return summary, nil
+	return items, nil
 }
 
+// encodeAttributes はカスタム属性を JSON カラムに保存する値にする（属性がない場合は NULL）
+func encodeAttributes(attributes entity.Attributes) (interface{}, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return string(b), nil
+}
+
+// scanItem は1行分のアイテムを読み取る
+// extra にはアイテムの列の後ろに続く追加の列（検索スコアなど）の格納先を渡す
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
-}) (*entity.Item, error) {
+}, extra ...interface{}) (*entity.Item, error) {
 	var item entity.Item
 	var purchaseDate string
+	var attributes, tags []byte
 	var createdAt, updatedAt time.Time
+	var deletedAt sql.NullTime
+	var locationID sql.NullInt64
+	var status string
 
-	err := scanner.Scan(
+	dest := []interface{}{
 		&item.ID,
 		&item.Name,
 		&item.Category,
 		&item.Brand,
 		&item.PurchasePrice,
 		&purchaseDate,
+		&attributes,
 		&createdAt,
 		&updatedAt,
-	)
+		&item.Version,
+		&deletedAt,
+		&locationID,
+		&status,
+		&tags,
+	}
+	err := scanner.Scan(append(dest, extra...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -232,8 +671,30 @@ func scanItem(scanner interface {
 		}
 	}
 
+	item.Attributes = entity.Attributes{}
+	if len(attributes) > 0 {
+		if err := json.Unmarshal(attributes, &item.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to decode attributes: %w", err)
+		}
+	}
+
+	item.Tags = []string{}
+	if len(tags) > 0 {
+		if err := json.Unmarshal(tags, &item.Tags); err != nil {
+			return nil, fmt.Errorf("failed to decode tags: %w", err)
+		}
+		sort.Strings(item.Tags)
+	}
+
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+	if locationID.Valid {
+		item.LocationID = &locationID.Int64
+	}
+	item.Status = entity.ItemStatus(status)
 
 	return &item, nil
 }
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
+)
+
+// fakeSqlHandler は Query で rows を返すテスト用の SqlHandler（Query 以外は使わない）
+// 最後に Query に渡された引数を args に記録する
+type fakeSqlHandler struct {
+	SqlHandler
+	rows     *fakeRows
+	queryErr error
+	args     []interface{}
+}
+
+func (h *fakeSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.args = args
+	if h.queryErr != nil {
+		return nil, h.queryErr
+	}
+	return h.rows, nil
+}
+
+// fakeRows は values を1行ずつ返し、Next と Close の呼び出しを記録する
+type fakeRows struct {
+	values [][]interface{}
+	err    error // すべての行を返した後に Err が返すエラー
+	next   int
+	closed bool
+}
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.next >= len(r.values) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	row := r.values[r.next-1]
+	if len(dest) != len(row) {
+		return errors.New("column count mismatch")
+	}
+	for i, d := range dest {
+		if scanner, ok := d.(sql.Scanner); ok {
+			if err := scanner.Scan(row[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(row[i]))
+	}
+	return nil
+}
+
+func (r *fakeRows) Close() error {
+	r.closed = true
+	return nil
+}
+
+func (r *fakeRows) Err() error {
+	if r.next < len(r.values) {
+		return nil
+	}
+	return r.err
+}
+
+// itemRow は itemColumns の順に並べた1行分の値
+func itemRow(id int64) []interface{} {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []interface{}{
+		id, "時計", "時計", "ROLEX", 1000000, "2023-01-01", []byte(`{}`),
+		at, at, 1, nil, nil, "owned", []byte(nil),
+	}
+}
+
+func TestItemRepository_Iterate(t *testing.T) {
+	errBroken := errors.New("connection reset")
+
+	tests := []struct {
+		name      string
+		rowsErr   error
+		queryErr  error
+		stopAfter int // 0 の場合は最後まで読む
+		cancelAt  int // 0 の場合はキャンセルしない
+		wantIDs   []int64
+		wantNext  int
+		wantErr   error
+	}{
+		{
+			name:     "正常系: すべての行を読み取って rows を閉じる",
+			wantIDs:  []int64{1, 2, 3},
+			wantNext: 3,
+		},
+		{
+			name:      "正常系: 呼び出し側がループを抜けたら残りの行を読まずに rows を閉じる",
+			stopAfter: 1,
+			wantIDs:   []int64{1},
+			wantNext:  1,
+		},
+		{
+			name:     "異常系: context がキャンセルされたら残りの行を読まずに rows を閉じる",
+			cancelAt: 1,
+			wantIDs:  []int64{1},
+			wantNext: 2,
+			wantErr:  context.Canceled,
+		},
+		{
+			name:     "異常系: 読み取り中のエラーはデータベースのエラーとして返す",
+			rowsErr:  errBroken,
+			wantIDs:  []int64{1, 2, 3},
+			wantNext: 3,
+			wantErr:  domainErrors.ErrDatabaseError,
+		},
+		{
+			name:     "異常系: クエリの実行に失敗",
+			queryErr: errBroken,
+			wantErr:  domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := &fakeRows{values: [][]interface{}{itemRow(1), itemRow(2), itemRow(3)}, err: tt.rowsErr}
+			repo := &ItemRepository{SqlHandler: &fakeSqlHandler{rows: rows, queryErr: tt.queryErr}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var ids []int64
+			var err error
+			for item, iterErr := range repo.Iterate(ctx, usecase.ItemCriteria{Sort: []usecase.SortField{{Field: "id"}}}) {
+				if iterErr != nil {
+					err = iterErr
+					break
+				}
+				ids = append(ids, item.ID)
+				if len(ids) == tt.cancelAt {
+					cancel()
+				}
+				if len(ids) == tt.stopAfter {
+					break
+				}
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, rows.next)
+			if tt.queryErr == nil {
+				assert.True(t, rows.closed, "rows が閉じられていない")
+			}
+		})
+	}
+
+	t.Run("異常系: context のキャンセルで読み取りが中断した場合はキャンセルのエラーを返す", func(t *testing.T) {
+		rows := &fakeRows{values: [][]interface{}{itemRow(1)}, err: errBroken}
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{rows: rows}}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var err error
+		for _, iterErr := range repo.Iterate(ctx, usecase.ItemCriteria{}) {
+			if iterErr != nil {
+				err = iterErr
+				break
+			}
+			cancel()
+		}
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.True(t, rows.closed)
+	})
+}
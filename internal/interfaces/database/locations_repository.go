@@ -0,0 +1,270 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// LocationRepository は保管場所（locations テーブル）と移動履歴（item_moves テーブル）を扱う
+type LocationRepository struct {
+	SqlHandler
+}
+
+const locationColumns = "id, parent_id, name, kind, created_at, updated_at"
+
+const itemMoveColumns = "id, item_id, from_location_id, to_location_id, note, actor, moved_at, created_at"
+
+// FindAll はすべての保管場所を名前順に返す
+func (r *LocationRepository) FindAll(ctx context.Context) ([]*entity.Location, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM locations
+        ORDER BY name ASC, id ASC
+    `, locationColumns)
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var locations []*entity.Location
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return locations, nil
+}
+
+// FindByID は保管場所を返す
+func (r *LocationRepository) FindByID(ctx context.Context, id int64) (*entity.Location, error) {
+	return r.findByID(ctx, id, "")
+}
+
+// LockByID は保管場所の行を FOR UPDATE でロックして返す
+// 外部キーの確認でアイテムの移動・子の登録は共有ロックを取るため、ロック中はこの保管場所の中身が増えない
+func (r *LocationRepository) LockByID(ctx context.Context, id int64) (*entity.Location, error) {
+	return r.findByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *LocationRepository) findByID(ctx context.Context, id int64, lock string) (*entity.Location, error) {
+	query := fmt.Sprintf(`SELECT %s FROM locations WHERE id = ? %s`, locationColumns, lock)
+
+	location, err := scanLocation(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return location, nil
+}
+
+// Create は保管場所を登録する
+func (r *LocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	var created *entity.Location
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx,
+			`INSERT INTO locations (parent_id, name, kind) VALUES (?, ?, ?)`,
+			location.ParentID,
+			location.Name,
+			string(location.Kind),
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		created, err = r.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update は親・名前・種類を保存する
+func (r *LocationRepository) Update(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	var updated *entity.Location
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Execute(ctx,
+			`UPDATE locations SET parent_id = ?, name = ?, kind = ?, updated_at = NOW() WHERE id = ?`,
+			location.ParentID,
+			location.Name,
+			string(location.Kind),
+			location.ID,
+		); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 値が変わらない場合は RowsAffected が0になるため、存在確認は再取得で行う
+		var err error
+		updated, err = r.FindByID(ctx, location.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Delete は保管場所を削除する
+// ゴミ箱のものも含めアイテムや中の保管場所が参照している場合は、外部キー制約で失敗する
+func (r *LocationRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM locations WHERE id = ?`, id)
+	if err != nil {
+		if strings.Contains(err.Error(), mysqlErrRowIsReferenced) {
+			return fmt.Errorf("%w: location %d is referenced by items or child locations", domainErrors.ErrLocationInUse, id)
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrLocationNotFound
+	}
+
+	return nil
+}
+
+// CountItems は保管場所に置かれているアイテムの件数を返す（ゴミ箱のアイテムも含む）
+func (r *LocationRepository) CountItems(ctx context.Context, id int64) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items WHERE location_id = ?`, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
+
+// CreateMove は移動履歴を保存する
+func (r *LocationRepository) CreateMove(ctx context.Context, move *entity.ItemMove) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		result, err := r.Execute(ctx, `
+            INSERT INTO item_moves (item_id, from_location_id, to_location_id, note, actor, moved_at)
+            VALUES (?, ?, ?, ?, ?, ?)
+        `, move.ItemID, move.FromLocationID, move.ToLocationID, move.Note, move.Actor, move.MovedAt)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		query := fmt.Sprintf(`SELECT %s FROM item_moves WHERE id = ?`, itemMoveColumns)
+		saved, err := scanItemMove(r.QueryRow(ctx, query, id))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		move.ID = saved.ID
+		move.MovedAt = saved.MovedAt
+		move.CreatedAt = saved.CreatedAt
+		return nil
+	})
+}
+
+// FindMovesByItemID はアイテムの移動履歴を移動日時の新しい順にすべて返す
+func (r *LocationRepository) FindMovesByItemID(ctx context.Context, itemID int64) ([]*entity.ItemMove, error) {
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM item_moves
+        WHERE item_id = ?
+        ORDER BY moved_at DESC, id DESC
+    `, itemMoveColumns)
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var moves []*entity.ItemMove
+	for rows.Next() {
+		move, err := scanItemMove(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		moves = append(moves, move)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return moves, nil
+}
+
+func scanLocation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Location, error) {
+	var location entity.Location
+	var parentID sql.NullInt64
+	var kind string
+	if err := scanner.Scan(
+		&location.ID,
+		&parentID,
+		&location.Name,
+		&kind,
+		&location.CreatedAt,
+		&location.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		location.ParentID = &parentID.Int64
+	}
+	location.Kind = entity.LocationKind(kind)
+	return &location, nil
+}
+
+func scanItemMove(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemMove, error) {
+	var move entity.ItemMove
+	var from, to sql.NullInt64
+	if err := scanner.Scan(
+		&move.ID,
+		&move.ItemID,
+		&from,
+		&to,
+		&move.Note,
+		&move.Actor,
+		&move.MovedAt,
+		&move.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if from.Valid {
+		move.FromLocationID = &from.Int64
+	}
+	if to.Valid {
+		move.ToLocationID = &to.Int64
+	}
+	return &move, nil
+}
@@ -7,6 +7,12 @@ type SqlHandler interface {
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
 	Close() error
+
+	// WithTx は fn を1つのトランザクション内で実行する
+	// fn に渡された ctx で Execute / Query / QueryRow を呼ぶと同じトランザクションで実行され、
+	// fn がエラーを返すかパニックした場合はロールバック、それ以外はコミットする。
+	// ctx が既にトランザクションを持っている場合はそのトランザクションに参加する
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Result interface {
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/usecase"
+)
+
+// TagRepository はタグ（tags / item_tags テーブル）を扱う
+type TagRepository struct {
+	SqlHandler
+}
+
+// FindAll はすべてのタグを、付いているアイテム（ゴミ箱を除く）の件数の多い順・名前順で返す
+// どのアイテムにも付いていないタグは件数0で返す
+func (r *TagRepository) FindAll(ctx context.Context) ([]usecase.TagCount, error) {
+	query := `
+        SELECT t.id, t.name, COUNT(i.id)
+        FROM tags t
+        LEFT JOIN item_tags it ON it.tag_id = t.id
+        LEFT JOIN items i ON i.id = it.item_id AND i.deleted_at IS NULL
+        GROUP BY t.id, t.name
+        ORDER BY COUNT(i.id) DESC, t.name ASC
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var tags []usecase.TagCount
+	for rows.Next() {
+		var tag usecase.TagCount
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tags, nil
+}
+
+// FindByID はタグを返す
+func (r *TagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	var tag entity.Tag
+	err := r.QueryRow(ctx, `SELECT id, name, created_at, updated_at FROM tags WHERE id = ?`, id).
+		Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &tag, nil
+}
+
+// ReplaceItemTags はアイテムのタグを names で置き換える
+// 外したタグの行のみ削除し、引き続き付いているタグは付けた日時を保持する
+func (r *TagRepository) ReplaceItemTags(ctx context.Context, itemID int64, names []string) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		remove := `DELETE FROM item_tags WHERE item_id = ?`
+		args := []interface{}{itemID}
+		if len(names) > 0 {
+			remove = `
+                DELETE it FROM item_tags it JOIN tags t ON t.id = it.tag_id
+                WHERE it.item_id = ? AND t.name NOT IN (` + placeholders(len(names)) + `)
+            `
+			for _, name := range names {
+				args = append(args, name)
+			}
+		}
+		if _, err := r.Execute(ctx, remove, args...); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		for _, name := range names {
+			// 既に登録されている名前の場合は、そのタグのIDを LAST_INSERT_ID で受け取る
+			result, err := r.Execute(ctx, `INSERT INTO tags (name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)`, name)
+			if err != nil {
+				return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+			tagID, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+
+			if _, err := r.Execute(ctx, `INSERT IGNORE INTO item_tags (item_id, tag_id) VALUES (?, ?)`, itemID, tagID); err != nil {
+				return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+		}
+		return nil
+	})
+}
+
+// Update はタグの名前を保存し、タグが付いたアイテムのバージョンを進める
+func (r *TagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	var updated *entity.Tag
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Execute(ctx, `UPDATE tags SET name = ?, updated_at = NOW() WHERE id = ?`, tag.Name, tag.ID); err != nil {
+			if strings.Contains(err.Error(), mysqlErrDuplicateEntry) {
+				return fmt.Errorf("%w: tag %s already exists", domainErrors.ErrDuplicateEntry, tag.Name)
+			}
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if err := r.touchTaggedItems(ctx, tag.ID); err != nil {
+			return err
+		}
+
+		var err error
+		updated, err = r.FindByID(ctx, tag.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Merge は source のタグを target のタグに付け替えてから、source のタグを削除する
+// 両方のタグが付いていたアイテムは target のタグのみになる
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID int64) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.touchTaggedItems(ctx, sourceID); err != nil {
+			return err
+		}
+
+		if _, err := r.Execute(ctx, `
+            INSERT IGNORE INTO item_tags (item_id, tag_id, created_at)
+            SELECT item_id, ?, created_at FROM item_tags WHERE tag_id = ?
+        `, targetID, sourceID); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// item_tags の行は外部キーの ON DELETE CASCADE で削除される
+		result, err := r.Execute(ctx, `DELETE FROM tags WHERE id = ?`, sourceID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if rowsAffected == 0 {
+			return domainErrors.ErrTagNotFound
+		}
+		return nil
+	})
+}
+
+// touchTaggedItems はタグが付いたアイテム（ゴミ箱を含む）のバージョンを進める
+// タグ名はアイテムの内容として返すため、ETag が変わるようにする
+func (r *TagRepository) touchTaggedItems(ctx context.Context, tagID int64) error {
+	if _, err := r.Execute(ctx,
+		`UPDATE items SET version = version + 1 WHERE id IN (SELECT item_id FROM item_tags WHERE tag_id = ?)`,
+		tagID,
+	); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package database
+
+import "context"
+
+// Transactor はユースケース層の Unit of Work（usecase.Transactor）の実装
+// SqlHandler.WithTx に委譲し、トランザクションは context.Context 経由で各リポジトリに伝播する
+type Transactor struct {
+	SqlHandler
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.WithTx(ctx, fn)
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import "context"
+
+// 操作者が分からない場合に変更履歴へ記録する名前
+const AnonymousActor = "anonymous"
+
+type actorContextKey struct{}
+
+// WithActor は変更履歴に記録する操作者を ctx に設定する
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext は ctx に設定された操作者を返す（未設定の場合は AnonymousActor）
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
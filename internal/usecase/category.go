@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// CategoryUsecase はカテゴリーの管理を行う
+type CategoryUsecase interface {
+	// GetCategories はカテゴリーを並び順に返す（includeInactive が false の場合は有効なもののみ）
+	GetCategories(ctx context.Context, includeInactive bool) ([]*entity.Category, error)
+	GetCategory(ctx context.Context, id int64) (*entity.Category, error)
+	CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error)
+	// UpdateCategory は表示名・親・並び順・有効フラグ・属性のスキーマを更新する（コードは変更できない）
+	// アイテムで使われているカテゴリー（子孫のカテゴリーを含む）は無効にできない
+	UpdateCategory(ctx context.Context, id int64, input UpdateCategoryInput) (*entity.Category, error)
+	// DeleteCategory はカテゴリーを削除する（子カテゴリーがある場合・アイテムで使われている場合は削除できない）
+	DeleteCategory(ctx context.Context, id int64) error
+}
+
+// CreateCategoryInput はカテゴリーの登録の入力（ParentID が nil の場合は最上位に登録する）
+type CreateCategoryInput struct {
+	Code            string                 `json:"code"`
+	NameJa          string                 `json:"name_ja"`
+	NameEn          string                 `json:"name_en"`
+	ParentID        *int64                 `json:"parent_id,omitempty"`
+	SortOrder       int                    `json:"sort_order"`
+	AttributeSchema entity.AttributeSchema `json:"attribute_schema"`
+}
+
+// UpdateCategoryInput はカテゴリーの部分更新の入力（nil のフィールドは更新しない）
+// ParentID に 0 を指定すると最上位に移動する。AttributeSchema は指定した場合、定義全体を置き換える
+type UpdateCategoryInput struct {
+	NameJa          *string                 `json:"name_ja,omitempty"`
+	NameEn          *string                 `json:"name_en,omitempty"`
+	ParentID        *int64                  `json:"parent_id,omitempty"`
+	SortOrder       *int                    `json:"sort_order,omitempty"`
+	Active          *bool                   `json:"active,omitempty"`
+	AttributeSchema *entity.AttributeSchema `json:"attribute_schema,omitempty"`
+}
+
+type categoryUsecase struct {
+	categoryRepo CategoryRepository
+	cache        *CategoryCache
+	transactor   Transactor
+}
+
+func NewCategoryUsecase(categoryRepo CategoryRepository, cache *CategoryCache, transactor Transactor) CategoryUsecase {
+	return &categoryUsecase{
+		categoryRepo: categoryRepo,
+		cache:        cache,
+		transactor:   transactor,
+	}
+}
+
+func (u *categoryUsecase) GetCategories(ctx context.Context, includeInactive bool) ([]*entity.Category, error) {
+	categories, err := u.cache.Categories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	result := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		if includeInactive || category.Active {
+			result = append(result, category)
+		}
+	}
+	return result, nil
+}
+
+func (u *categoryUsecase) GetCategory(ctx context.Context, id int64) (*entity.Category, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve category: %w", err)
+	}
+	return category, nil
+}
+
+func (u *categoryUsecase) CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error) {
+	category, err := entity.NewCategory(input.Code, input.NameJa, input.NameEn, input.SortOrder, input.AttributeSchema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	category.ParentID = input.ParentID
+
+	var created *entity.Category
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if category.ParentID != nil {
+			if err := u.validateParent(ctx, nil, category, *category.ParentID); err != nil {
+				return err
+			}
+		}
+
+		created, err = u.categoryRepo.Create(ctx, category)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, err
+		}
+		return nil, categoryWriteError("create", err)
+	}
+
+	u.cache.Invalidate()
+	return created, nil
+}
+
+func (u *categoryUsecase) UpdateCategory(ctx context.Context, id int64, input UpdateCategoryInput) (*entity.Category, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if input.NameJa == nil && input.NameEn == nil && input.ParentID == nil && input.SortOrder == nil && input.Active == nil && input.AttributeSchema == nil {
+		return nil, domainErrors.NewInputError(domainErrors.CodeNoFieldsToUpdate, "no fields to update", nil)
+	}
+
+	var updated *entity.Category
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 無効化の確認中に、このカテゴリーのアイテムが登録されないようにロックする
+		category, err := u.categoryRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// 親の付け替えと無効化の確認には、最新の親子関係を使う
+		var tree *entity.CategoryTree
+		if input.ParentID != nil || input.Active != nil {
+			categories, err := u.categoryRepo.FindAll(ctx)
+			if err != nil {
+				return err
+			}
+			tree = entity.NewCategoryTree(categories)
+		}
+
+		if input.ParentID != nil {
+			if *input.ParentID == 0 {
+				// 0 は最上位への移動
+				category.ParentID = nil
+			} else if category.ParentID == nil || *category.ParentID != *input.ParentID {
+				if err := u.validateParent(ctx, tree, category, *input.ParentID); err != nil {
+					return err
+				}
+				parentID := *input.ParentID
+				category.ParentID = &parentID
+			}
+		}
+
+		nameJa, nameEn, sortOrder, active, schema := category.NameJa, category.NameEn, category.SortOrder, category.Active, category.AttributeSchema
+		if input.NameJa != nil {
+			nameJa = *input.NameJa
+		}
+		if input.NameEn != nil {
+			nameEn = *input.NameEn
+		}
+		if input.SortOrder != nil {
+			sortOrder = *input.SortOrder
+		}
+		if input.Active != nil {
+			active = *input.Active
+		}
+		if input.AttributeSchema != nil {
+			schema = *input.AttributeSchema
+		}
+
+		if category.Active && !active {
+			// 親を無効にすると子孫のカテゴリーも設定できなくなるため、子孫のアイテムも確認する
+			if err := u.ensureUnused(ctx, append([]*entity.Category{category}, tree.Descendants(category.ID)...)); err != nil {
+				return err
+			}
+		}
+
+		if err := category.Update(nameJa, nameEn, sortOrder, active, schema); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		updated, err = u.categoryRepo.Update(ctx, category)
+		return err
+	})
+	if err != nil {
+		return nil, categoryWriteError("update", err)
+	}
+
+	u.cache.Invalidate()
+	return updated, nil
+}
+
+func (u *categoryUsecase) DeleteCategory(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		category, err := u.categoryRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		categories, err := u.categoryRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		if children := entity.NewCategoryTree(categories).Children(id); len(children) > 0 {
+			return fmt.Errorf("%w: category %s has %d child categories", domainErrors.ErrCategoryHasChildren, category.Code, len(children))
+		}
+
+		if err := u.ensureUnused(ctx, []*entity.Category{category}); err != nil {
+			return err
+		}
+		return u.categoryRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return categoryWriteError("delete", err)
+	}
+
+	u.cache.Invalidate()
+	return nil
+}
+
+// validateParent は parentID を category の親に設定できるかを確認する
+// 親の行をロックし、確認中に親へアイテムが登録されないようにする
+// tree は付け替えの場合の循環の確認に使う（新規登録の場合は nil）
+func (u *categoryUsecase) validateParent(ctx context.Context, tree *entity.CategoryTree, category *entity.Category, parentID int64) error {
+	var errs domainErrors.ValidationErrors
+
+	parent, err := u.categoryRepo.LockByID(ctx, parentID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id does not exist", nil)
+			return errs.Err()
+		}
+		return err
+	}
+
+	if parent.ID == category.ID || (tree != nil && tree.IsDescendant(category.ID, parent.ID)) {
+		errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id must not be the category itself or its descendant", nil)
+		return errs.Err()
+	}
+
+	// アイテムは末端のカテゴリーにのみ設定できるため、アイテムのあるカテゴリーの下には追加できない
+	count, err := u.categoryRepo.CountItems(ctx, parent.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		errs.Add("parent_id", domainErrors.CodeParentHasItems, "parent category is used by items", map[string]interface{}{"count": count})
+		return errs.Err()
+	}
+
+	return nil
+}
+
+// ensureUnused はカテゴリーを使っているアイテムがないことを確認する
+func (u *categoryUsecase) ensureUnused(ctx context.Context, categories []*entity.Category) error {
+	for _, category := range categories {
+		count, err := u.categoryRepo.CountItems(ctx, category.Code)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d item(s) use category %s", domainErrors.ErrCategoryInUse, count, category.Code)
+		}
+	}
+	return nil
+}
+
+// categoryWriteError は登録・更新・削除のエラーのうち、呼び出し側で判別するものはそのまま返す
+func categoryWriteError(op string, err error) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return domainErrors.ErrCategoryNotFound
+	case errors.Is(err, domainErrors.ErrCategoryInUse),
+		errors.Is(err, domainErrors.ErrCategoryHasChildren),
+		domainErrors.IsValidationError(err):
+		return err
+	default:
+		return fmt.Errorf("failed to %s category: %w", op, err)
+	}
+}
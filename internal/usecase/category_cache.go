@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+)
+
+// CategoryCache は categories テーブルの内容をメモリに保持する
+// アイテムのバリデーションのたびにデータベースを参照しないため、ItemUsecase が Tree で取得したものを entity.CategoryChecker として渡す
+// 同じプロセスでカテゴリーを変更した場合は Invalidate ですぐに反映し、
+// 他のサーバーでの変更は ttl が経過した後の最初の参照で反映する
+type CategoryCache struct {
+	repo CategoryRepository
+	ttl  time.Duration
+
+	mu       sync.RWMutex
+	tree     *entity.CategoryTree
+	loadedAt time.Time
+}
+
+// NewCategoryCache はキャッシュを作成する（読み込みは最初の参照時か Load で行う）
+func NewCategoryCache(repo CategoryRepository, ttl time.Duration) *CategoryCache {
+	return &CategoryCache{repo: repo, ttl: ttl}
+}
+
+// Load はカテゴリーをデータベースから読み込み直す
+func (c *CategoryCache) Load(ctx context.Context) error {
+	categories, err := c.repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	tree := entity.NewCategoryTree(categories)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tree = tree
+	c.loadedAt = time.Now()
+	return nil
+}
+
+// Invalidate は次の参照時にデータベースから読み込み直すようにする
+func (c *CategoryCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
+
+// Tree はカテゴリーの親子関係を返す（期限切れの場合は読み込み直す）
+func (c *CategoryCache) Tree(ctx context.Context) (*entity.CategoryTree, error) {
+	c.mu.RLock()
+	tree, fresh := c.tree, c.isFresh()
+	c.mu.RUnlock()
+	if fresh {
+		return tree, nil
+	}
+
+	if err := c.Load(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tree, nil
+}
+
+// Categories はすべてのカテゴリーを並び順に返す（期限切れの場合は読み込み直す）
+func (c *CategoryCache) Categories(ctx context.Context) ([]*entity.Category, error) {
+	tree, err := c.Tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tree.All(), nil
+}
+
+// isFresh は読み込み済みで、期限が切れていないかを返す（mu を取得した状態で呼ぶ）
+func (c *CategoryCache) isFresh() bool {
+	return !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// CategoryTotal はカテゴリーごとのアイテムの件数と購入価格の合計
+type CategoryTotal struct {
+	Category string
+	Count    int
+	Value    int
+}
+
+// CategorySummaryNode はカテゴリー別集計の1カテゴリー分
+// Count と Value は子孫のカテゴリーのアイテムを含めた値
+type CategorySummaryNode struct {
+	Code     string                 `json:"code"`
+	NameJa   string                 `json:"name_ja"`
+	NameEn   string                 `json:"name_en"`
+	Count    int                    `json:"count"`
+	Value    int                    `json:"value"`
+	Children []*CategorySummaryNode `json:"children,omitempty"`
+}
+
+// CategorySummary はカテゴリーの木に沿ったアイテムの件数と購入価格の合計
+type CategorySummary struct {
+	Categories []*CategorySummaryNode `json:"categories"`
+	Total      int                    `json:"total"`
+	TotalValue int                    `json:"total_value"`
+	Statuses   []string               `json:"statuses"` // 集計した所有状況
+}
+
+// GetCategorySummary は有効なカテゴリーごとの件数と購入価格の合計を、親カテゴリーに積み上げて返す
+// statuses を省略した場合は現在の所有資産（entity.HeldStatuses）のみを集計する
+func (u *itemUsecase) GetCategorySummary(ctx context.Context, statuses []string) (*CategorySummary, error) {
+	if len(statuses) == 0 {
+		for _, status := range entity.HeldStatuses {
+			statuses = append(statuses, string(status))
+		}
+	}
+	for _, status := range statuses {
+		if !entity.ItemStatus(status).IsValid() {
+			return nil, domainErrors.NewInputError(domainErrors.CodeInvalidOption, "status must be one of: "+strings.Join(entity.ItemStatuses, ", "), map[string]interface{}{"name": "status", "allowed": entity.ItemStatuses})
+		}
+	}
+
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	totals, err := u.itemRepo.SumByCategory(ctx, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	summary := &CategorySummary{Statuses: statuses}
+	byCode := make(map[string]CategoryTotal, len(totals))
+	for _, total := range totals {
+		byCode[total.Category] = total
+		summary.Total += total.Count
+		summary.TotalValue += total.Value
+	}
+
+	tree := entity.NewCategoryTree(categories)
+	summary.Categories = summarizeCategories(tree, tree.Roots(), byCode)
+
+	return summary, nil
+}
+
+// summarizeCategories は categories とその子孫の集計を組み立てる
+// 無効なカテゴリーはアイテムで使われていないため、子孫ごと除く
+func summarizeCategories(tree *entity.CategoryTree, categories []*entity.Category, totals map[string]CategoryTotal) []*CategorySummaryNode {
+	nodes := []*CategorySummaryNode{}
+	for _, category := range categories {
+		if !category.Active {
+			continue
+		}
+
+		node := &CategorySummaryNode{
+			Code:     category.Code,
+			NameJa:   category.NameJa,
+			NameEn:   category.NameEn,
+			Count:    totals[category.Code].Count,
+			Value:    totals[category.Code].Value,
+			Children: summarizeCategories(tree, tree.Children(category.ID), totals),
+		}
+		for _, child := range node.Children {
+			node.Count += child.Count
+			node.Value += child.Value
+		}
+		if len(node.Children) == 0 {
+			node.Children = nil
+		}
+
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// expandCategoryFilter は絞り込みのカテゴリーに子カテゴリーがある場合、子孫のカテゴリーも含める
+func (u *itemUsecase) expandCategoryFilter(ctx context.Context, criteria *ItemCriteria) error {
+	criteria.Categories = nil
+	if criteria.Category == "" {
+		return nil
+	}
+
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	tree := entity.NewCategoryTree(categories)
+	category, ok := tree.FindByCode(criteria.Category)
+	if !ok || tree.IsLeaf(category.ID) {
+		return nil
+	}
+
+	criteria.Categories = []string{category.Code}
+	for _, descendant := range tree.Descendants(category.ID) {
+		criteria.Categories = append(criteria.Categories, descendant.Code)
+	}
+	return nil
+}
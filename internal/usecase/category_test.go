@@ -0,0 +1,532 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func newTestCategoryUsecase(repo *MockCategoryRepository) (CategoryUsecase, *CategoryCache) {
+	cache := NewCategoryCache(repo, time.Hour)
+	return NewCategoryUsecase(repo, cache, new(MockTransactor)), cache
+}
+
+func TestCategoryUsecase_GetCategories(t *testing.T) {
+	repo := newMockCategoryRepository()
+	repo.categories[4].Active = false
+	usecase, _ := newTestCategoryUsecase(repo)
+
+	t.Run("正常系: 有効なカテゴリーのみ", func(t *testing.T) {
+		categories, err := usecase.GetCategories(context.Background(), false)
+		require.NoError(t, err)
+		assert.Len(t, categories, 4)
+	})
+
+	t.Run("正常系: 無効なカテゴリーも含める", func(t *testing.T) {
+		categories, err := usecase.GetCategories(context.Background(), true)
+		require.NoError(t, err)
+		assert.Len(t, categories, 5)
+	})
+
+	t.Run("正常系: 2回目以降はキャッシュから返す", func(t *testing.T) {
+		assert.Equal(t, 1, repo.findAllCalls)
+	})
+}
+
+func TestCategoryUsecase_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateCategoryInput
+		wantErr error
+	}{
+		{
+			name:  "正常系: カテゴリーを登録",
+			input: CreateCategoryInput{Code: "アパレル", NameJa: "アパレル", NameEn: "Apparel", SortOrder: 60},
+		},
+		{
+			name:    "異常系: 名前が未入力",
+			input:   CreateCategoryInput{Code: "アパレル", NameJa: "", NameEn: "Apparel"},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: コードが重複",
+			input:   CreateCategoryInput{Code: "時計", NameJa: "時計", NameEn: "Watches"},
+			wantErr: domainErrors.ErrDuplicateEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockCategoryRepository()
+			usecase, cache := newTestCategoryUsecase(repo)
+			require.NoError(t, cache.Load(context.Background()))
+
+			category, err := usecase.CreateCategory(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, category)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotZero(t, category.ID)
+			assert.True(t, category.Active)
+			// 登録したカテゴリーがすぐにアイテムで使えること
+			assert.True(t, isAssignable(t, cache, tt.input.Code))
+		})
+	}
+}
+
+func TestCategoryUsecase_UpdateCategory(t *testing.T) {
+	nameEn := "Timepieces"
+	sortOrder := -1
+	inactive := false
+
+	tests := []struct {
+		name       string
+		id         int64
+		input      UpdateCategoryInput
+		itemCounts map[string]int
+		wantErr    error
+		check      func(t *testing.T, category *entity.Category)
+	}{
+		{
+			name:  "正常系: 表示名のみ更新",
+			id:    1,
+			input: UpdateCategoryInput{NameEn: &nameEn},
+			check: func(t *testing.T, category *entity.Category) {
+				assert.Equal(t, "Timepieces", category.NameEn)
+				assert.Equal(t, "時計", category.NameJa)
+				assert.True(t, category.Active)
+			},
+		},
+		{
+			name:  "正常系: 使われていないカテゴリーを無効化",
+			id:    1,
+			input: UpdateCategoryInput{Active: &inactive},
+			check: func(t *testing.T, category *entity.Category) {
+				assert.False(t, category.Active)
+			},
+		},
+		{
+			name:       "異常系: 使われているカテゴリーは無効化できない",
+			id:         1,
+			input:      UpdateCategoryInput{Active: &inactive},
+			itemCounts: map[string]int{"時計": 3},
+			wantErr:    domainErrors.ErrCategoryInUse,
+		},
+		{
+			name:    "異常系: 並び順が負の値",
+			id:      1,
+			input:   UpdateCategoryInput{SortOrder: &sortOrder},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 更新するフィールドがない",
+			id:      1,
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 存在しないカテゴリー",
+			id:      999,
+			input:   UpdateCategoryInput{NameEn: &nameEn},
+			wantErr: domainErrors.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockCategoryRepository()
+			for code, count := range tt.itemCounts {
+				repo.itemCounts[code] = count
+			}
+			usecase, cache := newTestCategoryUsecase(repo)
+			require.NoError(t, cache.Load(context.Background()))
+
+			category, err := usecase.UpdateCategory(context.Background(), tt.id, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, category)
+				// 失敗した場合は有効なままであること
+				assert.True(t, isAssignable(t, cache, "時計"))
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, category)
+			assert.Equal(t, category.Active, isAssignable(t, cache, category.Code))
+		})
+	}
+}
+
+func TestCategoryUsecase_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		itemCounts map[string]int
+		wantErr    error
+	}{
+		{
+			name: "正常系: 使われていないカテゴリーを削除",
+			id:   5,
+		},
+		{
+			name:       "異常系: 使われているカテゴリーは削除できない",
+			id:         5,
+			itemCounts: map[string]int{"その他": 1},
+			wantErr:    domainErrors.ErrCategoryInUse,
+		},
+		{
+			name:    "異常系: 存在しないカテゴリー",
+			id:      999,
+			wantErr: domainErrors.ErrCategoryNotFound,
+		},
+		{
+			name:    "異常系: 不正なID",
+			id:      0,
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockCategoryRepository()
+			for code, count := range tt.itemCounts {
+				repo.itemCounts[code] = count
+			}
+			usecase, cache := newTestCategoryUsecase(repo)
+			require.NoError(t, cache.Load(context.Background()))
+
+			err := usecase.DeleteCategory(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, repo.categories, 4)
+			assert.False(t, isAssignable(t, cache, "その他"))
+		})
+	}
+}
+
+// isAssignable はキャッシュから取得したカテゴリーで、code をアイテムに設定できるかを返す
+func isAssignable(t *testing.T, cache *CategoryCache, code string) bool {
+	t.Helper()
+	tree, err := cache.Tree(context.Background())
+	require.NoError(t, err)
+	return tree.IsAssignable(code)
+}
+
+func TestCategoryCache(t *testing.T) {
+	t.Run("正常系: 期限切れの場合は読み込み直す", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		cache := NewCategoryCache(repo, time.Millisecond)
+
+		assert.True(t, isAssignable(t, cache, "時計"))
+		repo.categories[0].Active = false
+		time.Sleep(5 * time.Millisecond)
+
+		tree, err := cache.Tree(context.Background())
+		require.NoError(t, err)
+		assert.False(t, tree.IsAssignable("時計"))
+		assert.Equal(t, []string{"バッグ", "ジュエリー", "靴", "その他"}, tree.AssignableCodes())
+	})
+
+	t.Run("異常系: 読み込み直しに失敗した場合は古い内容を使わずにエラーを返す", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		cache := NewCategoryCache(repo, time.Millisecond)
+		require.NoError(t, cache.Load(context.Background()))
+
+		repo.err = domainErrors.ErrDatabaseError
+		time.Sleep(5 * time.Millisecond)
+
+		_, err := cache.Tree(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("異常系: 未登録のカテゴリー", func(t *testing.T) {
+		cache := NewCategoryCache(newMockCategoryRepository(), time.Hour)
+		assert.False(t, isAssignable(t, cache, "家具"))
+	})
+}
+
+// newMockCategoryTree はデフォルトの5カテゴリーに、バッグ（ID: 2）の子カテゴリーを追加したモックを返す
+//
+//	バッグ ─┬ トート（ID: 6）
+//	        └ クラッチ（ID: 7）─ ミニクラッチ（ID: 8）
+func newMockCategoryTree() *MockCategoryRepository {
+	repo := newMockCategoryRepository()
+	bags, clutches := int64(2), int64(7)
+	repo.categories = append(repo.categories,
+		&entity.Category{ID: 6, Code: "トート", NameJa: "トート", NameEn: "Totes", ParentID: &bags, SortOrder: 10, Active: true},
+		&entity.Category{ID: 7, Code: "クラッチ", NameJa: "クラッチ", NameEn: "Clutches", ParentID: &bags, SortOrder: 20, Active: true},
+		&entity.Category{ID: 8, Code: "ミニクラッチ", NameJa: "ミニクラッチ", NameEn: "Mini clutches", ParentID: &clutches, SortOrder: 10, Active: true},
+	)
+	return repo
+}
+
+func TestItemUsecase_GetCategorySummaryRollup(t *testing.T) {
+	repo := newMockCategoryTree()
+	repo.categories[4].Active = false // その他
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("SumByCategory", context.Background(), []string{"owned", "on_loan", "in_repair", "consigned"}).Return([]CategoryTotal{
+		{Category: "時計", Count: 1, Value: 1500000},
+		{Category: "トート", Count: 2, Value: 600000},
+		{Category: "ミニクラッチ", Count: 1, Value: 250000},
+	}, nil)
+	usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, categoryRepo: repo})
+
+	summary, err := usecase.GetCategorySummary(context.Background(), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 2350000, summary.TotalValue)
+
+	// 無効なカテゴリーは含めない
+	codes := make([]string, len(summary.Categories))
+	for i, node := range summary.Categories {
+		codes[i] = node.Code
+	}
+	assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴"}, codes)
+
+	// 子孫のカテゴリーの件数・金額を親に積み上げる
+	bags := summary.Categories[1]
+	assert.Equal(t, 3, bags.Count)
+	assert.Equal(t, 850000, bags.Value)
+	require.Len(t, bags.Children, 2)
+	assert.Equal(t, "トート", bags.Children[0].Code)
+	assert.Equal(t, 2, bags.Children[0].Count)
+	clutches := bags.Children[1]
+	assert.Equal(t, 1, clutches.Count)
+	assert.Equal(t, 250000, clutches.Value)
+	require.Len(t, clutches.Children, 1)
+	assert.Nil(t, clutches.Children[0].Children)
+}
+
+func TestItemUsecase_CategoryFilterIncludesDescendants(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		want     []string
+	}{
+		{name: "正常系: 親カテゴリーは子孫のカテゴリーを含める", category: "バッグ", want: []string{"バッグ", "トート", "クラッチ", "ミニクラッチ"}},
+		{name: "正常系: 中間のカテゴリー", category: "クラッチ", want: []string{"クラッチ", "ミニクラッチ"}},
+		{name: "正常系: 末端のカテゴリーはそのまま", category: "トート", want: nil},
+		{name: "正常系: 未登録のカテゴリーはそのまま", category: "家具", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			matches := mock.MatchedBy(func(c ItemCriteria) bool {
+				return c.Category == tt.category && assert.ObjectsAreEqual(tt.want, c.Categories)
+			})
+			mockRepo.On("Count", mock.Anything, matches).Return(0, nil)
+			mockRepo.On("FindAll", mock.Anything, matches).Return(([]*entity.Item)(nil), nil)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, categoryRepo: newMockCategoryTree()})
+
+			_, err := usecase.GetAllItems(context.Background(), ItemCriteria{Category: tt.category})
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryUsecase_CategoryTree(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	inactive := false
+
+	t.Run("正常系: 子カテゴリーを登録すると親はアイテムに設定できなくなる", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		usecase, cache := newTestCategoryUsecase(repo)
+
+		created, err := usecase.CreateCategory(context.Background(), CreateCategoryInput{
+			Code: "リュック", NameJa: "リュック", NameEn: "Backpacks", ParentID: int64Ptr(2),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), *created.ParentID)
+		assert.True(t, isAssignable(t, cache, "リュック"))
+		assert.False(t, isAssignable(t, cache, "バッグ"))
+	})
+
+	t.Run("異常系: アイテムのあるカテゴリーの下には登録できない", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		repo.itemCounts["バッグ"] = 1
+		usecase, _ := newTestCategoryUsecase(repo)
+
+		_, err := usecase.CreateCategory(context.Background(), CreateCategoryInput{
+			Code: "リュック", NameJa: "リュック", NameEn: "Backpacks", ParentID: int64Ptr(2),
+		})
+
+		verrs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeParentHasItems, verrs[0].Code)
+	})
+
+	t.Run("異常系: 存在しない親", func(t *testing.T) {
+		usecase, _ := newTestCategoryUsecase(newMockCategoryRepository())
+
+		_, err := usecase.CreateCategory(context.Background(), CreateCategoryInput{
+			Code: "リュック", NameJa: "リュック", NameEn: "Backpacks", ParentID: int64Ptr(999),
+		})
+
+		verrs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeInvalidParent, verrs[0].Code)
+	})
+
+	t.Run("異常系: 子孫のカテゴリーを親にはできない", func(t *testing.T) {
+		usecase, _ := newTestCategoryUsecase(newMockCategoryTree())
+
+		_, err := usecase.UpdateCategory(context.Background(), 2, UpdateCategoryInput{ParentID: int64Ptr(8)})
+
+		verrs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeInvalidParent, verrs[0].Code)
+	})
+
+	t.Run("正常系: 最上位に移動", func(t *testing.T) {
+		usecase, _ := newTestCategoryUsecase(newMockCategoryTree())
+
+		updated, err := usecase.UpdateCategory(context.Background(), 7, UpdateCategoryInput{ParentID: int64Ptr(0)})
+
+		require.NoError(t, err)
+		assert.Nil(t, updated.ParentID)
+	})
+
+	t.Run("異常系: 子孫のカテゴリーにアイテムがある場合は無効化できない", func(t *testing.T) {
+		repo := newMockCategoryTree()
+		repo.itemCounts["ミニクラッチ"] = 1
+		usecase, _ := newTestCategoryUsecase(repo)
+
+		_, err := usecase.UpdateCategory(context.Background(), 2, UpdateCategoryInput{Active: &inactive})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryInUse)
+	})
+
+	t.Run("正常系: 親を無効にすると子孫もアイテムに設定できなくなる", func(t *testing.T) {
+		usecase, cache := newTestCategoryUsecase(newMockCategoryTree())
+
+		_, err := usecase.UpdateCategory(context.Background(), 7, UpdateCategoryInput{Active: &inactive})
+
+		require.NoError(t, err)
+		assert.False(t, isAssignable(t, cache, "ミニクラッチ"))
+		assert.True(t, isAssignable(t, cache, "トート"))
+	})
+
+	t.Run("異常系: 子カテゴリーがある場合は削除できない", func(t *testing.T) {
+		usecase, _ := newTestCategoryUsecase(newMockCategoryTree())
+
+		err := usecase.DeleteCategory(context.Background(), 7)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryHasChildren)
+	})
+}
+
+func TestItemUsecase_CreateItemCategories(t *testing.T) {
+	input := CreateItemInput{Name: "シャツ", Category: "アパレル", Brand: "Brand", PurchasePrice: 1000, PurchaseDate: "2023-01-01"}
+
+	t.Run("正常系: カテゴリーのテーブルに追加したカテゴリーで登録できる", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		repo.categories = append(repo.categories, &entity.Category{ID: 6, Code: "アパレル", NameJa: "アパレル", NameEn: "Apparel", SortOrder: 60, Active: true})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(storedItem(), nil)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, categoryRepo: repo})
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: カテゴリーの読み込みに失敗した場合は登録しない", func(t *testing.T) {
+		repo := newMockCategoryRepository()
+		repo.err = domainErrors.ErrDatabaseError
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, categoryRepo: repo})
+
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_UpdateItemAttributes(t *testing.T) {
+	repo := newMockCategoryRepository()
+	repo.categories[0].AttributeSchema = entity.AttributeSchema{
+		{Key: "reference_number", Type: entity.AttributeString},
+		{Key: "movement", Type: entity.AttributeEnum, Options: []string{"automatic", "quartz"}},
+	}
+
+	current := func() *entity.Item {
+		item := storedItem()
+		item.Attributes = entity.Attributes{"reference_number": "116500LN", "movement": "automatic"}
+		return item
+	}
+
+	tests := []struct {
+		name    string
+		input   UpdateItemInput
+		want    entity.Attributes
+		wantErr error
+	}{
+		{
+			name:  "正常系: 送信したキーだけを更新する",
+			input: UpdateItemInput{Attributes: entity.Attributes{"movement": "quartz"}},
+			want:  entity.Attributes{"reference_number": "116500LN", "movement": "quartz"},
+		},
+		{
+			name:  "正常系: null のキーは削除する",
+			input: UpdateItemInput{Attributes: entity.Attributes{"reference_number": nil}},
+			want:  entity.Attributes{"movement": "automatic"},
+		},
+		{
+			name:  "正常系: 属性以外の更新では属性を保持する",
+			input: UpdateItemInput{PurchasePrice: intPtr(1200000)},
+			want:  entity.Attributes{"reference_number": "116500LN", "movement": "automatic"},
+		},
+		{
+			name:    "異常系: スキーマにない値",
+			input:   UpdateItemInput{Attributes: entity.Attributes{"movement": "solar"}},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 属性のないカテゴリーに変更",
+			input:   UpdateItemInput{Category: stringPtr("靴")},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, categoryRepo: repo})
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(current(), nil)
+			var saved *entity.Item
+			mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entity.Item)
+			}).Return(current(), nil).Maybe()
+
+			_, err := usecase.UpdateItem(context.Background(), 1, tt.input, nil)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, saved.Attributes)
+		})
+	}
+}
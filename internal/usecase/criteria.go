@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+const (
+	// MaxAttributeFilters は1リクエストで指定できるカスタム属性の条件の数
+	MaxAttributeFilters = 10
+	// DefaultItemLimit は limit 未指定時の取得件数
+	DefaultItemLimit = 20
+	// MaxItemLimit は1リクエストで取得できる最大件数
+	MaxItemLimit = 100
+)
+
+// ソート可能なフィールド（APIで指定できる名前）
+// リポジトリはここに含まれるフィールドのみSQLに埋め込む
+var sortableItemFields = map[string]bool{
+	"id":             true,
+	"name":           true,
+	"category":       true,
+	"brand":          true,
+	"purchase_price": true,
+	"purchase_date":  true,
+	"created_at":     true,
+	"updated_at":     true,
+	"relevance":      true, // 全文検索時のみ指定可能
+}
+
+// IsSortableItemField は field がソート可能なフィールドかを返す
+func IsSortableItemField(field string) bool {
+	return sortableItemFields[field]
+}
+
+// SortField は並び替えの1項目を表す
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ItemCriteria はアイテム一覧取得時の絞り込み・並び替え・ページング条件
+// ポインタ型・空文字のフィールドは条件なしを意味する
+type ItemCriteria struct {
+	Keyword       string   // 全文検索キーワード（名前・ブランドが対象）
+	Category      string   // 親カテゴリーを指定した場合は子孫のカテゴリーも含む
+	Categories    []string // Category と子孫のカテゴリーのコード（usecase で設定し、設定されている場合は Category より優先する）
+	Brand         string
+	MinPrice      *int
+	MaxPrice      *int
+	PurchasedFrom string            // YYYY-MM-DD（この日を含む）
+	PurchasedTo   string            // YYYY-MM-DD（この日を含む）
+	Attributes    map[string]string // カスタム属性の条件（値の文字列表現が一致するもの。真偽値は "true" / "false"）
+	TagsAny       []string          // いずれかのタグが付いているアイテムのみ
+	TagsAll       []string          // すべてのタグが付いているアイテムのみ
+	LocationID    *int64            // 保管場所（中にある保管場所に置かれたアイテムも含む）
+	LocationIDs   []int64           // LocationID と中にある保管場所のID（usecase で設定し、設定されている場合は LocationID より優先する）
+	Statuses      []string          // いずれかの所有状況のアイテムのみ
+	Sort          []SortField
+	Limit         int
+	Offset        int
+	After         *ItemCursor   // キーセットページングの開始位置（Offset とは併用不可）
+	Facets        *FacetOptions // nil でない場合は結果にファセット集計を含める
+}
+
+// ItemList はページング付きのアイテム一覧
+// Total はカーソル（キーセットページング）で取得したページでは件数を数えないため nil
+type ItemList struct {
+	Items  []*entity.Item `json:"items"`
+	Total  *int           `json:"total,omitempty"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	// NextCursor は次ページが存在し、かつキーセットページングが可能な並び順の場合のみ設定される
+	NextCursor *ItemCursor `json:"-"`
+	Facets     *ItemFacets `json:"facets,omitempty"`
+}
+
+// DefaultItemSort は sort 未指定時の並び順（作成日時の新しい順）
+func DefaultItemSort() []SortField {
+	return []SortField{{Field: "created_at", Desc: true}}
+}
+
+// ParseSortFields は "-purchase_price,name" 形式の文字列を SortField に変換する
+// 先頭に "-" が付いたフィールドは降順になる
+func ParseSortFields(s string) ([]SortField, error) {
+	var fields []SortField
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: part}
+		if strings.HasPrefix(part, "-") {
+			field = SortField{Field: strings.TrimPrefix(part, "-"), Desc: true}
+		} else if strings.HasPrefix(part, "+") {
+			field = SortField{Field: strings.TrimPrefix(part, "+")}
+		}
+
+		if !sortableItemFields[field.Field] {
+			return nil, domainErrors.NewInputError(domainErrors.CodeUnsupportedSortField, fmt.Sprintf("sort field %q is not supported", field.Field), map[string]interface{}{"field": field.Field})
+		}
+		if seen[field.Field] {
+			return nil, domainErrors.NewInputError(domainErrors.CodeDuplicateSortField, fmt.Sprintf("sort field %q is specified more than once", field.Field), map[string]interface{}{"field": field.Field})
+		}
+		seen[field.Field] = true
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Normalize は未指定項目にデフォルト値を設定し、条件の妥当性をチェックする
+func (c *ItemCriteria) Normalize() error {
+	var errs domainErrors.InputErrors
+
+	if c.Limit == 0 {
+		c.Limit = DefaultItemLimit
+	}
+	if c.Limit < 0 || c.Limit > MaxItemLimit {
+		errs.Add(domainErrors.CodeOutOfRange, fmt.Sprintf("limit must be between 1 and %d", MaxItemLimit), map[string]interface{}{"name": "limit", "min": 1, "max": MaxItemLimit})
+	}
+	if c.Offset < 0 {
+		errs.Add(domainErrors.CodeTooSmall, "offset must be 0 or greater", map[string]interface{}{"name": "offset", "min": 0})
+	}
+
+	if c.MinPrice != nil && *c.MinPrice < 0 {
+		errs.Add(domainErrors.CodeTooSmall, "min_price must be 0 or greater", map[string]interface{}{"name": "min_price", "min": 0})
+	}
+	if c.MaxPrice != nil && *c.MaxPrice < 0 {
+		errs.Add(domainErrors.CodeTooSmall, "max_price must be 0 or greater", map[string]interface{}{"name": "max_price", "min": 0})
+	}
+	if c.MinPrice != nil && c.MaxPrice != nil && *c.MinPrice > *c.MaxPrice {
+		errs.Add(domainErrors.CodeInvalidRange, "min_price must be less than or equal to max_price", map[string]interface{}{"from": "min_price", "to": "max_price"})
+	}
+
+	from, fromErr := parseCriteriaDate(c.PurchasedFrom)
+	if fromErr != nil {
+		errs.Add(domainErrors.CodeInvalidFormat, "purchased_from must be in YYYY-MM-DD format", map[string]interface{}{"name": "purchased_from", "format": "YYYY-MM-DD"})
+	}
+	to, toErr := parseCriteriaDate(c.PurchasedTo)
+	if toErr != nil {
+		errs.Add(domainErrors.CodeInvalidFormat, "purchased_to must be in YYYY-MM-DD format", map[string]interface{}{"name": "purchased_to", "format": "YYYY-MM-DD"})
+	}
+	if fromErr == nil && toErr == nil && !from.IsZero() && !to.IsZero() && from.After(to) {
+		errs.Add(domainErrors.CodeInvalidRange, "purchased_from must be on or before purchased_to", map[string]interface{}{"from": "purchased_from", "to": "purchased_to"})
+	}
+
+	for _, tags := range []*[]string{&c.TagsAny, &c.TagsAll} {
+		if len(*tags) == 0 {
+			continue
+		}
+		normalized, err := entity.NormalizeTags(*tags)
+		if err != nil {
+			errs.Add(domainErrors.CodeInvalidTagFilter, fmt.Sprintf("tag filters must be 1 to %d characters and %d tags or fewer", entity.MaxTagNameLength, entity.MaxItemTags), map[string]interface{}{"max_length": entity.MaxTagNameLength, "max": entity.MaxItemTags})
+			continue
+		}
+		*tags = normalized
+	}
+
+	if c.LocationID != nil && *c.LocationID <= 0 {
+		errs.Add(domainErrors.CodeTooSmall, "location_id must be 1 or greater", map[string]interface{}{"name": "location_id", "min": 1})
+	}
+	for _, status := range c.Statuses {
+		if !entity.ItemStatus(status).IsValid() {
+			errs.Add(domainErrors.CodeInvalidOption, fmt.Sprintf("status must be one of: %s", strings.Join(entity.ItemStatuses, ", ")), map[string]interface{}{"name": "status", "allowed": entity.ItemStatuses})
+			break
+		}
+	}
+
+	if len(c.Attributes) > MaxAttributeFilters {
+		errs.Add(domainErrors.CodeTooMany, fmt.Sprintf("attribute filters must be %d or fewer", MaxAttributeFilters), map[string]interface{}{"name": "attr.<key>", "max": MaxAttributeFilters})
+	}
+	for key := range c.Attributes {
+		if !entity.IsValidAttributeKey(key) {
+			errs.Add(domainErrors.CodeInvalidAttributeFilter, fmt.Sprintf("attribute filter %q is not a valid attribute key", key), map[string]interface{}{"key": key})
+		}
+	}
+
+	for _, field := range c.Sort {
+		if !sortableItemFields[field.Field] {
+			errs.Add(domainErrors.CodeUnsupportedSortField, fmt.Sprintf("sort field %q is not supported", field.Field), map[string]interface{}{"field": field.Field})
+		}
+		if field.Field == "relevance" && c.Keyword == "" {
+			errs.Add(domainErrors.CodeRelevanceRequiresKeyword, "sort by relevance requires a search keyword", nil)
+		}
+	}
+	if c.After != nil {
+		if err := c.After.validate(); err != nil {
+			errs.Add(domainErrors.CodeInvalidCursor, "cursor is invalid", nil)
+		} else {
+			if len(c.Sort) == 0 {
+				// sort 未指定の場合はカーソル作成時の並び順を引き継ぐ
+				c.Sort = []SortField{c.After.Sort}
+			}
+			if !IsKeysetSort(c.Sort) || c.Sort[0] != c.After.Sort {
+				errs.Add(domainErrors.CodeCursorSortMismatch, "cursor does not match the requested sort", nil)
+			} else if c.After.Filter != c.fingerprint() {
+				// 別の絞り込み条件で作成したカーソルを使うと、ページの行が欠けたり重複したりする
+				errs.Add(domainErrors.CodeCursorFilterMismatch, "cursor does not match the requested filters", nil)
+			}
+		}
+		if c.Offset != 0 {
+			errs.Add(domainErrors.CodeConflictingParams, "offset cannot be combined with cursor", map[string]interface{}{"name": "offset", "other": "cursor"})
+		}
+	}
+	if len(c.Sort) == 0 {
+		c.Sort = DefaultItemSort()
+	}
+
+	return errs.Err()
+}
+
+// fingerprint は絞り込み条件と並び順のハッシュを返す
+// 利用者が指定した条件のみを対象にし、ページング（limit / offset / cursor）とファセットは含めない
+func (c *ItemCriteria) fingerprint() string {
+	data, _ := json.Marshal(struct {
+		Keyword       string            `json:"q,omitempty"`
+		Category      string            `json:"c,omitempty"`
+		Brand         string            `json:"b,omitempty"`
+		MinPrice      *int              `json:"min,omitempty"`
+		MaxPrice      *int              `json:"max,omitempty"`
+		PurchasedFrom string            `json:"from,omitempty"`
+		PurchasedTo   string            `json:"to,omitempty"`
+		Attributes    map[string]string `json:"attr,omitempty"`
+		TagsAny       []string          `json:"any,omitempty"`
+		TagsAll       []string          `json:"all,omitempty"`
+		LocationID    *int64            `json:"loc,omitempty"`
+		Statuses      []string          `json:"st,omitempty"`
+		Sort          []SortField       `json:"sort"`
+	}{
+		Keyword:       c.Keyword,
+		Category:      c.Category,
+		Brand:         c.Brand,
+		MinPrice:      c.MinPrice,
+		MaxPrice:      c.MaxPrice,
+		PurchasedFrom: c.PurchasedFrom,
+		PurchasedTo:   c.PurchasedTo,
+		Attributes:    c.Attributes,
+		TagsAny:       sortedCopy(c.TagsAny),
+		TagsAll:       sortedCopy(c.TagsAll),
+		LocationID:    c.LocationID,
+		Statuses:      sortedCopy(c.Statuses),
+		Sort:          c.Sort,
+	})
+
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// sortedCopy は指定順に意味のない条件を比較できるよう、並べ替えたコピーを返す
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	copied := slices.Clone(values)
+	slices.Sort(copied)
+	return copied
+}
+
+func parseCriteriaDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
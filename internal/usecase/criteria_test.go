@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestIsSortableItemField(t *testing.T) {
+	assert.True(t, IsSortableItemField("purchase_price"))
+	assert.True(t, IsSortableItemField("relevance"))
+	assert.False(t, IsSortableItemField("search_text"))
+	assert.False(t, IsSortableItemField("price; DROP TABLE items"))
+}
+
+func TestParseSortFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []SortField
+		wantErr bool
+	}{
+		{
+			name:  "正常系: 昇順と降順の複数指定",
+			input: "-purchase_price,name",
+			want: []SortField{
+				{Field: "purchase_price", Desc: true},
+				{Field: "name"},
+			},
+		},
+		{
+			name:  "正常系: 空白と空要素は無視",
+			input: " +brand , ,-created_at ",
+			want: []SortField{
+				{Field: "brand"},
+				{Field: "created_at", Desc: true},
+			},
+		},
+		{
+			name:    "異常系: 未対応のフィールド",
+			input:   "price; DROP TABLE items",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: 同じフィールドの重複指定",
+			input:   "name,-name",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSortFields(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItemCriteria_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria ItemCriteria
+		wantErr  string
+	}{
+		{
+			name:     "正常系: 未指定の場合はデフォルト値",
+			criteria: ItemCriteria{},
+		},
+		{
+			name:     "正常系: 価格と購入日の範囲指定",
+			criteria: ItemCriteria{MinPrice: intPtr(0), MaxPrice: intPtr(100), PurchasedFrom: "2023-01-01", PurchasedTo: "2023-01-01"},
+		},
+		{
+			name:     "正常系: カスタム属性の条件",
+			criteria: ItemCriteria{Attributes: map[string]string{"movement": "automatic", "has_box": "true"}},
+		},
+		{
+			name:     "異常系: カスタム属性のキーが不正",
+			criteria: ItemCriteria{Attributes: map[string]string{"$.movement": "automatic"}},
+			wantErr:  `attribute filter "$.movement" is not a valid attribute key`,
+		},
+		{
+			name:     "正常系: タグの条件",
+			criteria: ItemCriteria{TagsAny: []string{"旅行", "限定品"}, TagsAll: []string{" 母からの贈り物 "}},
+		},
+		{
+			name:     "異常系: タグの条件が空",
+			criteria: ItemCriteria{TagsAll: []string{""}},
+			wantErr:  "tag filters must be 1 to 50 characters and 20 tags or fewer",
+		},
+		{
+			name:     "異常系: 保管場所のIDが0",
+			criteria: ItemCriteria{LocationID: new(int64)},
+			wantErr:  "location_id must be 1 or greater",
+		},
+		{
+			name:     "正常系: 所有状況の条件",
+			criteria: ItemCriteria{Statuses: []string{"owned", "on_loan"}},
+		},
+		{
+			name:     "異常系: 未定義の所有状況",
+			criteria: ItemCriteria{Statuses: []string{"archived"}},
+			wantErr:  "status must be one of: owned, on_loan, in_repair, consigned, sold, lost, stolen, disposed",
+		},
+		{
+			name:     "異常系: limitが上限を超える",
+			criteria: ItemCriteria{Limit: MaxItemLimit + 1},
+			wantErr:  "limit must be between 1 and 100",
+		},
+		{
+			name:     "異常系: offsetが負の値",
+			criteria: ItemCriteria{Offset: -1},
+			wantErr:  "offset must be 0 or greater",
+		},
+		{
+			name:     "異常系: 価格範囲が逆転",
+			criteria: ItemCriteria{MinPrice: intPtr(200), MaxPrice: intPtr(100)},
+			wantErr:  "min_price must be less than or equal to max_price",
+		},
+		{
+			name:     "異常系: 日付形式が不正",
+			criteria: ItemCriteria{PurchasedFrom: "2023/01/01"},
+			wantErr:  "purchased_from must be in YYYY-MM-DD format",
+		},
+		{
+			name:     "異常系: 日付範囲が逆転",
+			criteria: ItemCriteria{PurchasedFrom: "2023-02-01", PurchasedTo: "2023-01-01"},
+			wantErr:  "purchased_from must be on or before purchased_to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := tt.criteria
+			err := criteria.Normalize()
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Greater(t, criteria.Limit, 0)
+			assert.NotEmpty(t, criteria.Sort)
+		})
+	}
+}
+
+func TestItemCriteria_Fingerprint(t *testing.T) {
+	base := ItemCriteria{Category: "時計", TagsAny: []string{"旅行", "限定品"}, Sort: DefaultItemSort()}
+
+	t.Run("正常系: ページングと指定順に関係なく同じ条件は同じハッシュ", func(t *testing.T) {
+		other := base
+		other.TagsAny = []string{"限定品", "旅行"}
+		other.Limit = 50
+		other.After = &ItemCursor{Sort: SortField{Field: "id"}, Value: "1", ID: 1}
+
+		assert.Equal(t, base.fingerprint(), other.fingerprint())
+	})
+
+	changes := []struct {
+		name   string
+		modify func(c *ItemCriteria)
+	}{
+		{name: "正常系: 絞り込み条件が異なる", modify: func(c *ItemCriteria) { c.Brand = "ROLEX" }},
+		{name: "正常系: 並び順が異なる", modify: func(c *ItemCriteria) { c.Sort = []SortField{{Field: "created_at"}} }},
+	}
+
+	for _, tt := range changes {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			tt.modify(&other)
+
+			assert.NotEqual(t, base.fingerprint(), other.fingerprint())
+		})
+	}
+}
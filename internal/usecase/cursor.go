@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// キーセットページングに使えるソートフィールド
+// いずれもインデックス（idx_created_at, idx_purchase_date, PRIMARY）が効く列に限定する
+var keysetSortFields = map[string]bool{
+	"created_at":    true,
+	"purchase_date": true,
+	"id":            true,
+}
+
+// ItemCursor はキーセットページングの位置（直前のページの最後の行）を表す
+// Value はソートキーの値を文字列化したもの（created_at は RFC3339Nano、purchase_date は YYYY-MM-DD）
+// Filter はカーソルを作成したときの絞り込み条件と並び順のハッシュ（ItemCriteria.fingerprint）
+type ItemCursor struct {
+	Sort   SortField
+	Value  string
+	ID     int64
+	Filter string
+}
+
+// IsKeysetSort はソート条件がキーセットページングに対応しているかを返す
+func IsKeysetSort(sort []SortField) bool {
+	return len(sort) == 1 && keysetSortFields[sort[0].Field]
+}
+
+// newItemCursor はアイテムの値から次ページ用のカーソルを作成する
+func newItemCursor(criteria ItemCriteria, item *entity.Item) *ItemCursor {
+	sort := criteria.Sort[0]
+	cursor := &ItemCursor{Sort: sort, ID: item.ID, Filter: criteria.fingerprint()}
+
+	switch sort.Field {
+	case "created_at":
+		cursor.Value = item.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "purchase_date":
+		cursor.Value = item.PurchaseDate
+	case "id":
+		cursor.Value = strconv.FormatInt(item.ID, 10)
+	}
+
+	return cursor
+}
+
+// validate はカーソルの値がソートフィールドに合った形式かをチェックする
+func (c *ItemCursor) validate() error {
+	if !keysetSortFields[c.Sort.Field] || c.ID <= 0 {
+		return domainErrors.NewInputError(domainErrors.CodeInvalidCursor, "invalid cursor", nil)
+	}
+
+	var err error
+	switch c.Sort.Field {
+	case "created_at":
+		_, err = time.Parse(time.RFC3339Nano, c.Value)
+	case "purchase_date":
+		_, err = time.Parse("2006-01-02", c.Value)
+	case "id":
+		_, err = strconv.ParseInt(c.Value, 10, 64)
+	}
+	if err != nil {
+		return domainErrors.NewInputError(domainErrors.CodeInvalidCursor, "invalid cursor", nil)
+	}
+
+	return nil
+}
+
+// CursorCodec はカーソルを改ざん検知付きの不透明なトークンに変換する
+// トークンは base64url(JSON) + "." + base64url(HMAC-SHA256) の形式
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+// トークンに埋め込むペイロード（キー名はトークン長を抑えるため短くしている）
+type cursorPayload struct {
+	Field  string `json:"f"`
+	Desc   bool   `json:"d"`
+	Value  string `json:"v"`
+	ID     int64  `json:"i"`
+	Filter string `json:"h"`
+}
+
+// Encode はカーソルをトークン文字列に変換する
+func (c *CursorCodec) Encode(cursor *ItemCursor) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Field:  cursor.Sort.Field,
+		Desc:   cursor.Sort.Desc,
+		Value:  cursor.Value,
+		ID:     cursor.ID,
+		Filter: cursor.Filter,
+	})
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + base64.RawURLEncoding.EncodeToString(c.sign(body))
+}
+
+// Decode はトークン文字列を検証してカーソルに戻す
+// 署名が一致しない・形式が不正な場合は ErrInvalidInput を返す
+func (c *CursorCodec) Decode(token string) (*ItemCursor, error) {
+	invalid := domainErrors.NewInputError(domainErrors.CodeInvalidCursor, "invalid cursor", nil)
+
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, invalid
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, c.sign(body)) {
+		return nil, invalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, invalid
+	}
+
+	cursor := &ItemCursor{
+		Sort:   SortField{Field: payload.Field, Desc: payload.Desc},
+		Value:  payload.Value,
+		ID:     payload.ID,
+		Filter: payload.Filter,
+	}
+	if err := cursor.validate(); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(body string) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write([]byte(body))
+	return h.Sum(nil)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestCursorCodec_EncodeDecode(t *testing.T) {
+	codec := NewCursorCodec([]byte("test-secret"))
+
+	cursors := []*ItemCursor{
+		{Sort: SortField{Field: "created_at", Desc: true}, Value: "2023-01-15T10:00:00Z", ID: 42},
+		{Sort: SortField{Field: "purchase_date"}, Value: "2023-01-15", ID: 7},
+		{Sort: SortField{Field: "id", Desc: true}, Value: "99", ID: 99, Filter: "e3b0c44298fc1c149afbf4c8"},
+	}
+
+	for _, cursor := range cursors {
+		t.Run(cursor.Sort.Field, func(t *testing.T) {
+			token := codec.Encode(cursor)
+
+			decoded, err := codec.Decode(token)
+			require.NoError(t, err)
+			assert.Equal(t, cursor, decoded)
+		})
+	}
+}
+
+func TestCursorCodec_DecodeInvalid(t *testing.T) {
+	codec := NewCursorCodec([]byte("test-secret"))
+	valid := codec.Encode(&ItemCursor{Sort: SortField{Field: "id"}, Value: "1", ID: 1})
+	body, sig, _ := strings.Cut(valid, ".")
+
+	// 別の鍵で署名されたトークン
+	otherCodec := NewCursorCodec([]byte("other-secret"))
+	forged := otherCodec.Encode(&ItemCursor{Sort: SortField{Field: "id"}, Value: "1", ID: 1})
+
+	// 署名は正しいが並び替えに使えないフィールドを含むトークン
+	unsupported := codec.Encode(&ItemCursor{Sort: SortField{Field: "name"}, Value: "x", ID: 1})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"区切り文字なし", body},
+		{"本文の改ざん", body + "x." + sig},
+		{"署名の改ざん", body + "." + sig + "x"},
+		{"別の鍵で署名", forged},
+		{"未対応のソートフィールド", unsupported},
+		{"空文字", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := codec.Decode(tt.token)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.Nil(t, cursor)
+		})
+	}
+}
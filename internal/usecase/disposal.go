@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// SellItemInput はアイテムの売却の入力
+type SellItemInput struct {
+	SoldOn    string `json:"sold_on"` // YYYY-MM-DD 形式
+	SalePrice int    `json:"sale_price"`
+	Fees      int    `json:"fees"`
+	Channel   string `json:"channel"`
+	Note      string `json:"note"` // 省略した場合は所有状況の変更理由に売却先を記録する
+}
+
+// ItemSale は売却後のアイテムと売却の記録
+type ItemSale struct {
+	Item *entity.Item     `json:"item"`
+	Sale *entity.Disposal `json:"sale"`
+}
+
+// RealizedGainTotal は売却した年・カテゴリーごとの件数と金額の合計
+type RealizedGainTotal struct {
+	Year      int
+	Category  string
+	Count     int
+	SalePrice int
+	Fees      int
+	Cost      int
+}
+
+// RealizedGain は売却損益の合計
+type RealizedGain struct {
+	Count     int `json:"count"`
+	SalePrice int `json:"sale_price"`
+	Fees      int `json:"fees"`
+	Cost      int `json:"cost"`
+	GainLoss  int `json:"gain_loss"` // 売却価格 - 費用 - 取得費
+}
+
+func (g *RealizedGain) add(total RealizedGainTotal) {
+	g.Count += total.Count
+	g.SalePrice += total.SalePrice
+	g.Fees += total.Fees
+	g.Cost += total.Cost
+	g.GainLoss += total.SalePrice - total.Fees - total.Cost
+}
+
+// RealizedGainCategory は1年・1カテゴリー分の売却損益
+type RealizedGainCategory struct {
+	Category string `json:"category"`
+	RealizedGain
+}
+
+// RealizedGainYear は1年分の売却損益とカテゴリーごとの内訳
+type RealizedGainYear struct {
+	Year int `json:"year"`
+	RealizedGain
+	Categories []*RealizedGainCategory `json:"categories"`
+}
+
+// RealizedGainReport は年・カテゴリーごとの売却損益
+type RealizedGainReport struct {
+	Years []*RealizedGainYear `json:"years"`
+	Total RealizedGain        `json:"total"`
+}
+
+// SellItem はアイテムの売却を記録し、所有状況を sold に変更する
+// アイテムは削除せず、現在の所有資産から外れる。所有状況の変更の記録も残す
+func (u *itemUsecase) SellItem(ctx context.Context, id int64, input SellItemInput, expectedVersion *int) (*ItemSale, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var sale *ItemSale
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && item.Version != *expectedVersion {
+			return domainErrors.ErrVersionConflict
+		}
+
+		disposal, err := entity.NewDisposal(item, input.SoldOn, input.SalePrice, input.Fees, input.Channel, input.Note)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		changedAt, err := u.soldAt(ctx, id, disposal.SoldOn)
+		if err != nil {
+			return err
+		}
+		reason := disposal.Note
+		if reason == "" {
+			reason = disposal.Channel + "で売却"
+		}
+		event, err := item.Sell(reason, changedAt)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		soldItem, err := u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		actor := ActorFromContext(ctx)
+		event.Actor = actor
+		if err := u.statusRepo.Create(ctx, event); err != nil {
+			return err
+		}
+		disposal.Actor = actor
+		if err := u.disposalRepo.Create(ctx, disposal); err != nil {
+			return err
+		}
+
+		sale = &ItemSale{Item: soldItem, Sale: disposal}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsConflictError(err) {
+			return nil, domainErrors.ErrVersionConflict
+		}
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to sell item: %w", err)
+	}
+
+	return sale, nil
+}
+
+// soldAt は売却日を所有状況の変更日時にする
+// 売却日に別の変更がある場合（委託販売に出した当日に売れたなど）は、その変更と同じ日時にする
+func (u *itemUsecase) soldAt(ctx context.Context, itemID int64, soldOn string) (time.Time, error) {
+	changedAt, err := time.ParseInLocation("2006-01-02", soldOn, time.Local)
+	if err != nil {
+		return time.Time{}, domainErrors.NewInputError(domainErrors.CodeInvalidFormat, "sold_on must be in YYYY-MM-DD format", map[string]interface{}{"name": "sold_on", "format": "YYYY-MM-DD"})
+	}
+
+	events, err := u.statusRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(events) == 0 || !changedAt.Before(events[0].ChangedAt) {
+		return changedAt, nil
+	}
+
+	latest := events[0].ChangedAt
+	if latest.Before(changedAt.AddDate(0, 0, 1)) {
+		return latest, nil
+	}
+	var errs domainErrors.ValidationErrors
+	errs.Add("sold_on", domainErrors.CodeTooSmall, "sold_on must not be before the previous status change",
+		map[string]interface{}{"min": latest.Format("2006-01-02")})
+	return time.Time{}, errs.Err()
+}
+
+// GetItemDisposal はアイテムの売却の記録を返す
+func (u *itemUsecase) GetItemDisposal(ctx context.Context, id int64) (*entity.Disposal, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	disposal, err := u.disposalRepo.FindByItemID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domainErrors.ErrDisposalNotFound) {
+			return nil, fmt.Errorf("failed to retrieve disposal: %w", err)
+		}
+		// 記録がない場合は、売却していないアイテムかどうかを確認する
+		if _, err := u.GetItemByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.ErrDisposalNotFound
+	}
+
+	return disposal, nil
+}
+
+// GetRealizedGains は売却損益を売却した年・アイテムのカテゴリーごとに集計する
+// 取得費はアイテムの購入価格で、年・カテゴリーの順に並ぶ
+func (u *itemUsecase) GetRealizedGains(ctx context.Context, year *int) (*RealizedGainReport, error) {
+	if year != nil && (*year < 1 || *year > 9999) {
+		return nil, domainErrors.NewInputError(domainErrors.CodeOutOfRange, "year must be between 1 and 9999", map[string]interface{}{"name": "year", "min": 1, "max": 9999})
+	}
+
+	totals, err := u.disposalRepo.SumByYearAndCategory(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get realized gains: %w", err)
+	}
+
+	report := &RealizedGainReport{Years: []*RealizedGainYear{}}
+	var current *RealizedGainYear
+	for _, total := range totals {
+		if current == nil || current.Year != total.Year {
+			current = &RealizedGainYear{Year: total.Year, Categories: []*RealizedGainCategory{}}
+			report.Years = append(report.Years, current)
+		}
+		category := &RealizedGainCategory{Category: total.Category}
+		category.add(total)
+		current.Categories = append(current.Categories, category)
+		current.add(total)
+		report.Total.add(total)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// MockDisposalRepository は売却の記録をメモリに保持するモック
+// totals には SumByYearAndCategory が返す集計、sold には FindByYear が返す記録を設定する
+type MockDisposalRepository struct {
+	disposals []*entity.Disposal
+	totals    []RealizedGainTotal
+	sold      []*DisposedItem
+	year      *int // SumByYearAndCategory に渡された year
+}
+
+func newMockDisposalRepository() *MockDisposalRepository {
+	return &MockDisposalRepository{}
+}
+
+func (m *MockDisposalRepository) Create(ctx context.Context, disposal *entity.Disposal) error {
+	disposal.ID = int64(len(m.disposals) + 1)
+	disposal.CreatedAt = time.Now()
+	m.disposals = append(m.disposals, disposal)
+	return nil
+}
+
+func (m *MockDisposalRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.Disposal, error) {
+	for _, d := range m.disposals {
+		if d.ItemID == itemID {
+			copied := *d
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrDisposalNotFound
+}
+
+func (m *MockDisposalRepository) SumByYearAndCategory(ctx context.Context, year *int) ([]RealizedGainTotal, error) {
+	m.year = year
+	return m.totals, nil
+}
+
+// FindByYear は sold のうち year に売却したものを返す
+func (m *MockDisposalRepository) FindByYear(ctx context.Context, year int) ([]*DisposedItem, error) {
+	var items []*DisposedItem
+	prefix := fmt.Sprintf("%04d-", year)
+	for _, item := range m.sold {
+		if strings.HasPrefix(item.Disposal.SoldOn, prefix) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func TestItemUsecase_SellItem(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	tests := []struct {
+		name      string
+		status    entity.ItemStatus
+		input     SellItemInput
+		wantErr   error
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:   "正常系: 委託販売中のアイテムを売却",
+			status: entity.StatusConsigned,
+			input:  SellItemInput{SoldOn: yesterday, SalePrice: 1200000, Fees: 120000, Channel: " 買取店 "},
+		},
+		{
+			name:      "異常系: 貸し出し中のアイテムは売却できない",
+			status:    entity.StatusOnLoan,
+			input:     SellItemInput{SoldOn: yesterday, SalePrice: 1200000, Channel: "買取店"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidTransition,
+			wantField: "status",
+		},
+		{
+			name:      "異常系: 売却価格がない",
+			status:    entity.StatusOwned,
+			input:     SellItemInput{SoldOn: yesterday, Channel: "買取店"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeTooSmall,
+			wantField: "sale_price",
+		},
+		{
+			name:      "異常系: 直前の所有状況の変更より前の売却日",
+			status:    entity.StatusConsigned,
+			input:     SellItemInput{SoldOn: time.Now().AddDate(0, 0, -10).Format("2006-01-02"), SalePrice: 1200000, Channel: "買取店"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeTooSmall,
+			wantField: "sold_on",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			statusRepo := newMockItemStatusEventRepository()
+			statusRepo.events = []*entity.ItemStatusEvent{
+				{ID: 1, ItemID: 1, FromStatus: entity.StatusOwned, ToStatus: tt.status, Reason: "委託", ChangedAt: time.Now().AddDate(0, 0, -7)},
+			}
+			disposalRepo := newMockDisposalRepository()
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, statusRepo: statusRepo, disposalRepo: disposalRepo})
+
+			item := storedItem()
+			item.Status = tt.status
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			var saved *entity.Item
+			mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entity.Item)
+			}).Return(storedItem(), nil).Maybe()
+
+			ctx := WithActor(context.Background(), "alice")
+			sale, err := usecase.SellItem(ctx, 1, tt.input, nil)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var verrs domainErrors.ValidationErrors
+				require.ErrorAs(t, err, &verrs)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				assert.Empty(t, disposalRepo.disposals)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entity.StatusSold, saved.Status)
+
+			assert.Equal(t, "買取店", sale.Sale.Channel)
+			assert.Equal(t, 1000000, sale.Sale.Cost)
+			assert.Equal(t, 80000, sale.Sale.GainLoss)
+			assert.Equal(t, "alice", sale.Sale.Actor)
+			require.Len(t, disposalRepo.disposals, 1)
+
+			require.Len(t, statusRepo.events, 2)
+			event := statusRepo.events[1]
+			assert.Equal(t, entity.StatusConsigned, event.FromStatus)
+			assert.Equal(t, entity.StatusSold, event.ToStatus)
+			assert.Equal(t, "買取店で売却", event.Reason)
+			assert.Equal(t, yesterday, event.ChangedAt.Format("2006-01-02"))
+		})
+	}
+
+	t.Run("正常系: 売却日に別の変更がある場合はその日時で記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		consignedAt := time.Now().Add(-time.Minute)
+		statusRepo := newMockItemStatusEventRepository()
+		statusRepo.events = []*entity.ItemStatusEvent{
+			{ID: 1, ItemID: 1, FromStatus: entity.StatusOwned, ToStatus: entity.StatusConsigned, Reason: "委託", ChangedAt: consignedAt},
+		}
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, statusRepo: statusRepo})
+		item := storedItem()
+		item.Status = entity.StatusConsigned
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(storedItem(), nil)
+
+		input := SellItemInput{SoldOn: consignedAt.Format("2006-01-02"), SalePrice: 900000, Channel: "メルカリ", Note: "委託した当日に売れた"}
+		_, err := usecase.SellItem(context.Background(), 1, input, nil)
+
+		require.NoError(t, err)
+		require.Len(t, statusRepo.events, 2)
+		assert.True(t, statusRepo.events[1].ChangedAt.Equal(consignedAt))
+		assert.Equal(t, "委託した当日に売れた", statusRepo.events[1].Reason)
+	})
+
+	t.Run("異常系: バージョンが一致しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		_, err := usecase.SellItem(context.Background(), 1, SellItemInput{SoldOn: yesterday, SalePrice: 1, Channel: "買取店"}, intPtr(2))
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+}
+
+func TestItemUsecase_GetItemDisposal(t *testing.T) {
+	t.Run("正常系: 売却の記録を返す", func(t *testing.T) {
+		disposalRepo := newMockDisposalRepository()
+		disposalRepo.disposals = []*entity.Disposal{{ID: 1, ItemID: 1, SoldOn: "2024-05-01", SalePrice: 500000, Channel: "買取店"}}
+		usecase := newTestItemUsecase(t, testItemDeps{disposalRepo: disposalRepo})
+
+		disposal, err := usecase.GetItemDisposal(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-05-01", disposal.SoldOn)
+	})
+
+	t.Run("異常系: 売却していないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		_, err := usecase.GetItemDisposal(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDisposalNotFound)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := usecase.GetItemDisposal(context.Background(), 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemUsecase_GetRealizedGains(t *testing.T) {
+	t.Run("正常系: 年ごとにカテゴリーの内訳と合計を集計する", func(t *testing.T) {
+		disposalRepo := newMockDisposalRepository()
+		disposalRepo.totals = []RealizedGainTotal{
+			{Year: 2023, Category: "時計", Count: 1, SalePrice: 1500000, Fees: 50000, Cost: 1000000},
+			{Year: 2024, Category: "トート", Count: 2, SalePrice: 300000, Fees: 30000, Cost: 400000},
+			{Year: 2024, Category: "時計", Count: 1, SalePrice: 800000, Fees: 0, Cost: 600000},
+		}
+		usecase := newTestItemUsecase(t, testItemDeps{disposalRepo: disposalRepo})
+
+		report, err := usecase.GetRealizedGains(context.Background(), nil)
+
+		require.NoError(t, err)
+		require.Len(t, report.Years, 2)
+		assert.Equal(t, 2023, report.Years[0].Year)
+		assert.Equal(t, 450000, report.Years[0].GainLoss)
+
+		year2024 := report.Years[1]
+		assert.Equal(t, 3, year2024.Count)
+		assert.Equal(t, 70000, year2024.GainLoss)
+		require.Len(t, year2024.Categories, 2)
+		assert.Equal(t, "トート", year2024.Categories[0].Category)
+		assert.Equal(t, -130000, year2024.Categories[0].GainLoss)
+
+		assert.Equal(t, 4, report.Total.Count)
+		assert.Equal(t, 520000, report.Total.GainLoss)
+	})
+
+	t.Run("正常系: 売却がない場合は空", func(t *testing.T) {
+		disposalRepo := newMockDisposalRepository()
+		usecase := newTestItemUsecase(t, testItemDeps{disposalRepo: disposalRepo})
+		year := 2024
+
+		report, err := usecase.GetRealizedGains(context.Background(), &year)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Years)
+		assert.Equal(t, intPtr(2024), disposalRepo.year)
+	})
+
+	t.Run("異常系: 年が範囲外", func(t *testing.T) {
+		usecase := newTestItemUsecase(t, testItemDeps{})
+
+		_, err := usecase.GetRealizedGains(context.Background(), intPtr(0))
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
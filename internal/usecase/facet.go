@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// FacetField は件数を集計するフィールド
+type FacetField string
+
+const (
+	FacetCategory FacetField = "category"
+	FacetBrand    FacetField = "brand"
+)
+
+// ブランドは種類が多くなりやすいため、件数の多い順に上位のみ返す
+const brandFacetLimit = 20
+
+// DefaultPriceBoundaries は価格帯ファセットのデフォルトの区切り（円）
+// 0–10万, 10万–100万, 100万–500万, 500万以上 の4区間になる
+var DefaultPriceBoundaries = []int{0, 100000, 1000000, 5000000}
+
+// FacetCount はファセットの値ごとの件数
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PriceBucket は価格帯（Min 以上 Max 未満、Max が nil の場合は上限なし）
+type PriceBucket struct {
+	Min int  `json:"min"`
+	Max *int `json:"max,omitempty"`
+}
+
+// PriceBucketCount は価格帯ごとの件数
+type PriceBucketCount struct {
+	PriceBucket
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ItemFacets は絞り込み用サイドバーに表示する集計結果
+type ItemFacets struct {
+	Categories  []FacetCount       `json:"categories"`
+	Brands      []FacetCount       `json:"brands"`
+	PriceRanges []PriceBucketCount `json:"price_ranges"`
+}
+
+// FacetOptions はファセット集計の条件
+type FacetOptions struct {
+	PriceBuckets []PriceBucket
+}
+
+// NewPriceBuckets は昇順の区切り値から価格帯を作成する
+// 例: [0, 100000] → [0, 100000), [100000, ∞)
+func NewPriceBuckets(boundaries []int) ([]PriceBucket, error) {
+	if len(boundaries) == 0 {
+		return nil, fmt.Errorf("%w: price buckets must not be empty", domainErrors.ErrInvalidInput)
+	}
+
+	buckets := make([]PriceBucket, 0, len(boundaries))
+	for i, min := range boundaries {
+		if min < 0 {
+			return nil, fmt.Errorf("%w: price bucket boundaries must be 0 or greater", domainErrors.ErrInvalidInput)
+		}
+		bucket := PriceBucket{Min: min}
+		if i+1 < len(boundaries) {
+			max := boundaries[i+1]
+			if max <= min {
+				return nil, fmt.Errorf("%w: price bucket boundaries must be in ascending order", domainErrors.ErrInvalidInput)
+			}
+			bucket.Max = &max
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// ParsePriceBuckets は "0,100000,1000000" 形式の文字列から価格帯を作成する
+func ParsePriceBuckets(s string) ([]PriceBucket, error) {
+	var boundaries []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: price bucket boundary %q is not an integer", domainErrors.ErrInvalidInput, part)
+		}
+		boundaries = append(boundaries, v)
+	}
+
+	return NewPriceBuckets(boundaries)
+}
+
+// Label は "¥1M–5M" 形式の表示用ラベルを返す
+func (b PriceBucket) Label() string {
+	if b.Max == nil {
+		return formatYen(b.Min) + "+"
+	}
+	return formatYen(b.Min) + "–" + strings.TrimPrefix(formatYen(*b.Max), "¥")
+}
+
+// formatYen は金額を K（千）/ M（百万）単位の短い表記にする
+func formatYen(v int) string {
+	switch {
+	case v >= 1000000 && v%100000 == 0:
+		return "¥" + strconv.FormatFloat(float64(v)/1000000, 'f', -1, 64) + "M"
+	case v >= 1000 && v%100 == 0:
+		return "¥" + strconv.FormatFloat(float64(v)/1000, 'f', -1, 64) + "K"
+	default:
+		return "¥" + strconv.Itoa(v)
+	}
+}
+
+// computeFacets は現在の絞り込み条件のもとでカテゴリー・ブランド・価格帯ごとの件数を集計する
+func (u *itemUsecase) computeFacets(ctx context.Context, criteria ItemCriteria, options FacetOptions) (*ItemFacets, error) {
+	categories, err := u.itemRepo.CountByField(ctx, criteria, FacetCategory, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	brands, err := u.itemRepo.CountByField(ctx, criteria, FacetBrand, brandFacetLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count brands: %w", err)
+	}
+
+	buckets := options.PriceBuckets
+	if len(buckets) == 0 {
+		buckets, _ = NewPriceBuckets(DefaultPriceBoundaries)
+	}
+	counts, err := u.itemRepo.CountByPriceBuckets(ctx, criteria, buckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count price ranges: %w", err)
+	}
+
+	priceRanges := make([]PriceBucketCount, len(buckets))
+	for i, bucket := range buckets {
+		priceRanges[i] = PriceBucketCount{PriceBucket: bucket, Label: bucket.Label()}
+		if i < len(counts) {
+			priceRanges[i].Count = counts[i]
+		}
+	}
+
+	if categories == nil {
+		categories = []FacetCount{}
+	}
+	if brands == nil {
+		brands = []FacetCount{}
+	}
+
+	return &ItemFacets{
+		Categories:  categories,
+		Brands:      brands,
+		PriceRanges: priceRanges,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestParsePriceBuckets(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLabels []string
+		wantErr    bool
+	}{
+		{
+			name:       "正常系: デフォルトの区切り",
+			input:      "0,100000,1000000,5000000",
+			wantLabels: []string{"¥0–100K", "¥100K–1M", "¥1M–5M", "¥5M+"},
+		},
+		{
+			name:       "正常系: 端数のある区切り",
+			input:      "0, 1500000, 2500000",
+			wantLabels: []string{"¥0–1.5M", "¥1.5M–2.5M", "¥2.5M+"},
+		},
+		{
+			name:    "異常系: 昇順でない",
+			input:   "0,100,50",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: 数値でない",
+			input:   "0,abc",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: 空",
+			input:   " , ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, err := ParsePriceBuckets(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				return
+			}
+
+			require.NoError(t, err)
+			var labels []string
+			for _, bucket := range buckets {
+				labels = append(labels, bucket.Label())
+			}
+			assert.Equal(t, tt.wantLabels, labels)
+			assert.Nil(t, buckets[len(buckets)-1].Max)
+		})
+	}
+}
+
+func TestItemUsecase_GetAllItems_Facets(t *testing.T) {
+	buckets, err := NewPriceBuckets([]int{0, 1000000})
+	require.NoError(t, err)
+
+	mockRepo := new(MockItemRepository)
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1500000, "2023-01-01", nil, entity.FixedCategories)
+
+	// ファセットは一覧と同じ絞り込み条件で集計される
+	filtered := mock.MatchedBy(func(c ItemCriteria) bool { return c.Category == "時計" })
+	mockRepo.On("Count", mock.Anything, filtered).Return(1, nil)
+	mockRepo.On("FindAll", mock.Anything, filtered).Return([]*entity.Item{item}, nil)
+	mockRepo.On("CountByField", mock.Anything, filtered, FacetCategory, 0).Return([]FacetCount{{Value: "時計", Count: 1}}, nil)
+	mockRepo.On("CountByField", mock.Anything, filtered, FacetBrand, brandFacetLimit).Return([]FacetCount{{Value: "ROLEX", Count: 1}}, nil)
+	mockRepo.On("CountByPriceBuckets", mock.Anything, filtered, buckets).Return([]int{0, 1}, nil)
+
+	usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+	list, err := usecase.GetAllItems(context.Background(), ItemCriteria{
+		Category: "時計",
+		Facets:   &FacetOptions{PriceBuckets: buckets},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, list.Facets)
+	assert.Equal(t, []FacetCount{{Value: "時計", Count: 1}}, list.Facets.Categories)
+	assert.Equal(t, []FacetCount{{Value: "ROLEX", Count: 1}}, list.Facets.Brands)
+	require.Len(t, list.Facets.PriceRanges, 2)
+	assert.Equal(t, "¥0–1M", list.Facets.PriceRanges[0].Label)
+	assert.Equal(t, 0, list.Facets.PriceRanges[0].Count)
+	assert.Equal(t, "¥1M+", list.Facets.PriceRanges[1].Label)
+	assert.Equal(t, 1, list.Facets.PriceRanges[1].Count)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// 1回のインポートで受け付ける最大行数（ヘッダー行を除く）
+const MaxImportRows = 10000
+
+// CSVの文字コード
+const (
+	ImportEncodingAuto     = "auto" // UTF-8 として正しくなければ Shift_JIS とみなす
+	ImportEncodingUTF8     = "utf-8"
+	ImportEncodingShiftJIS = "shift_jis"
+)
+
+// インポート結果の行ごとの状態
+const (
+	ImportRowCreated = "created"
+	ImportRowSkipped = "skipped"
+	ImportRowFailed  = "failed"
+)
+
+// ImportColumnMapping はアイテムのフィールド名とCSVのヘッダー名の対応
+// 例: {"name": "品名", "purchase_price": "購入価格"}（指定しないフィールドはフィールド名と同じヘッダーを使う）
+type ImportColumnMapping map[string]string
+
+// importFields はインポートで読み取るフィールド（すべて必須）
+var importFields = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+// optionalImportFields はCSVに列がなくてもよいフィールド
+// attributes / tags はエクスポートと同じくJSONで書く（例: {"movement":"automatic"}、["限定品","旅行"]）
+var optionalImportFields = []string{"attributes", "tags"}
+
+// ImportItemsInput はCSVインポートの入力
+type ImportItemsInput struct {
+	CSV      io.Reader
+	Encoding string // ImportEncodingAuto（デフォルト）/ ImportEncodingUTF8 / ImportEncodingShiftJIS
+	Mapping  ImportColumnMapping
+	DryRun   bool // true の場合は検証のみ行い、登録しない
+}
+
+// ImportRowResult はCSVの1行分の結果
+type ImportRowResult struct {
+	Line   int      `json:"line"` // CSVの行番号（ヘッダー行が1）
+	Status string   `json:"status"`
+	ItemID *int64   `json:"item_id,omitempty"`
+	Errors []string `json:"errors,omitempty"` // failed / skipped の理由
+}
+
+// ImportReport はCSVインポートの結果
+// Committed が false の場合（dry_run または失敗行がある場合）は何も登録されておらず、
+// Created は登録可能だった行数を表す
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Committed bool              `json:"committed"`
+	Total     int               `json:"total"`
+	Created   int               `json:"created"`
+	Skipped   int               `json:"skipped"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// ImportItems はCSVの各行を entity.NewItem で検証し、すべての行が正しい場合のみ1トランザクションで登録する
+// 空行と、同じファイル内で重複する行はスキップする
+func (u *itemUsecase) ImportItems(ctx context.Context, input ImportItemsInput) (*ImportReport, error) {
+	reader, err := decodeImportCSV(input.CSV, input.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, domainErrors.NewInputError(domainErrors.CodeEmptyCSV, "csv is empty", nil)
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, invalidCSV(parseErr)
+		}
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	columns, err := resolveImportColumns(header, input.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := u.assignableCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: input.DryRun, Rows: []ImportRowResult{}}
+	var items []*entity.Item
+	var itemRows []int
+	seen := make(map[string]int)
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// 読み込みに失敗した場合 FieldPos は使えないため、行番号は csv.ParseError から取得する
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, invalidCSV(parseErr)
+			}
+			// CSVの形式ではなくボディの読み込みのエラー（サイズの上限を超えたなど）は呼び出し側で判別できるようにそのまま返す
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		if report.Total >= MaxImportRows {
+			return nil, domainErrors.NewInputError(domainErrors.CodeTooManyRows, fmt.Sprintf("csv must contain %d rows or fewer", MaxImportRows), map[string]interface{}{"max": MaxImportRows})
+		}
+		report.Total++
+
+		values := make(map[string]string, len(columns))
+		blank := true
+		for field, index := range columns {
+			if index < len(record) {
+				values[field] = strings.TrimSpace(record[index])
+			}
+			if values[field] != "" {
+				blank = false
+			}
+		}
+
+		row := ImportRowResult{Line: line}
+		if blank {
+			row.Status = ImportRowSkipped
+			row.Errors = []string{"empty row"}
+			report.Rows = append(report.Rows, row)
+			report.Skipped++
+			continue
+		}
+
+		item, errs := parseImportRow(values, categories)
+		if len(errs) > 0 {
+			row.Status = ImportRowFailed
+			row.Errors = errs
+			report.Rows = append(report.Rows, row)
+			report.Failed++
+			continue
+		}
+
+		key := importDuplicateKey(item)
+		if first, ok := seen[key]; ok {
+			row.Status = ImportRowSkipped
+			row.Errors = []string{fmt.Sprintf("duplicate of line %d", first)}
+			report.Rows = append(report.Rows, row)
+			report.Skipped++
+			continue
+		}
+		seen[key] = line
+
+		row.Status = ImportRowCreated
+		report.Rows = append(report.Rows, row)
+		report.Created++
+		items = append(items, item)
+		itemRows = append(itemRows, len(report.Rows)-1)
+	}
+
+	// 検証のみ、または1行でも失敗した場合は何も登録しない
+	if input.DryRun || report.Failed > 0 {
+		return report, nil
+	}
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			created, err := u.createItem(ctx, item)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", report.Rows[itemRows[i]].Line, err)
+			}
+			if err := u.recordRevision(ctx, entity.RevisionCreate, nil, created); err != nil {
+				return err
+			}
+			id := created.ID
+			report.Rows[itemRows[i]].ItemID = &id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import items: %w", err)
+	}
+
+	report.Committed = true
+	return report, nil
+}
+
+// decodeImportCSV は指定された文字コードのCSVを UTF-8 として読めるようにする
+// Excel が出力する UTF-8 の BOM は取り除く
+func decodeImportCSV(r io.Reader, encoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", ImportEncodingAuto:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+		if utf8.Valid(data) {
+			return bytes.NewReader(data), nil
+		}
+		return transform.NewReader(bytes.NewReader(data), japanese.ShiftJIS.NewDecoder()), nil
+	case ImportEncodingUTF8, "utf8":
+		br := bufio.NewReader(r)
+		if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+			br.Discard(3)
+		}
+		return br, nil
+	case ImportEncodingShiftJIS, "sjis", "cp932":
+		return transform.NewReader(r, japanese.ShiftJIS.NewDecoder()), nil
+	default:
+		return nil, domainErrors.NewInputError(domainErrors.CodeInvalidOption, "unsupported encoding: "+encoding, map[string]interface{}{"name": "encoding", "allowed": []string{ImportEncodingAuto, ImportEncodingUTF8, ImportEncodingShiftJIS}})
+	}
+}
+
+// invalidCSV はCSVの形式のエラーを行番号付きの入力エラーにする
+func invalidCSV(parseErr *csv.ParseError) error {
+	return domainErrors.NewInputError(
+		domainErrors.CodeInvalidCSV,
+		fmt.Sprintf("invalid csv at line %d: %s", parseErr.StartLine, parseErr.Err.Error()),
+		map[string]interface{}{"line": parseErr.StartLine, "reason": parseErr.Err.Error()},
+	)
+}
+
+// resolveImportColumns はマッピングに従って各フィールドが何列目にあるかを求める
+// ヘッダー名の比較では前後の空白・全角/半角・大文字/小文字を区別しない
+func resolveImportColumns(header []string, mapping ImportColumnMapping) (map[string]int, error) {
+	for field := range mapping {
+		if !isImportField(field) {
+			return nil, domainErrors.NewInputError(domainErrors.CodeUnknownMappingField, "unknown field in mapping: "+field, map[string]interface{}{"field": field})
+		}
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if _, ok := positions[key]; !ok {
+			positions[key] = i
+		}
+	}
+
+	columns := make(map[string]int, len(importFields))
+	var missing domainErrors.InputErrors
+	for _, field := range importFields {
+		name := field
+		if mapped, ok := mapping[field]; ok && strings.TrimSpace(mapped) != "" {
+			name = mapped
+		}
+		index, ok := positions[normalizeHeader(name)]
+		if !ok {
+			missing.Add(domainErrors.CodeMissingColumn, fmt.Sprintf("column %q for %s not found", name, field), map[string]interface{}{"column": name, "field": field})
+			continue
+		}
+		columns[field] = index
+	}
+	for _, field := range optionalImportFields {
+		name := field
+		if mapped, ok := mapping[field]; ok && strings.TrimSpace(mapped) != "" {
+			name = mapped
+		}
+		if index, ok := positions[normalizeHeader(name)]; ok {
+			columns[field] = index
+		}
+	}
+	if err := missing.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// parseImportRow は1行分の値を categories で検証し、アイテムを作成する
+// 金額の桁区切り・円記号・全角数字や、スラッシュ区切りの日付（Excel の既定の形式）も受け付ける
+func parseImportRow(values map[string]string, categories entity.CategoryChecker) (*entity.Item, []string) {
+	var errs []string
+
+	price := 0
+	rawPrice := norm.NFKC.String(values["purchase_price"])
+	rawPrice = strings.NewReplacer(",", "", "¥", "", "円", "", " ", "").Replace(rawPrice)
+	if rawPrice == "" {
+		errs = append(errs, "purchase_price is required")
+	} else if v, err := strconv.Atoi(rawPrice); err != nil {
+		errs = append(errs, "purchase_price must be an integer")
+	} else {
+		price = v
+	}
+
+	date := norm.NFKC.String(values["purchase_date"])
+	if t, err := time.Parse("2006/1/2", date); err == nil {
+		date = t.Format("2006-01-02")
+	}
+
+	var attributes entity.Attributes
+	if raw := values["attributes"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &attributes); err != nil {
+			errs = append(errs, "attributes must be a JSON object")
+		}
+	}
+
+	var tags []string
+	if raw := values["tags"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+			errs = append(errs, "tags must be a JSON array of strings")
+		}
+	}
+
+	item, err := entity.NewItem(values["name"], values["category"], values["brand"], price, date, attributes, categories)
+	if err == nil {
+		err = item.SetTags(tags)
+	}
+	if validationErrs, ok := domainErrors.AsValidationErrors(err); ok {
+		for _, v := range validationErrs {
+			errs = append(errs, v.Message)
+		}
+	} else if err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return item, nil
+}
+
+func importDuplicateKey(item *entity.Item) string {
+	return strings.Join([]string{item.Name, item.Category, item.Brand, strconv.Itoa(item.PurchasePrice), item.PurchaseDate, item.Attributes.String(), strings.Join(item.Tags, "\x01")}, "\x00")
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(name)))
+}
+
+func isImportField(field string) bool {
+	for _, f := range append(importFields, optionalImportFields...) {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// expectCreate はリポジトリの Create が登録済みのアイテムを返すようにモックを設定する
+func expectCreate(mockRepo *MockItemRepository) {
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(storedItem(), nil)
+}
+
+func TestItemUsecase_ImportItems(t *testing.T) {
+	japaneseCSV := "品名,分類,ブランド,購入価格,購入日\n" +
+		"ロレックス デイトナ,時計,ROLEX,\"￥1,500,000\",2023/1/15\n" +
+		"エルメス バーキン,バッグ,エルメス,２００００００,2023-02-20\n"
+	japaneseMapping := ImportColumnMapping{
+		"name":           "品名",
+		"category":       "分類",
+		"brand":          "ブランド",
+		"purchase_price": "購入価格",
+		"purchase_date":  "購入日",
+	}
+
+	shiftJIS, err := japanese.ShiftJIS.NewEncoder().String(japaneseCSV)
+	require.NoError(t, err)
+
+	// ボディの読み込みのエラー（サイズの上限を超えたなど）は呼び出し側で判別できるようにそのまま返す
+	errReadBody := errors.New("request body too large")
+	failingCSV := func() io.Reader {
+		return io.MultiReader(strings.NewReader("name,category,brand,purchase_price,purchase_date\n時計1,"), iotest.ErrReader(errReadBody))
+	}
+
+	tests := []struct {
+		name          string
+		input         ImportItemsInput
+		setupMock     func(*MockItemRepository)
+		wantErr       error
+		wantErrMsg    string
+		wantCommitted bool
+		wantCreated   int
+		wantSkipped   int
+		wantFailed    int
+		wantStatuses  []string
+	}{
+		{
+			name:          "正常系: UTF-8（BOM付き）をマッピングして登録",
+			input:         ImportItemsInput{CSV: strings.NewReader("\xEF\xBB\xBF" + japaneseCSV), Mapping: japaneseMapping},
+			setupMock:     expectCreate,
+			wantCommitted: true,
+			wantCreated:   2,
+			wantStatuses:  []string{ImportRowCreated, ImportRowCreated},
+		},
+		{
+			name:          "正常系: Shift_JIS を自動判定して登録",
+			input:         ImportItemsInput{CSV: strings.NewReader(shiftJIS), Mapping: japaneseMapping},
+			setupMock:     expectCreate,
+			wantCommitted: true,
+			wantCreated:   2,
+			wantStatuses:  []string{ImportRowCreated, ImportRowCreated},
+		},
+		{
+			name:          "正常系: Shift_JIS を明示して登録",
+			input:         ImportItemsInput{CSV: strings.NewReader(shiftJIS), Mapping: japaneseMapping, Encoding: ImportEncodingShiftJIS},
+			setupMock:     expectCreate,
+			wantCommitted: true,
+			wantCreated:   2,
+			wantStatuses:  []string{ImportRowCreated, ImportRowCreated},
+		},
+		{
+			name:         "正常系: dry_run では登録しない",
+			input:        ImportItemsInput{CSV: strings.NewReader(japaneseCSV), Mapping: japaneseMapping, DryRun: true},
+			setupMock:    func(mockRepo *MockItemRepository) {},
+			wantCreated:  2,
+			wantStatuses: []string{ImportRowCreated, ImportRowCreated},
+		},
+		{
+			name: "正常系: 空行と重複行はスキップ",
+			input: ImportItemsInput{CSV: strings.NewReader("name,category,brand,purchase_price,purchase_date\n" +
+				"時計1,時計,ROLEX,1000000,2023-01-01\n" +
+				",,,,\n" +
+				"時計1,時計,ROLEX,1000000,2023-01-01\n")},
+			setupMock:     expectCreate,
+			wantCommitted: true,
+			wantCreated:   1,
+			wantSkipped:   2,
+			wantStatuses:  []string{ImportRowCreated, ImportRowSkipped, ImportRowSkipped},
+		},
+		{
+			name: "異常系: 失敗した行があれば1件も登録しない",
+			input: ImportItemsInput{CSV: strings.NewReader("name,category,brand,purchase_price,purchase_date\n" +
+				"時計1,時計,ROLEX,1000000,2023-01-01\n" +
+				"家具1,家具,IKEA,abc,2023-13-01\n")},
+			setupMock:    func(mockRepo *MockItemRepository) {},
+			wantCreated:  1,
+			wantFailed:   1,
+			wantStatuses: []string{ImportRowCreated, ImportRowFailed},
+		},
+		{
+			name:      "異常系: マッピングした列がない",
+			input:     ImportItemsInput{CSV: strings.NewReader("品名,分類\nA,時計\n"), Mapping: ImportColumnMapping{"name": "品名"}},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name:      "異常系: マッピングに存在しないフィールド",
+			input:     ImportItemsInput{CSV: strings.NewReader(japaneseCSV), Mapping: ImportColumnMapping{"price": "購入価格"}},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name:      "異常系: 未対応の文字コード",
+			input:     ImportItemsInput{CSV: strings.NewReader(japaneseCSV), Encoding: "euc-jp"},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name:      "異常系: 空のCSV",
+			input:     ImportItemsInput{CSV: strings.NewReader("")},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 引用符が閉じていない行があるCSV",
+			input: ImportItemsInput{CSV: strings.NewReader("name,category,brand,purchase_price,purchase_date\n" +
+				"時計1,時計,ROLEX,1000000,2023-01-01\n" +
+				"家具1,家具,\"IKEA,50000,2023-02-01\n")},
+			setupMock:  func(mockRepo *MockItemRepository) {},
+			wantErr:    domainErrors.ErrInvalidInput,
+			wantErrMsg: "invalid csv at line 3",
+		},
+		{
+			name: "異常系: 引用符の後に余計な文字があるCSV",
+			input: ImportItemsInput{CSV: strings.NewReader("name,category,brand,purchase_price,purchase_date\n" +
+				"時計1,\"時計\"x,ROLEX,1000000,2023-01-01\n")},
+			setupMock:  func(mockRepo *MockItemRepository) {},
+			wantErr:    domainErrors.ErrInvalidInput,
+			wantErrMsg: "invalid csv at line 2",
+		},
+		{
+			name:      "異常系: 文字コードの自動判定中にボディの読み込みに失敗",
+			input:     ImportItemsInput{CSV: failingCSV()},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   errReadBody,
+		},
+		{
+			name:      "異常系: 行の読み込み中にボディの読み込みに失敗",
+			input:     ImportItemsInput{CSV: failingCSV(), Encoding: ImportEncodingUTF8},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   errReadBody,
+		},
+		{
+			name:  "異常系: 登録中のデータベースエラーでロールバック",
+			input: ImportItemsInput{CSV: strings.NewReader(japaneseCSV), Mapping: japaneseMapping},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Create", mock.Anything, mock.Anything).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
+			},
+			wantErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			revisionRepo := new(MockItemRevisionRepository)
+			transactor := new(MockTransactor)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo, transactor: transactor})
+
+			report, err := usecase.ImportItems(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErrMsg != "" {
+					assert.ErrorContains(t, err, tt.wantErrMsg)
+				}
+				assert.Nil(t, report)
+				if transactor.calls > 0 {
+					assert.Error(t, transactor.err)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommitted, report.Committed)
+			assert.Equal(t, tt.wantCreated, report.Created)
+			assert.Equal(t, tt.wantSkipped, report.Skipped)
+			assert.Equal(t, tt.wantFailed, report.Failed)
+
+			var statuses []string
+			for _, row := range report.Rows {
+				statuses = append(statuses, row.Status)
+				if row.Status == ImportRowCreated {
+					assert.Equal(t, tt.wantCommitted, row.ItemID != nil)
+				}
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+
+			if tt.wantCommitted {
+				assert.Equal(t, 1, transactor.calls)
+				assert.Len(t, revisionRepo.revisions, tt.wantCreated)
+			} else {
+				assert.Equal(t, 0, transactor.calls)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	item, errs := parseImportRow(map[string]string{
+		"name":           "ロレックス デイトナ",
+		"category":       "時計",
+		"brand":          "ROLEX",
+		"purchase_price": "¥1,500,000",
+		"purchase_date":  "2023/1/5",
+	}, entity.FixedCategories)
+	require.Empty(t, errs)
+	assert.Equal(t, 1500000, item.PurchasePrice)
+	assert.Equal(t, "2023-01-05", item.PurchaseDate)
+
+	_, errs = parseImportRow(map[string]string{
+		"name":           "",
+		"category":       "時計",
+		"brand":          "ROLEX",
+		"purchase_price": "",
+		"purchase_date":  "2023-01-05",
+	}, entity.FixedCategories)
+	assert.Contains(t, errs, "purchase_price is required")
+}
+
+func TestParseImportRow_Attributes(t *testing.T) {
+	repo := newMockCategoryRepository()
+	repo.categories[0].AttributeSchema = entity.AttributeSchema{
+		{Key: "movement", Type: entity.AttributeEnum, Options: []string{"automatic", "quartz"}},
+	}
+	categories := entity.NewCategoryTree(repo.categories)
+
+	row := map[string]string{
+		"name":           "ロレックス デイトナ",
+		"category":       "時計",
+		"brand":          "ROLEX",
+		"purchase_price": "1500000",
+		"purchase_date":  "2023-01-05",
+	}
+
+	t.Run("正常系: JSONの属性を読み取る", func(t *testing.T) {
+		row["attributes"] = `{"movement":"automatic"}`
+		item, errs := parseImportRow(row, categories)
+		require.Empty(t, errs)
+		assert.Equal(t, entity.Attributes{"movement": "automatic"}, item.Attributes)
+	})
+
+	t.Run("異常系: JSONのオブジェクトでない", func(t *testing.T) {
+		row["attributes"] = `automatic`
+		_, errs := parseImportRow(row, categories)
+		assert.Contains(t, errs, "attributes must be a JSON object")
+	})
+
+	t.Run("正常系: JSONの配列のタグを読み取る", func(t *testing.T) {
+		row["attributes"] = ""
+		row["tags"] = `["旅行","限定品","旅行"]`
+		item, errs := parseImportRow(row, categories)
+		require.Empty(t, errs)
+		assert.Equal(t, []string{"旅行", "限定品"}, item.Tags)
+	})
+
+	t.Run("異常系: タグがJSONの配列でない", func(t *testing.T) {
+		row["tags"] = `旅行,限定品`
+		_, errs := parseImportRow(row, categories)
+		assert.Contains(t, errs, "tags must be a JSON array of strings")
+	})
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// MoveItemInput はアイテムの移動の入力
+// MovedAt を省略した場合は記録した日時に移動したものとする
+type MoveItemInput struct {
+	LocationID int64      `json:"location_id"`
+	Note       string     `json:"note"`
+	MovedAt    *time.Time `json:"moved_at,omitempty"`
+}
+
+// LocationItems は保管場所と、その中（中にある保管場所を含む）に置かれているアイテム
+type LocationItems struct {
+	Location *entity.Location
+	Path     []string // 最上位から保管場所までの名前
+	Items    *ItemList
+}
+
+// MoveItem はアイテムを別の保管場所に移動し、移動履歴を記録する
+// 保管場所の変更でもバージョンは進むが、変更履歴（revisions）には記録しない
+func (u *itemUsecase) MoveItem(ctx context.Context, id int64, input MoveItemInput, expectedVersion *int) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var movedItem *entity.Item
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && item.Version != *expectedVersion {
+			return domainErrors.ErrVersionConflict
+		}
+
+		if input.LocationID > 0 {
+			if err := u.lockLocation(ctx, input.LocationID); err != nil {
+				return err
+			}
+		}
+
+		var movedAt time.Time
+		if input.MovedAt != nil {
+			movedAt = *input.MovedAt
+		}
+		move, err := item.MoveTo(input.LocationID, input.Note, movedAt)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		// 履歴の順序が入れ替わらないよう、直前の移動より前の日時は指定できない
+		moves, err := u.locationRepo.FindMovesByItemID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(moves) > 0 && move.MovedAt.Before(moves[0].MovedAt) {
+			var errs domainErrors.ValidationErrors
+			errs.Add("moved_at", domainErrors.CodeTooSmall, "moved_at must not be before the previous move",
+				map[string]interface{}{"min": moves[0].MovedAt.Format(time.RFC3339)})
+			return errs.Err()
+		}
+
+		movedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		move.Actor = ActorFromContext(ctx)
+		return u.locationRepo.CreateMove(ctx, move)
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsConflictError(err) {
+			return nil, domainErrors.ErrVersionConflict
+		}
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to move item: %w", err)
+	}
+
+	return movedItem, nil
+}
+
+// GetItemMoves はアイテムの移動履歴を移動日時の新しい順に返す
+// 移動前・移動先の保管場所は、現在の名前で最上位からのパスを設定する
+func (u *itemUsecase) GetItemMoves(ctx context.Context, id int64) ([]*entity.ItemMove, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	moves, err := u.locationRepo.FindMovesByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve moves: %w", err)
+	}
+
+	// 履歴がない場合は、移動したことのないアイテムかどうかを確認する
+	if len(moves) == 0 {
+		if _, err := u.GetItemByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return []*entity.ItemMove{}, nil
+	}
+
+	locations, err := u.locationRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	tree := entity.NewLocationTree(locations)
+	for _, move := range moves {
+		move.FromPath = locationPath(tree, move.FromLocationID)
+		move.ToPath = locationPath(tree, move.ToLocationID)
+	}
+
+	return moves, nil
+}
+
+// GetLocationItems は保管場所と、その中にある保管場所を含めて置かれているアイテムを返す
+// criteria の絞り込み・並び順・ページングは GetAllItems と同じ
+func (u *itemUsecase) GetLocationItems(ctx context.Context, locationID int64, criteria ItemCriteria) (*LocationItems, error) {
+	if locationID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	locations, err := u.locationRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	tree := entity.NewLocationTree(locations)
+	location, ok := tree.FindByID(locationID)
+	if !ok {
+		return nil, domainErrors.ErrLocationNotFound
+	}
+
+	criteria.LocationID = &location.ID
+	items, err := u.GetAllItems(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocationItems{
+		Location: location,
+		Path:     tree.Path(location.ID),
+		Items:    items,
+	}, nil
+}
+
+// expandLocationFilter は絞り込みの保管場所の中に別の保管場所がある場合、その保管場所も含める
+func (u *itemUsecase) expandLocationFilter(ctx context.Context, criteria *ItemCriteria) error {
+	criteria.LocationIDs = nil
+	if criteria.LocationID == nil {
+		return nil
+	}
+
+	locations, err := u.locationRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+
+	descendants := entity.NewLocationTree(locations).Descendants(*criteria.LocationID)
+	if len(descendants) == 0 {
+		return nil
+	}
+
+	criteria.LocationIDs = []int64{*criteria.LocationID}
+	for _, descendant := range descendants {
+		criteria.LocationIDs = append(criteria.LocationIDs, descendant.ID)
+	}
+	return nil
+}
+
+// lockLocation はアイテムの移動先の保管場所をロックし、移動中に削除されないようにする
+// 存在しない場合は location_id のバリデーションエラーを返す
+func (u *itemUsecase) lockLocation(ctx context.Context, locationID int64) error {
+	if _, err := u.locationRepo.LockByID(ctx, locationID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			var errs domainErrors.ValidationErrors
+			errs.Add("location_id", domainErrors.CodeInvalidLocation, "location_id does not exist", nil)
+			return errs.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// locationPath は保管場所の最上位からの名前を返す（未設定・削除済みの場合は空）
+func locationPath(tree *entity.LocationTree, id *int64) []string {
+	if id == nil {
+		return []string{}
+	}
+	path := tree.Path(*id)
+	if path == nil {
+		return []string{}
+	}
+	return path
+}
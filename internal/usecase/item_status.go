@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// ChangeItemStatusInput は所有状況の変更の入力
+// ChangedAt を省略した場合は記録した日時に変わったものとする
+type ChangeItemStatusInput struct {
+	Status    entity.ItemStatus `json:"status"`
+	Reason    string            `json:"reason"`
+	ChangedAt *time.Time        `json:"changed_at,omitempty"`
+}
+
+// ChangeItemStatus はアイテムの所有状況を変更し、変更の記録を残す
+// 保管場所の移動と同じくバージョンは進むが、変更履歴（revisions）には記録しない
+// sold には変更できない（売却は SellItem で売却の記録と合わせて行う）
+func (u *itemUsecase) ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput, expectedVersion *int) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var changedItem *entity.Item
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && item.Version != *expectedVersion {
+			return domainErrors.ErrVersionConflict
+		}
+
+		var changedAt time.Time
+		if input.ChangedAt != nil {
+			changedAt = *input.ChangedAt
+		}
+		event, err := item.ChangeStatus(input.Status, input.Reason, changedAt)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		// 記録の順序が入れ替わらないよう、直前の変更より前の日時は指定できない
+		events, err := u.statusRepo.FindByItemID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && event.ChangedAt.Before(events[0].ChangedAt) {
+			var errs domainErrors.ValidationErrors
+			errs.Add("changed_at", domainErrors.CodeTooSmall, "changed_at must not be before the previous status change",
+				map[string]interface{}{"min": events[0].ChangedAt.Format(time.RFC3339)})
+			return errs.Err()
+		}
+
+		changedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		event.Actor = ActorFromContext(ctx)
+		return u.statusRepo.Create(ctx, event)
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsConflictError(err) {
+			return nil, domainErrors.ErrVersionConflict
+		}
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change item status: %w", err)
+	}
+
+	return changedItem, nil
+}
+
+// GetItemStatusEvents はアイテムの所有状況の変更の記録を変更日時の新しい順に返す
+func (u *itemUsecase) GetItemStatusEvents(ctx context.Context, id int64) ([]*entity.ItemStatusEvent, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	events, err := u.statusRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve status events: %w", err)
+	}
+
+	// 記録がない場合は、状態を変更したことのないアイテムかどうかを確認する
+	if len(events) == 0 {
+		if _, err := u.GetItemByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return []*entity.ItemStatusEvent{}, nil
+	}
+
+	return events, nil
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// MockItemStatusEventRepository は所有状況の変更の記録をメモリに保持するモック
+type MockItemStatusEventRepository struct {
+	events []*entity.ItemStatusEvent
+}
+
+func newMockItemStatusEventRepository() *MockItemStatusEventRepository {
+	return &MockItemStatusEventRepository{}
+}
+
+func (m *MockItemStatusEventRepository) Create(ctx context.Context, event *entity.ItemStatusEvent) error {
+	event.ID = int64(len(m.events) + 1)
+	event.CreatedAt = time.Now()
+	m.events = append(m.events, event)
+	return nil
+}
+
+// FindByItemID は登録の逆順（変更日時の新しい順を想定）に返す
+func (m *MockItemStatusEventRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemStatusEvent, error) {
+	var events []*entity.ItemStatusEvent
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].ItemID == itemID {
+			copied := *m.events[i]
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
+
+func TestItemUsecase_ChangeItemStatus(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		input     ChangeItemStatusInput
+		wantErr   error
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:  "正常系: 貸し出し中から手元に戻す",
+			input: ChangeItemStatusInput{Status: entity.StatusOwned, Reason: "  返却された  ", ChangedAt: &past},
+		},
+		{
+			name:      "異常系: 遷移できない状態",
+			input:     ChangeItemStatusInput{Status: entity.StatusConsigned, Reason: "委託した"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidTransition,
+			wantField: "status",
+		},
+		{
+			name:      "異常系: sold には売却の記録でのみ変更できる",
+			input:     ChangeItemStatusInput{Status: entity.StatusSold, Reason: "売却した"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidOption,
+			wantField: "status",
+		},
+		{
+			name:      "異常系: 理由がない",
+			input:     ChangeItemStatusInput{Status: entity.StatusOwned},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeRequired,
+			wantField: "reason",
+		},
+		{
+			name: "異常系: 直前の変更より前の日時",
+			input: func() ChangeItemStatusInput {
+				changedAt := time.Now().Add(-10 * 24 * time.Hour)
+				return ChangeItemStatusInput{Status: entity.StatusOwned, Reason: "返却された", ChangedAt: &changedAt}
+			}(),
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeTooSmall,
+			wantField: "changed_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			statusRepo := newMockItemStatusEventRepository()
+			statusRepo.events = []*entity.ItemStatusEvent{
+				{ID: 1, ItemID: 1, FromStatus: entity.StatusOwned, ToStatus: entity.StatusOnLoan, Reason: "友人に貸した", ChangedAt: time.Now().Add(-7 * 24 * time.Hour)},
+			}
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, statusRepo: statusRepo})
+
+			item := storedItem()
+			item.Status = entity.StatusOnLoan
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			var saved *entity.Item
+			mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entity.Item)
+			}).Return(storedItem(), nil).Maybe()
+
+			ctx := WithActor(context.Background(), "alice")
+			_, err := usecase.ChangeItemStatus(ctx, 1, tt.input, nil)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var verrs domainErrors.ValidationErrors
+				require.ErrorAs(t, err, &verrs)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				assert.Len(t, statusRepo.events, 1)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entity.StatusOwned, saved.Status)
+			require.Len(t, statusRepo.events, 2)
+			event := statusRepo.events[1]
+			assert.Equal(t, entity.StatusOnLoan, event.FromStatus)
+			assert.Equal(t, entity.StatusOwned, event.ToStatus)
+			assert.Equal(t, "返却された", event.Reason)
+			assert.Equal(t, "alice", event.Actor)
+			assert.True(t, event.ChangedAt.Equal(past))
+		})
+	}
+
+	t.Run("異常系: バージョンが一致しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		_, err := usecase.ChangeItemStatus(context.Background(), 1, ChangeItemStatusInput{Status: entity.StatusLost, Reason: "紛失"}, intPtr(2))
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := usecase.ChangeItemStatus(context.Background(), 99, ChangeItemStatusInput{Status: entity.StatusLost, Reason: "紛失"}, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemUsecase_GetItemStatusEvents(t *testing.T) {
+	t.Run("正常系: 変更日時の新しい順に返す", func(t *testing.T) {
+		statusRepo := newMockItemStatusEventRepository()
+		statusRepo.events = []*entity.ItemStatusEvent{
+			{ID: 1, ItemID: 1, FromStatus: entity.StatusOwned, ToStatus: entity.StatusInRepair, Reason: "オーバーホール"},
+			{ID: 2, ItemID: 2, FromStatus: entity.StatusOwned, ToStatus: entity.StatusLost, Reason: "紛失"},
+			{ID: 3, ItemID: 1, FromStatus: entity.StatusInRepair, ToStatus: entity.StatusOwned, Reason: "修理完了"},
+		}
+		usecase := newTestItemUsecase(t, testItemDeps{statusRepo: statusRepo})
+
+		events, err := usecase.GetItemStatusEvents(context.Background(), 1)
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(3), events[0].ID)
+		assert.Equal(t, int64(1), events[1].ID)
+	})
+
+	t.Run("正常系: 変更したことのないアイテムは空", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		events, err := usecase.GetItemStatusEvents(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := usecase.GetItemStatusEvents(context.Background(), 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemUsecase_GetCategorySummaryStatuses(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []string
+		wantStatuses []string
+		wantErr      error
+	}{
+		{
+			name:         "正常系: 省略した場合は現在の所有資産のみ",
+			statuses:     nil,
+			wantStatuses: []string{"owned", "on_loan", "in_repair", "consigned"},
+		},
+		{
+			name:         "正常系: 指定した所有状況で集計",
+			statuses:     []string{"sold"},
+			wantStatuses: []string{"sold"},
+		},
+		{
+			name:     "異常系: 未定義の所有状況",
+			statuses: []string{"archived"},
+			wantErr:  domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+			mockRepo.On("SumByCategory", mock.Anything, tt.wantStatuses).Return([]CategoryTotal{}, nil).Maybe()
+
+			summary, err := usecase.GetCategorySummary(context.Background(), tt.statuses)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "SumByCategory", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatuses, summary.Statuses)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
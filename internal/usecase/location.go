@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// LocationUsecase は保管場所（建物 > 部屋 > 収納）の管理を行う
+// アイテムの移動は ItemUsecase.MoveItem で行う
+type LocationUsecase interface {
+	// GetLocations はすべての保管場所を名前順に返す（階層は parent_id でたどる）
+	GetLocations(ctx context.Context) ([]*entity.Location, error)
+	GetLocation(ctx context.Context, id int64) (*entity.Location, error)
+	CreateLocation(ctx context.Context, input CreateLocationInput) (*entity.Location, error)
+	// UpdateLocation は名前・種類・親を更新する（中の保管場所とアイテムは一緒に移動する）
+	UpdateLocation(ctx context.Context, id int64, input UpdateLocationInput) (*entity.Location, error)
+	// DeleteLocation は保管場所を削除する（中に保管場所がある場合・アイテムが置かれている場合は削除できない）
+	DeleteLocation(ctx context.Context, id int64) error
+}
+
+// CreateLocationInput は保管場所の登録の入力（ParentID が nil の場合は最上位に登録する）
+type CreateLocationInput struct {
+	Name     string              `json:"name"`
+	Kind     entity.LocationKind `json:"kind"`
+	ParentID *int64              `json:"parent_id,omitempty"`
+}
+
+// UpdateLocationInput は保管場所の部分更新の入力（nil のフィールドは更新しない）
+// ParentID に 0 を指定すると最上位に移動する
+type UpdateLocationInput struct {
+	Name     *string              `json:"name,omitempty"`
+	Kind     *entity.LocationKind `json:"kind,omitempty"`
+	ParentID *int64               `json:"parent_id,omitempty"`
+}
+
+type locationUsecase struct {
+	locationRepo LocationRepository
+	transactor   Transactor
+}
+
+func NewLocationUsecase(locationRepo LocationRepository, transactor Transactor) LocationUsecase {
+	return &locationUsecase{
+		locationRepo: locationRepo,
+		transactor:   transactor,
+	}
+}
+
+func (u *locationUsecase) GetLocations(ctx context.Context) ([]*entity.Location, error) {
+	locations, err := u.locationRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	if locations == nil {
+		locations = []*entity.Location{}
+	}
+	return locations, nil
+}
+
+func (u *locationUsecase) GetLocation(ctx context.Context, id int64) (*entity.Location, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	location, err := u.locationRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve location: %w", err)
+	}
+	return location, nil
+}
+
+func (u *locationUsecase) CreateLocation(ctx context.Context, input CreateLocationInput) (*entity.Location, error) {
+	location, err := entity.NewLocation(input.Name, input.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	location.ParentID = input.ParentID
+
+	var created *entity.Location
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var parent *entity.Location
+		if location.ParentID != nil {
+			if parent, err = u.validateParent(ctx, nil, location, *location.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := location.CanBePlacedIn(parent); err != nil {
+			return err
+		}
+
+		locations, err := u.locationRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		if err := ensureUniqueName(entity.NewLocationTree(locations), location); err != nil {
+			return err
+		}
+
+		created, err = u.locationRepo.Create(ctx, location)
+		return err
+	})
+	if err != nil {
+		return nil, locationWriteError("create", err)
+	}
+
+	return created, nil
+}
+
+func (u *locationUsecase) UpdateLocation(ctx context.Context, id int64, input UpdateLocationInput) (*entity.Location, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if input.Name == nil && input.Kind == nil && input.ParentID == nil {
+		return nil, domainErrors.NewInputError(domainErrors.CodeNoFieldsToUpdate, "no fields to update", nil)
+	}
+
+	var updated *entity.Location
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		location, err := u.locationRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// 親の付け替えの循環と、中の保管場所との組み合わせの確認には最新の親子関係を使う
+		locations, err := u.locationRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		tree := entity.NewLocationTree(locations)
+
+		var parent *entity.Location
+		if location.ParentID != nil {
+			parent, _ = tree.FindByID(*location.ParentID)
+		}
+		if input.ParentID != nil {
+			if *input.ParentID == 0 {
+				// 0 は最上位への移動
+				location.ParentID = nil
+				parent = nil
+			} else if location.ParentID == nil || *location.ParentID != *input.ParentID {
+				if parent, err = u.validateParent(ctx, tree, location, *input.ParentID); err != nil {
+					return err
+				}
+				parentID := *input.ParentID
+				location.ParentID = &parentID
+			}
+		}
+
+		name, kind := location.Name, location.Kind
+		if input.Name != nil {
+			name = *input.Name
+		}
+		if input.Kind != nil {
+			kind = *input.Kind
+		}
+		if err := location.Update(name, kind); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		// 種類を変えた場合も、親の中に置けて、中の保管場所を置いたままにできる必要がある
+		if err := location.CanBePlacedIn(parent); err != nil {
+			return err
+		}
+		if err := location.CanContain(tree.Children(location.ID)); err != nil {
+			return err
+		}
+		if err := ensureUniqueName(tree, location); err != nil {
+			return err
+		}
+
+		updated, err = u.locationRepo.Update(ctx, location)
+		return err
+	})
+	if err != nil {
+		return nil, locationWriteError("update", err)
+	}
+
+	return updated, nil
+}
+
+func (u *locationUsecase) DeleteLocation(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		location, err := u.locationRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		locations, err := u.locationRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		if children := entity.NewLocationTree(locations).Children(id); len(children) > 0 {
+			return fmt.Errorf("%w: location %s contains %d location(s)", domainErrors.ErrLocationHasChildren, location.Name, len(children))
+		}
+
+		count, err := u.locationRepo.CountItems(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d item(s) are stored in location %s", domainErrors.ErrLocationInUse, count, location.Name)
+		}
+		return u.locationRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return locationWriteError("delete", err)
+	}
+
+	return nil
+}
+
+// validateParent は parentID を location の親に設定できるかを確認し、親の保管場所を返す
+// 親の行をロックし、確認中に親が削除されないようにする
+// tree は付け替えの場合の循環の確認に使う（新規登録の場合は nil）
+func (u *locationUsecase) validateParent(ctx context.Context, tree *entity.LocationTree, location *entity.Location, parentID int64) (*entity.Location, error) {
+	var errs domainErrors.ValidationErrors
+
+	parent, err := u.locationRepo.LockByID(ctx, parentID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id does not exist", nil)
+			return nil, errs.Err()
+		}
+		return nil, err
+	}
+
+	if parent.ID == location.ID || (tree != nil && tree.IsDescendant(location.ID, parent.ID)) {
+		errs.Add("parent_id", domainErrors.CodeInvalidParent, "parent_id must not be the location itself or its descendant", nil)
+		return nil, errs.Err()
+	}
+
+	return parent, nil
+}
+
+// ensureUniqueName は同じ親の中に同じ名前（大文字・小文字を区別しない）の保管場所がないことを確認する
+func ensureUniqueName(tree *entity.LocationTree, location *entity.Location) error {
+	parentID := int64(0)
+	if location.ParentID != nil {
+		parentID = *location.ParentID
+	}
+	for _, sibling := range tree.Children(parentID) {
+		if sibling.ID != location.ID && strings.EqualFold(sibling.Name, location.Name) {
+			return fmt.Errorf("%w: location %s already exists in the same place", domainErrors.ErrDuplicateEntry, location.Name)
+		}
+	}
+	return nil
+}
+
+// locationWriteError は登録・更新・削除のエラーのうち、呼び出し側で判別するものはそのまま返す
+func locationWriteError(op string, err error) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return domainErrors.ErrLocationNotFound
+	case errors.Is(err, domainErrors.ErrLocationInUse),
+		errors.Is(err, domainErrors.ErrLocationHasChildren),
+		errors.Is(err, domainErrors.ErrDuplicateEntry),
+		domainErrors.IsValidationError(err):
+		return err
+	default:
+		return fmt.Errorf("failed to %s location: %w", op, err)
+	}
+}
@@ -0,0 +1,547 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// MockLocationRepository は保管場所と移動履歴をメモリに保持するモック
+// itemCounts には保管場所ごとに置かれているアイテムの件数を設定する
+type MockLocationRepository struct {
+	locations  []*entity.Location
+	itemCounts map[int64]int
+	moves      []*entity.ItemMove
+}
+
+func newMockLocationRepository() *MockLocationRepository {
+	return &MockLocationRepository{itemCounts: make(map[int64]int)}
+}
+
+// newMockLocationTree は次の保管場所を登録したモックを返す
+//
+//	1 自宅（建物）
+//	├── 2 寝室（部屋）
+//	│   └── 3 金庫（収納）
+//	└── 4 書斎（部屋）
+//	5 銀行（建物）
+//	└── 6 貸金庫（収納）
+func newMockLocationTree() *MockLocationRepository {
+	m := newMockLocationRepository()
+	add := func(id int64, parentID *int64, name string, kind entity.LocationKind) {
+		m.locations = append(m.locations, &entity.Location{ID: id, ParentID: parentID, Name: name, Kind: kind})
+	}
+	add(1, nil, "自宅", entity.LocationBuilding)
+	add(2, int64Ptr(1), "寝室", entity.LocationRoom)
+	add(3, int64Ptr(2), "金庫", entity.LocationContainer)
+	add(4, int64Ptr(1), "書斎", entity.LocationRoom)
+	add(5, nil, "銀行", entity.LocationBuilding)
+	add(6, int64Ptr(5), "貸金庫", entity.LocationContainer)
+	return m
+}
+
+func (m *MockLocationRepository) FindAll(ctx context.Context) ([]*entity.Location, error) {
+	locations := make([]*entity.Location, 0, len(m.locations))
+	for _, l := range m.locations {
+		copied := *l
+		locations = append(locations, &copied)
+	}
+	return locations, nil
+}
+
+func (m *MockLocationRepository) FindByID(ctx context.Context, id int64) (*entity.Location, error) {
+	for _, l := range m.locations {
+		if l.ID == id {
+			copied := *l
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrLocationNotFound
+}
+
+func (m *MockLocationRepository) LockByID(ctx context.Context, id int64) (*entity.Location, error) {
+	return m.FindByID(ctx, id)
+}
+
+func (m *MockLocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	created := *location
+	created.ID = int64(len(m.locations) + 1)
+	m.locations = append(m.locations, &created)
+	copied := created
+	return &copied, nil
+}
+
+func (m *MockLocationRepository) Update(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	for i, l := range m.locations {
+		if l.ID == location.ID {
+			updated := *location
+			m.locations[i] = &updated
+			copied := updated
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrLocationNotFound
+}
+
+func (m *MockLocationRepository) Delete(ctx context.Context, id int64) error {
+	for i, l := range m.locations {
+		if l.ID == id {
+			m.locations = append(m.locations[:i], m.locations[i+1:]...)
+			return nil
+		}
+	}
+	return domainErrors.ErrLocationNotFound
+}
+
+func (m *MockLocationRepository) CountItems(ctx context.Context, id int64) (int, error) {
+	return m.itemCounts[id], nil
+}
+
+func (m *MockLocationRepository) CreateMove(ctx context.Context, move *entity.ItemMove) error {
+	move.ID = int64(len(m.moves) + 1)
+	move.CreatedAt = time.Now()
+	m.moves = append(m.moves, move)
+	return nil
+}
+
+// FindMovesByItemID は登録の逆順（移動日時の新しい順を想定）に返す
+func (m *MockLocationRepository) FindMovesByItemID(ctx context.Context, itemID int64) ([]*entity.ItemMove, error) {
+	var moves []*entity.ItemMove
+	for i := len(m.moves) - 1; i >= 0; i-- {
+		if m.moves[i].ItemID == itemID {
+			copied := *m.moves[i]
+			moves = append(moves, &copied)
+		}
+	}
+	return moves, nil
+}
+
+func TestLocationUsecase_CreateLocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     CreateLocationInput
+		wantErr   error
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:  "正常系: 最上位に建物を登録",
+			input: CreateLocationInput{Name: "実家", Kind: entity.LocationBuilding},
+		},
+		{
+			name:  "正常系: 部屋の中に収納を登録",
+			input: CreateLocationInput{Name: "クローゼット", Kind: entity.LocationContainer, ParentID: int64Ptr(2)},
+		},
+		{
+			name:      "異常系: 部屋を最上位に登録",
+			input:     CreateLocationInput{Name: "リビング", Kind: entity.LocationRoom},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidParentKind,
+			wantField: "parent_id",
+		},
+		{
+			name:      "異常系: 収納の中に部屋を登録",
+			input:     CreateLocationInput{Name: "納戸", Kind: entity.LocationRoom, ParentID: int64Ptr(3)},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidParentKind,
+			wantField: "parent_id",
+		},
+		{
+			name:      "異常系: 存在しない親",
+			input:     CreateLocationInput{Name: "箱", Kind: entity.LocationContainer, ParentID: int64Ptr(99)},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidParent,
+			wantField: "parent_id",
+		},
+		{
+			name:      "異常系: 種類が不正",
+			input:     CreateLocationInput{Name: "倉庫", Kind: "warehouse"},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidOption,
+			wantField: "kind",
+		},
+		{
+			name:    "異常系: 同じ親の中に同じ名前",
+			input:   CreateLocationInput{Name: "寝室", Kind: entity.LocationRoom, ParentID: int64Ptr(1)},
+			wantErr: domainErrors.ErrDuplicateEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockLocationTree()
+			usecase := NewLocationUsecase(repo, new(MockTransactor))
+
+			location, err := usecase.CreateLocation(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantCode != "" {
+					var verrs domainErrors.ValidationErrors
+					require.ErrorAs(t, err, &verrs)
+					assert.Equal(t, tt.wantField, verrs[0].Field)
+					assert.Equal(t, tt.wantCode, verrs[0].Code)
+				}
+				assert.Len(t, repo.locations, 6)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), location.ID)
+			assert.Equal(t, tt.input.ParentID, location.ParentID)
+		})
+	}
+}
+
+func TestLocationUsecase_UpdateLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		input      UpdateLocationInput
+		wantParent *int64
+		wantErr    error
+		wantCode   string
+	}{
+		{
+			name:       "正常系: 収納を別の建物に移動",
+			id:         3,
+			input:      UpdateLocationInput{ParentID: int64Ptr(5)},
+			wantParent: int64Ptr(5),
+		},
+		{
+			name:       "正常系: 名前のみ変更",
+			id:         4,
+			input:      UpdateLocationInput{Name: stringPtr("仕事部屋")},
+			wantParent: int64Ptr(1),
+		},
+		{
+			name:     "異常系: 子孫の中に移動",
+			id:       1,
+			input:    UpdateLocationInput{ParentID: int64Ptr(3)},
+			wantErr:  domainErrors.ErrInvalidInput,
+			wantCode: domainErrors.CodeInvalidParent,
+		},
+		{
+			name: "異常系: 部屋が入っている建物を収納に変更",
+			id:   1,
+			input: func() UpdateLocationInput {
+				kind := entity.LocationContainer
+				return UpdateLocationInput{Kind: &kind, ParentID: int64Ptr(5)}
+			}(),
+			wantErr:  domainErrors.ErrInvalidInput,
+			wantCode: domainErrors.CodeInvalidParentKind,
+		},
+		{
+			name:    "異常系: 存在しない保管場所",
+			id:      99,
+			input:   UpdateLocationInput{Name: stringPtr("倉庫")},
+			wantErr: domainErrors.ErrLocationNotFound,
+		},
+		{
+			name:    "異常系: 更新するフィールドがない",
+			id:      1,
+			input:   UpdateLocationInput{},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewLocationUsecase(newMockLocationTree(), new(MockTransactor))
+
+			location, err := usecase.UpdateLocation(context.Background(), tt.id, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantCode != "" {
+					var verrs domainErrors.ValidationErrors
+					require.ErrorAs(t, err, &verrs)
+					assert.Equal(t, tt.wantCode, verrs[0].Code)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantParent, location.ParentID)
+		})
+	}
+}
+
+func TestLocationUsecase_DeleteLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		itemCounts map[int64]int
+		wantErr    error
+	}{
+		{
+			name: "正常系: 空の保管場所を削除",
+			id:   4,
+		},
+		{
+			name:    "異常系: 中に保管場所がある",
+			id:      2,
+			wantErr: domainErrors.ErrLocationHasChildren,
+		},
+		{
+			name:       "異常系: アイテムが置かれている",
+			id:         6,
+			itemCounts: map[int64]int{6: 2},
+			wantErr:    domainErrors.ErrLocationInUse,
+		},
+		{
+			name:    "異常系: 存在しない保管場所",
+			id:      99,
+			wantErr: domainErrors.ErrLocationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockLocationTree()
+			for id, count := range tt.itemCounts {
+				repo.itemCounts[id] = count
+			}
+			usecase := NewLocationUsecase(repo, new(MockTransactor))
+
+			err := usecase.DeleteLocation(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, repo.locations, 5)
+		})
+	}
+}
+
+func TestItemUsecase_MoveItem(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		input     MoveItemInput
+		wantErr   error
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:  "正常系: 別の保管場所に移動",
+			input: MoveItemInput{LocationID: 6, Note: "  貸金庫に預けた  ", MovedAt: &past},
+		},
+		{
+			name:      "異常系: 同じ保管場所",
+			input:     MoveItemInput{LocationID: 3},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidLocation,
+			wantField: "location_id",
+		},
+		{
+			name:      "異常系: 存在しない保管場所",
+			input:     MoveItemInput{LocationID: 99},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeInvalidLocation,
+			wantField: "location_id",
+		},
+		{
+			name:      "異常系: 保管場所の指定がない",
+			input:     MoveItemInput{},
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeRequired,
+			wantField: "location_id",
+		},
+		{
+			name: "異常系: 直前の移動より前の日時",
+			input: func() MoveItemInput {
+				movedAt := time.Now().Add(-10 * 24 * time.Hour)
+				return MoveItemInput{LocationID: 6, MovedAt: &movedAt}
+			}(),
+			wantErr:   domainErrors.ErrInvalidInput,
+			wantCode:  domainErrors.CodeTooSmall,
+			wantField: "moved_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			locationRepo := newMockLocationTree()
+			locationRepo.moves = []*entity.ItemMove{
+				{ID: 1, ItemID: 1, ToLocationID: int64Ptr(3), MovedAt: time.Now().Add(-7 * 24 * time.Hour)},
+			}
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, locationRepo: locationRepo})
+
+			item := storedItem()
+			item.LocationID = int64Ptr(3)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			var saved *entity.Item
+			mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entity.Item)
+			}).Return(storedItem(), nil).Maybe()
+
+			ctx := WithActor(context.Background(), "alice")
+			_, err := usecase.MoveItem(ctx, 1, tt.input, nil)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var verrs domainErrors.ValidationErrors
+				require.ErrorAs(t, err, &verrs)
+				assert.Equal(t, tt.wantField, verrs[0].Field)
+				assert.Equal(t, tt.wantCode, verrs[0].Code)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				assert.Len(t, locationRepo.moves, 1)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64Ptr(6), saved.LocationID)
+			require.Len(t, locationRepo.moves, 2)
+			move := locationRepo.moves[1]
+			assert.Equal(t, int64Ptr(3), move.FromLocationID)
+			assert.Equal(t, int64Ptr(6), move.ToLocationID)
+			assert.Equal(t, "貸金庫に預けた", move.Note)
+			assert.Equal(t, "alice", move.Actor)
+			assert.True(t, move.MovedAt.Equal(past))
+		})
+	}
+
+	t.Run("異常系: バージョンが一致しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, locationRepo: newMockLocationTree()})
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		_, err := usecase.MoveItem(context.Background(), 1, MoveItemInput{LocationID: 6}, intPtr(2))
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+}
+
+func TestItemUsecase_GetItemMoves(t *testing.T) {
+	t.Run("正常系: 移動前・移動先のパスを設定する", func(t *testing.T) {
+		locationRepo := newMockLocationTree()
+		locationRepo.moves = []*entity.ItemMove{
+			{ID: 1, ItemID: 1, ToLocationID: int64Ptr(3)},
+			{ID: 2, ItemID: 1, FromLocationID: int64Ptr(3), ToLocationID: int64Ptr(6)},
+		}
+		usecase := newTestItemUsecase(t, testItemDeps{locationRepo: locationRepo})
+
+		moves, err := usecase.GetItemMoves(context.Background(), 1)
+
+		require.NoError(t, err)
+		require.Len(t, moves, 2)
+		assert.Equal(t, []string{"自宅", "寝室", "金庫"}, moves[0].FromPath)
+		assert.Equal(t, []string{"銀行", "貸金庫"}, moves[0].ToPath)
+		assert.Equal(t, []string{}, moves[1].FromPath)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := usecase.GetItemMoves(context.Background(), 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemUsecase_GetLocationItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		locationID int64
+		wantIDs    []int64
+		wantPath   []string
+		wantErr    error
+	}{
+		{
+			name:       "正常系: 中にある保管場所も含める",
+			locationID: 1,
+			wantIDs:    []int64{1, 2, 3, 4},
+			wantPath:   []string{"自宅"},
+		},
+		{
+			name:       "正常系: 中に保管場所がない場合はそのまま",
+			locationID: 3,
+			wantPath:   []string{"自宅", "寝室", "金庫"},
+		},
+		{
+			name:       "異常系: 存在しない保管場所",
+			locationID: 99,
+			wantErr:    domainErrors.ErrLocationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, locationRepo: newMockLocationTree()})
+
+			matchesLocation := mock.MatchedBy(func(c ItemCriteria) bool {
+				return c.LocationID != nil && *c.LocationID == tt.locationID && assert.ObjectsAreEqual(tt.wantIDs, c.LocationIDs)
+			})
+			mockRepo.On("Count", mock.Anything, matchesLocation).Return(1, nil)
+			mockRepo.On("FindAll", mock.Anything, matchesLocation).Return([]*entity.Item{storedItem()}, nil)
+
+			result, err := usecase.GetLocationItems(context.Background(), tt.locationID, ItemCriteria{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.locationID, result.Location.ID)
+			assert.Equal(t, tt.wantPath, result.Path)
+			assert.Equal(t, intPtr(1), result.Items.Total)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItemWithLocation(t *testing.T) {
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+	}
+
+	t.Run("正常系: 保管場所を指定すると最初の移動履歴を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		locationRepo := newMockLocationTree()
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, locationRepo: locationRepo})
+
+		var saved *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*entity.Item)
+		}).Return(storedItem(), nil)
+
+		input := input
+		input.LocationID = int64Ptr(3)
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64Ptr(3), saved.LocationID)
+		require.Len(t, locationRepo.moves, 1)
+		assert.Equal(t, int64(1), locationRepo.moves[0].ItemID)
+		assert.Nil(t, locationRepo.moves[0].FromLocationID)
+		assert.Equal(t, int64Ptr(3), locationRepo.moves[0].ToLocationID)
+	})
+
+	t.Run("異常系: 存在しない保管場所", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, locationRepo: newMockLocationTree()})
+
+		input := input
+		input.LocationID = int64Ptr(99)
+		_, err := usecase.CreateItem(context.Background(), input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
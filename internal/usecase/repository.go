@@ -2,14 +2,32 @@ package usecase
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"aicon-coding-test/internal/domain/entity"
 )
 
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves items matching the criteria, sorted and paginated
+	FindAll(ctx context.Context, criteria ItemCriteria) ([]*entity.Item, error)
+
+	// FindPage はキーセット（シーク）方式で after の次の行から criteria.Limit 件を取得する
+	// after が nil の場合は先頭から取得する。criteria.Offset は無視される
+	FindPage(ctx context.Context, criteria ItemCriteria, after *ItemCursor) ([]*entity.Item, error)
+
+	// Search は criteria.Keyword で全文検索し、関連度スコア付きで返す
+	// criteria.Keyword 以外の絞り込み・並び替え・ページング条件も適用する
+	Search(ctx context.Context, criteria ItemCriteria) ([]*ItemSearchHit, error)
+
+	// Iterate は criteria に一致するアイテムを並び順に1件ずつ返すイテレーターを返す（ページングの条件は無視する）
+	// 全件をメモリに載せずに処理するため、ストリーミングやエクスポートなど件数の多い処理で使う
+	// 途中でエラーが発生した場合や ctx がキャンセルされた場合は、エラーを1回返して終了する
+	Iterate(ctx context.Context, criteria ItemCriteria) iter.Seq2[*entity.Item, error]
+
+	// Count returns the number of items matching the criteria (ignores sort and pagination)
+	Count(ctx context.Context, criteria ItemCriteria) (int, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
@@ -17,14 +35,159 @@ type ItemRepository interface {
 	// Create creates a new item and returns it with the generated ID
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Update はアイテムの特定フィールドをIDで部分更新する
-	// *string, *int はポインタ型で、nilの場合は更新対象外を意味する
+	// Update はアイテムの更新可能な全フィールドを item の値で保存する
+	// item.Version が現在のバージョンと異なる場合は ErrVersionConflict を返す
 	// 更新後のアイテムを返す
-	Update(ctx context.Context, id int64, name, brand *string, purchasePrice *int) (*entity.Item, error)
+	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
+
+	// Delete はアイテムをゴミ箱に移動する（論理削除）
+	// expectedVersion が nil でなく現在のバージョンと異なる場合は ErrVersionConflict を返す
+	Delete(ctx context.Context, id int64, expectedVersion *int) error
+
+	// FindTrashed はゴミ箱にあるアイテムを削除日時の新しい順に取得する
+	FindTrashed(ctx context.Context, limit, offset int) ([]*entity.Item, error)
+
+	// CountTrashed はゴミ箱にあるアイテムの件数を返す
+	CountTrashed(ctx context.Context) (int, error)
+
+	// FindTrashedByID はゴミ箱にあるアイテムを取得する（ゴミ箱にない場合は ErrItemNotFound）
+	FindTrashedByID(ctx context.Context, id int64) (*entity.Item, error)
+
+	// Restore はゴミ箱にあるアイテムを元に戻す（ゴミ箱にない場合は ErrItemNotFound）
+	Restore(ctx context.Context, id int64) (*entity.Item, error)
+
+	// Purge はゴミ箱にあるアイテムを完全に削除する（ゴミ箱にない場合は ErrItemNotFound、売却の記録がある場合は ErrItemSold）
+	Purge(ctx context.Context, id int64) error
+
+	// FindTrashedBefore は before より前にゴミ箱に移動したアイテムを取得する（売却の記録があるアイテムは除く）
+	FindTrashedBefore(ctx context.Context, before time.Time) ([]*entity.Item, error)
+
+	// CountByField は criteria に一致するアイテムを field の値ごとに集計し、件数の多い順に返す
+	// limit が 0 の場合はすべての値を返す
+	CountByField(ctx context.Context, criteria ItemCriteria, field FacetField, limit int) ([]FacetCount, error)
+
+	// CountByPriceBuckets は criteria に一致するアイテムを価格帯ごとに集計し、buckets と同じ順で件数を返す
+	CountByPriceBuckets(ctx context.Context, criteria ItemCriteria, buckets []PriceBucket) ([]int, error)
 
-	// Delete deletes an item by ID
+	// SumByCategory はゴミ箱以外で statuses のいずれかの所有状況のアイテムをカテゴリーごとに集計し、件数と購入価格の合計を返す
+	SumByCategory(ctx context.Context, statuses []string) ([]CategoryTotal, error)
+}
+
+// ItemRevisionRepository はアイテムの変更履歴を保存する
+type ItemRevisionRepository interface {
+	// Create は変更履歴を保存する。Revision にはアイテムごとの次の連番を採番して設定する
+	Create(ctx context.Context, revision *entity.ItemRevision) error
+
+	// FindByItemID はアイテムの変更履歴を新しい順にすべて返す
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error)
+
+	// FindByRevision は指定した連番の変更履歴を返す（存在しない場合は ErrRevisionNotFound）
+	FindByRevision(ctx context.Context, itemID int64, revision int) (*entity.ItemRevision, error)
+}
+
+// ItemStatusEventRepository はアイテムの所有状況の変更の記録（item_status_events テーブル）のデータアクセス
+type ItemStatusEventRepository interface {
+	// Create は変更の記録を保存し、ID と記録日時を設定する
+	Create(ctx context.Context, event *entity.ItemStatusEvent) error
+
+	// FindByItemID はアイテムの変更の記録を変更日時の新しい順にすべて返す
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemStatusEvent, error)
+}
+
+// DisposalRepository はアイテムの売却の記録（disposals テーブル）のデータアクセス
+type DisposalRepository interface {
+	// Create は売却の記録を保存し、ID と記録日時・売却損益を設定する
+	Create(ctx context.Context, disposal *entity.Disposal) error
+
+	// FindByItemID はアイテムの売却の記録を返す（記録がない場合は ErrDisposalNotFound）
+	FindByItemID(ctx context.Context, itemID int64) (*entity.Disposal, error)
+
+	// SumByYearAndCategory は売却した年・カテゴリーごとの合計を年・カテゴリーの順に返す（year が nil の場合はすべての年。ゴミ箱のアイテムも含む）
+	SumByYearAndCategory(ctx context.Context, year *int) ([]RealizedGainTotal, error)
+
+	// FindByYear は year に売却した記録をアイテムの情報とともに売却日・ID の順に返す（ゴミ箱のアイテムも含む）
+	FindByYear(ctx context.Context, year int) ([]*DisposedItem, error)
+}
+
+// Transactor は複数のリポジトリ操作を1つのトランザクションにまとめる（Unit of Work）
+// fn に渡された ctx を使ったリポジトリ操作はすべて同じトランザクションで実行され、
+// fn がエラーを返した場合はまとめてロールバックされる
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CategoryRepository はカテゴリー（categories テーブル）のデータアクセス
+type CategoryRepository interface {
+	// FindAll は無効なものも含むすべてのカテゴリーを並び順（sort_order, id の昇順）で返す
+	FindAll(ctx context.Context) ([]*entity.Category, error)
+
+	// FindByID はカテゴリーを返す（存在しない場合は ErrCategoryNotFound）
+	FindByID(ctx context.Context, id int64) (*entity.Category, error)
+
+	// LockByID はカテゴリーの行ロックを取得して返す（トランザクション内で使う）
+	// ロック中は同じカテゴリーを参照するアイテムの登録・更新が待たされる
+	LockByID(ctx context.Context, id int64) (*entity.Category, error)
+
+	// Create はカテゴリーを登録し、ID 付きで返す（コードが重複する場合は ErrDuplicateEntry）
+	Create(ctx context.Context, category *entity.Category) (*entity.Category, error)
+
+	// Update は表示名・並び順・有効フラグを保存し、更新後のカテゴリーを返す
+	Update(ctx context.Context, category *entity.Category) (*entity.Category, error)
+
+	// Delete はカテゴリーを削除する（アイテムで使われている場合は ErrCategoryInUse）
 	Delete(ctx context.Context, id int64) error
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+	// CountItems はカテゴリーを使っているアイテムの件数を返す（ゴミ箱のアイテムも含む）
+	CountItems(ctx context.Context, code string) (int, error)
+}
+
+// TagRepository はタグ（tags / item_tags テーブル）のデータアクセス
+type TagRepository interface {
+	// FindAll はすべてのタグを、付いているアイテム（ゴミ箱を除く）の件数の多い順・名前順で返す
+	FindAll(ctx context.Context) ([]TagCount, error)
+
+	// FindByID はタグを返す（存在しない場合は ErrTagNotFound）
+	FindByID(ctx context.Context, id int64) (*entity.Tag, error)
+
+	// ReplaceItemTags はアイテムのタグを names で置き換える。未登録の名前のタグは登録する
+	ReplaceItemTags(ctx context.Context, itemID int64, names []string) error
+
+	// Update はタグの名前を保存し、更新後のタグを返す（同じ名前のタグがある場合は ErrDuplicateEntry）
+	// タグが付いたアイテムの表示が変わるため、それらのアイテムのバージョンも進める
+	Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+
+	// Merge は source のタグが付いたアイテムに target のタグを付け、source のタグを削除する
+	// 対象のアイテムのバージョンも進める
+	Merge(ctx context.Context, sourceID, targetID int64) error
+}
+
+// LocationRepository は保管場所（locations テーブル）と移動履歴（item_moves テーブル）のデータアクセス
+type LocationRepository interface {
+	// FindAll はすべての保管場所を名前順（name, id の昇順）で返す
+	FindAll(ctx context.Context) ([]*entity.Location, error)
+
+	// FindByID は保管場所を返す（存在しない場合は ErrLocationNotFound）
+	FindByID(ctx context.Context, id int64) (*entity.Location, error)
+
+	// LockByID は保管場所の行ロックを取得して返す（トランザクション内で使う）
+	// ロック中は同じ保管場所への移動・保管場所の削除が待たされる
+	LockByID(ctx context.Context, id int64) (*entity.Location, error)
+
+	// Create は保管場所を登録し、ID 付きで返す
+	Create(ctx context.Context, location *entity.Location) (*entity.Location, error)
+
+	// Update は親・名前・種類を保存し、更新後の保管場所を返す
+	Update(ctx context.Context, location *entity.Location) (*entity.Location, error)
+
+	// Delete は保管場所を削除する（アイテムが置かれている場合は ErrLocationInUse）
+	Delete(ctx context.Context, id int64) error
+
+	// CountItems は保管場所に置かれているアイテムの件数を返す（ゴミ箱のアイテムも含み、中の保管場所は含まない）
+	CountItems(ctx context.Context, id int64) (int, error)
+
+	// CreateMove は移動履歴を保存し、ID と記録日時を設定する
+	CreateMove(ctx context.Context, move *entity.ItemMove) error
+
+	// FindMovesByItemID はアイテムの移動履歴を移動日時の新しい順にすべて返す
+	FindMovesByItemID(ctx context.Context, itemID int64) ([]*entity.ItemMove, error)
 }
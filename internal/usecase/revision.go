@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// GetItemRevisions はアイテムの変更履歴を新しい順に返す
+// ゴミ箱にあるアイテムの履歴も取得できる
+func (u *itemUsecase) GetItemRevisions(ctx context.Context, id int64) ([]*entity.ItemRevision, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	revisions, err := u.revisionRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+
+	// 履歴がない場合は、履歴の記録を始める前から存在するアイテム（ゴミ箱にあるものを含む）かどうかを確認する
+	if len(revisions) == 0 {
+		if _, err := u.GetItemByID(ctx, id); err != nil {
+			if !errors.Is(err, domainErrors.ErrItemNotFound) {
+				return nil, err
+			}
+			if _, err := u.itemRepo.FindTrashedByID(ctx, id); err != nil {
+				if domainErrors.IsNotFoundError(err) {
+					return nil, domainErrors.ErrItemNotFound
+				}
+				return nil, fmt.Errorf("failed to retrieve item: %w", err)
+			}
+		}
+		return []*entity.ItemRevision{}, nil
+	}
+
+	return revisions, nil
+}
+
+// GetItemRevision は指定した連番の変更履歴を返す
+func (u *itemUsecase) GetItemRevision(ctx context.Context, id int64, revision int) (*entity.ItemRevision, error) {
+	if id <= 0 || revision <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	rev, err := u.revisionRepo.FindByRevision(ctx, id, revision)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrRevisionNotFound) {
+			return nil, domainErrors.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// RevertItem はアイテムの内容を指定した変更履歴の時点に戻す
+// 履歴は書き換えず、戻した結果を新しい変更履歴（revert）として記録する
+func (u *itemUsecase) RevertItem(ctx context.Context, id int64, revision int, expectedVersion *int) (*entity.Item, error) {
+	rev, err := u.GetItemRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.assignableCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := rev.Snapshot
+	return u.modifyItem(ctx, id, expectedVersion, entity.RevisionRevert, func(item *entity.Item) error {
+		if err := item.Update(snapshot.Name, snapshot.Category, snapshot.Brand, snapshot.PurchasePrice, snapshot.PurchaseDate, snapshot.Attributes, categories); err != nil {
+			return err
+		}
+		// タグを記録する前の履歴（tags が nil）の場合は、現在のタグのままにする
+		if snapshot.Tags == nil {
+			return nil
+		}
+		return item.SetTags(snapshot.Tags)
+	})
+}
+
+// recordRevision は操作を変更履歴に記録する。書き込みと同じトランザクション内で呼び出すこと
+func (u *itemUsecase) recordRevision(ctx context.Context, action entity.RevisionAction, before, after *entity.Item) error {
+	revision := entity.NewItemRevision(action, ActorFromContext(ctx), before, after)
+	if err := u.revisionRepo.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
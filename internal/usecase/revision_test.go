@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestItemUsecase_RecordsRevisions(t *testing.T) {
+	t.Run("正常系: 登録・更新・削除がすべて記録される", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		revisionRepo := new(MockItemRevisionRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo})
+		ctx := WithActor(context.Background(), "tanaka")
+
+		created := storedItem()
+		created.Version = 1
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(created, nil)
+		_, err := usecase.CreateItem(ctx, CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01",
+		})
+		require.NoError(t, err)
+
+		updated := storedItem()
+		updated.PurchasePrice = 1200000
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updated, nil)
+		_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{PurchasePrice: intPtr(1200000)}, nil)
+		require.NoError(t, err)
+
+		mockRepo.On("Delete", mock.Anything, int64(1), (*int)(nil)).Return(nil)
+		require.NoError(t, usecase.DeleteItem(ctx, 1, nil))
+
+		require.Len(t, revisionRepo.revisions, 3)
+
+		create := revisionRepo.revisions[0]
+		assert.Equal(t, entity.RevisionCreate, create.Action)
+		assert.Equal(t, 1, create.Revision)
+		assert.Equal(t, "tanaka", create.Actor)
+		assert.Len(t, create.Changes, 7)
+
+		update := revisionRepo.revisions[1]
+		assert.Equal(t, entity.RevisionUpdate, update.Action)
+		assert.Equal(t, []entity.FieldChange{
+			{Field: "purchase_price", Before: 1000000, After: 1200000},
+		}, update.Changes)
+
+		deleted := revisionRepo.revisions[2]
+		assert.Equal(t, entity.RevisionDelete, deleted.Action)
+		assert.NotNil(t, deleted.Snapshot.DeletedAt)
+		require.Len(t, deleted.Changes, 1)
+		assert.Equal(t, "deleted_at", deleted.Changes[0].Field)
+		assert.Nil(t, deleted.Changes[0].Before)
+	})
+
+	t.Run("正常系: 操作者が未設定の場合は anonymous", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		revisionRepo := new(MockItemRevisionRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo})
+
+		trashed := storedItem()
+		deletedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		trashed.DeletedAt = &deletedAt
+		restored := storedItem()
+		restored.Version = trashed.Version + 1
+		mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashed, nil)
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(restored, nil)
+		_, err := usecase.RestoreItem(context.Background(), 1)
+		require.NoError(t, err)
+
+		require.Len(t, revisionRepo.revisions, 1)
+		assert.Equal(t, entity.RevisionRestore, revisionRepo.revisions[0].Action)
+		assert.Equal(t, AnonymousActor, revisionRepo.revisions[0].Actor)
+		// 復元前の削除日時からの差分が記録される
+		assert.Equal(t, []entity.FieldChange{
+			{Field: "deleted_at", Before: deletedAt, After: nil},
+		}, revisionRepo.revisions[0].Changes)
+	})
+
+	t.Run("異常系: 履歴の記録に失敗した場合は更新もロールバックされる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		revisionRepo := &MockItemRevisionRepository{err: domainErrors.ErrDatabaseError}
+		transactor := new(MockTransactor)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo, transactor: transactor})
+
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(storedItem(), nil)
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Name: stringPtr("時計2")}, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Error(t, transactor.err)
+	})
+}
+
+func TestItemUsecase_GetItemRevisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        int64
+		revisions []*entity.ItemRevision
+		setupMock func(*MockItemRepository)
+		wantLen   int
+		wantErr   error
+	}{
+		{
+			name: "正常系: 新しい順に返す",
+			id:   1,
+			revisions: []*entity.ItemRevision{
+				{ItemID: 1, Action: entity.RevisionCreate},
+				{ItemID: 1, Action: entity.RevisionUpdate},
+				{ItemID: 2, Action: entity.RevisionCreate},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantLen:   2,
+		},
+		{
+			name: "正常系: 履歴の記録を始める前からあるアイテム",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			wantLen: 0,
+		},
+		{
+			name: "正常系: 履歴の記録を始める前からあるゴミ箱のアイテム",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			wantLen: 0,
+		},
+		{
+			name: "異常系: アイテムが存在しない",
+			id:   999,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			wantErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:      "異常系: 無効なID",
+			id:        0,
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			revisionRepo := new(MockItemRevisionRepository)
+			for _, r := range tt.revisions {
+				require.NoError(t, revisionRepo.Create(context.Background(), r))
+			}
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo})
+
+			revisions, err := usecase.GetItemRevisions(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, revisions, tt.wantLen)
+			if tt.wantLen > 1 {
+				assert.Greater(t, revisions[0].Revision, revisions[1].Revision)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RevertItem(t *testing.T) {
+	newUsecase := func(mockRepo *MockItemRepository) (ItemUsecase, *MockItemRevisionRepository) {
+		revisionRepo := new(MockItemRevisionRepository)
+		original := storedItem()
+		original.Version = 1
+		require.NoError(t, revisionRepo.Create(context.Background(), entity.NewItemRevision(entity.RevisionCreate, "tanaka", nil, original)))
+		return newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo}), revisionRepo
+	}
+
+	t.Run("正常系: 指定した時点の内容に戻し、revert として記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase, revisionRepo := newUsecase(mockRepo)
+
+		current := storedItem()
+		current.Name = "誤って変更した名前"
+		current.PurchasePrice = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "時計1" && item.PurchasePrice == 1000000
+		})).Return(storedItem(), nil)
+
+		item, err := usecase.RevertItem(context.Background(), 1, 1, intPtr(3))
+
+		require.NoError(t, err)
+		assert.Equal(t, "時計1", item.Name)
+		require.Len(t, revisionRepo.revisions, 2)
+		revert := revisionRepo.revisions[1]
+		assert.Equal(t, entity.RevisionRevert, revert.Action)
+		assert.Equal(t, 2, revert.Revision)
+		assert.Len(t, revert.Changes, 2)
+	})
+
+	t.Run("異常系: 変更履歴が存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase, _ := newUsecase(mockRepo)
+
+		_, err := usecase.RevertItem(context.Background(), 1, 5, nil)
+
+		assert.True(t, errors.Is(err, domainErrors.ErrRevisionNotFound))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: バージョンが一致しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase, _ := newUsecase(mockRepo)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+
+		_, err := usecase.RevertItem(context.Background(), 1, 1, intPtr(1))
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/domain/search"
+)
+
+// ハイライト部分を囲むタグ
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+// ItemSearchHit は検索結果の1件
+// Score は全文検索の関連度、Highlights は一致箇所を <mark> で囲んだフィールドの値
+type ItemSearchHit struct {
+	*entity.Item
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// ItemSearchResult はページング付きの検索結果
+type ItemSearchResult struct {
+	Query  string           `json:"query"`
+	Items  []*ItemSearchHit `json:"items"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+	Facets *ItemFacets      `json:"facets,omitempty"`
+}
+
+// SearchItems は名前・ブランドを全文検索し、関連度順に返す
+// 全角/半角・大文字/小文字・アクセント・ひらがな/カタカナの違いは区別しない
+func (u *itemUsecase) SearchItems(ctx context.Context, criteria ItemCriteria) (*ItemSearchResult, error) {
+	criteria.Keyword = strings.TrimSpace(criteria.Keyword)
+	terms := search.Terms(criteria.Keyword)
+	if len(terms) == 0 {
+		return nil, domainErrors.NewInputError(domainErrors.CodeRequired, "q is required", map[string]interface{}{"name": "q"})
+	}
+	if criteria.After != nil {
+		return nil, domainErrors.NewInputError(domainErrors.CodeConflictingParams, "cursor is not supported for search", map[string]interface{}{"name": "cursor", "other": "q"})
+	}
+	if len(criteria.Sort) == 0 {
+		criteria.Sort = []SortField{{Field: "relevance", Desc: true}}
+	}
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := u.expandCategoryFilter(ctx, &criteria); err != nil {
+		return nil, err
+	}
+	if err := u.expandLocationFilter(ctx, &criteria); err != nil {
+		return nil, err
+	}
+
+	hits, err := u.itemRepo.Search(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+
+	total, err := u.itemRepo.Count(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	for _, hit := range hits {
+		hit.Highlights = highlightItem(hit.Item, terms)
+	}
+	if hits == nil {
+		hits = []*ItemSearchHit{}
+	}
+
+	result := &ItemSearchResult{
+		Query:  criteria.Keyword,
+		Items:  hits,
+		Total:  total,
+		Limit:  criteria.Limit,
+		Offset: criteria.Offset,
+	}
+	if criteria.Facets != nil {
+		result.Facets, err = u.computeFacets(ctx, criteria, *criteria.Facets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// highlightItem は検索対象フィールドのうち一致したものだけハイライトを作成する
+func highlightItem(item *entity.Item, terms []string) map[string]string {
+	highlights := make(map[string]string)
+
+	if snippet, ok := search.Highlight(item.Name, terms, highlightOpen, highlightClose); ok {
+		highlights["name"] = snippet
+	}
+	if snippet, ok := search.Highlight(item.Brand, terms, highlightOpen, highlightClose); ok {
+		highlights["brand"] = snippet
+	}
+
+	return highlights
+}
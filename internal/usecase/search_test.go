@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestItemUsecase_SearchItems(t *testing.T) {
+	tests := []struct {
+		name           string
+		criteria       ItemCriteria
+		setupMock      func(*MockItemRepository)
+		wantHighlights map[string]string
+		expectedErr    error
+	}{
+		{
+			name:     "正常系: 半角カナの検索語で一致箇所をハイライト",
+			criteria: ItemCriteria{Keyword: "ｴﾙﾒｽ"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("エルメス バーキン", "バッグ", "HERMÈS", 2000000, "2023-02-20", nil, entity.FixedCategories)
+				expected := mock.MatchedBy(func(c ItemCriteria) bool {
+					return c.Keyword == "ｴﾙﾒｽ" && len(c.Sort) == 1 && c.Sort[0] == SortField{Field: "relevance", Desc: true}
+				})
+				mockRepo.On("Search", mock.Anything, expected).Return([]*ItemSearchHit{{Item: item, Score: 1.5}}, nil)
+				mockRepo.On("Count", mock.Anything, expected).Return(1, nil)
+			},
+			wantHighlights: map[string]string{"name": "<mark>エルメス</mark> バーキン"},
+		},
+		{
+			name:     "正常系: アクセントなしの検索語でブランドをハイライト",
+			criteria: ItemCriteria{Keyword: "hermes"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("エルメス バーキン", "バッグ", "HERMÈS", 2000000, "2023-02-20", nil, entity.FixedCategories)
+				mockRepo.On("Search", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return([]*ItemSearchHit{{Item: item, Score: 1.2}}, nil)
+				mockRepo.On("Count", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(1, nil)
+			},
+			wantHighlights: map[string]string{"brand": "<mark>HERMÈS</mark>"},
+		},
+		{
+			name:     "異常系: 検索語が空",
+			criteria: ItemCriteria{Keyword: "   "},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// バリデーションでエラーになるため、リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: データベースエラー",
+			criteria: ItemCriteria{Keyword: "rolex"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Search", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(([]*ItemSearchHit)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			result, err := usecase.SearchItems(context.Background(), tt.criteria)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Items, 1)
+			assert.Equal(t, tt.wantHighlights, result.Items[0].Highlights)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
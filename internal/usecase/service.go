@@ -3,60 +3,186 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"strings"
+	"iter"
+	"slices"
+	"time"
 
 	"aicon-coding-test/internal/domain/entity"
 	domainErrors "aicon-coding-test/internal/domain/errors"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	GetAllItems(ctx context.Context, criteria ItemCriteria) (*ItemList, error)
+	SearchItems(ctx context.Context, criteria ItemCriteria) (*ItemSearchResult, error)
+	StreamItems(ctx context.Context, criteria ItemCriteria) (iter.Seq2[*entity.Item, error], error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
-	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
-	DeleteItem(ctx context.Context, id int64) error
-	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	ImportItems(ctx context.Context, input ImportItemsInput) (*ImportReport, error)
+	// expectedVersion が nil でない場合は、現在のバージョンと一致するときのみ更新・削除する
+	UpdateItem(ctx context.Context, id int64, input UpdateItemInput, expectedVersion *int) (*entity.Item, error)
+	ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput, expectedVersion *int) (*entity.Item, error)
+	DeleteItem(ctx context.Context, id int64, expectedVersion *int) error
+	GetTrashedItems(ctx context.Context, limit, offset int) (*ItemList, error)
+	RestoreItem(ctx context.Context, id int64) (*entity.Item, error)
+	PurgeItem(ctx context.Context, id int64) error
+	PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int64, error)
+	GetItemRevisions(ctx context.Context, id int64) ([]*entity.ItemRevision, error)
+	GetItemRevision(ctx context.Context, id int64, revision int) (*entity.ItemRevision, error)
+	RevertItem(ctx context.Context, id int64, revision int, expectedVersion *int) (*entity.Item, error)
+	// SetItemTags はアイテムのタグを tags で置き換える（更新と同じくバージョンが進み、変更履歴に記録される）
+	SetItemTags(ctx context.Context, id int64, tags []string, expectedVersion *int) (*entity.Item, error)
+	// MoveItem はアイテムを別の保管場所に移動し、移動履歴を記録する
+	MoveItem(ctx context.Context, id int64, input MoveItemInput, expectedVersion *int) (*entity.Item, error)
+	GetItemMoves(ctx context.Context, id int64) ([]*entity.ItemMove, error)
+	GetLocationItems(ctx context.Context, locationID int64, criteria ItemCriteria) (*LocationItems, error)
+	// GetCategorySummary はカテゴリー別集計を返す（statuses を省略した場合は現在の所有資産のみ）
+	GetCategorySummary(ctx context.Context, statuses []string) (*CategorySummary, error)
+	// ChangeItemStatus は所有状況を遷移表に従って変更し、変更の記録を残す
+	ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput, expectedVersion *int) (*entity.Item, error)
+	GetItemStatusEvents(ctx context.Context, id int64) ([]*entity.ItemStatusEvent, error)
+	// SellItem はアイテムの売却を記録し、所有状況を sold に変更する
+	SellItem(ctx context.Context, id int64, input SellItemInput, expectedVersion *int) (*ItemSale, error)
+	GetItemDisposal(ctx context.Context, id int64) (*entity.Disposal, error)
+	// GetRealizedGains は売却損益を年・カテゴリーごとに集計する（year が nil の場合はすべての年）
+	GetRealizedGains(ctx context.Context, year *int) (*RealizedGainReport, error)
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice int               `json:"purchase_price"`
+	PurchaseDate  string            `json:"purchase_date"`
+	Attributes    entity.Attributes `json:"attributes"`  // カテゴリーのカスタム属性（省略可）
+	Tags          []string          `json:"tags"`        // タグ名（省略可）
+	LocationID    *int64            `json:"location_id"` // 保管場所（省略可。指定した場合は最初の移動履歴を記録する）
 }
 
 // UpdateItemInput はPATCHリクエストで使用する構造体
 // *string, *int はポインタ型で、nilの場合は更新対象外を意味する
 // omitemptyタグにより、JSONで空の場合はフィールドが省略される
 type UpdateItemInput struct {
-	Name          *string `json:"name,omitempty"`          // アイテム名（オプショナル）
-	Brand         *string `json:"brand,omitempty"`         // ブランド名（オプショナル）
+	Name          *string `json:"name,omitempty"`           // アイテム名（オプショナル）
+	Category      *string `json:"category,omitempty"`       // カテゴリー（オプショナル）
+	Brand         *string `json:"brand,omitempty"`          // ブランド名（オプショナル）
 	PurchasePrice *int    `json:"purchase_price,omitempty"` // 購入価格（オプショナル）
+	PurchaseDate  *string `json:"purchase_date,omitempty"`  // 購入日（オプショナル）
+	// カスタム属性（オプショナル）。送信したキーだけを更新し、値が null のキーは削除する
+	Attributes entity.Attributes `json:"attributes,omitempty"`
 }
 
-type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+// ReplaceItemInput はPUTリクエストで使用する構造体
+// 全フィールドが必須で、アイテムの内容を丸ごと置き換える
+type ReplaceItemInput struct {
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice int               `json:"purchase_price"`
+	PurchaseDate  string            `json:"purchase_date"`
+	Attributes    entity.Attributes `json:"attributes"` // 省略した場合は属性をすべて削除する
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo      ItemRepository
+	revisionRepo  ItemRevisionRepository
+	categoryRepo  CategoryRepository
+	categoryCache *CategoryCache
+	tagRepo       TagRepository
+	locationRepo  LocationRepository
+	statusRepo    ItemStatusEventRepository
+	disposalRepo  DisposalRepository
+	transactor    Transactor
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
+// categoryCache はアイテムのカテゴリーと属性の検証に使う（CategoryUsecase と同じものを渡し、カテゴリーの変更をすぐに反映する）
+func NewItemUsecase(itemRepo ItemRepository, revisionRepo ItemRevisionRepository, categoryRepo CategoryRepository, categoryCache *CategoryCache, tagRepo TagRepository, locationRepo LocationRepository, statusRepo ItemStatusEventRepository, disposalRepo DisposalRepository, transactor Transactor) ItemUsecase {
 	return &itemUsecase{
-		itemRepo: itemRepo,
+		itemRepo:      itemRepo,
+		revisionRepo:  revisionRepo,
+		categoryRepo:  categoryRepo,
+		categoryCache: categoryCache,
+		tagRepo:       tagRepo,
+		locationRepo:  locationRepo,
+		statusRepo:    statusRepo,
+		disposalRepo:  disposalRepo,
+		transactor:    transactor,
 	}
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+// assignableCategories はアイテムのバリデーションに使うカテゴリーを返す
+// キャッシュが期限切れの場合は、リクエストの ctx でデータベースから読み込み直す
+func (u *itemUsecase) assignableCategories(ctx context.Context) (entity.CategoryChecker, error) {
+	tree, err := u.categoryCache.Tree(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+	return tree, nil
+}
+
+func (u *itemUsecase) GetAllItems(ctx context.Context, criteria ItemCriteria) (*ItemList, error) {
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := u.expandCategoryFilter(ctx, &criteria); err != nil {
+		return nil, err
+	}
+	if err := u.expandLocationFilter(ctx, &criteria); err != nil {
+		return nil, err
 	}
 
-	return items, nil
+	var items []*entity.Item
+	var total *int
+	var hasMore bool
+	var err error
+	if criteria.After != nil {
+		// キーセットページングでは読み飛ばす行がないため、件数も数えない（大きなテーブルでは COUNT が重い）
+		// 次ページの有無を判定するため1件多く取得する
+		pageCriteria := criteria
+		pageCriteria.Limit = criteria.Limit + 1
+		items, err = u.itemRepo.FindPage(ctx, pageCriteria, criteria.After)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		hasMore = len(items) > criteria.Limit
+		if hasMore {
+			items = items[:criteria.Limit]
+		}
+	} else {
+		count, err := u.itemRepo.Count(ctx, criteria)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count items: %w", err)
+		}
+		total = &count
+
+		items, err = u.itemRepo.FindAll(ctx, criteria)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		hasMore = criteria.Offset+len(items) < count
+	}
+
+	// 0件の場合も null ではなく空配列を返す
+	if items == nil {
+		items = []*entity.Item{}
+	}
+
+	list := &ItemList{
+		Items:  items,
+		Total:  total,
+		Limit:  criteria.Limit,
+		Offset: criteria.Offset,
+	}
+	if hasMore && len(items) > 0 && IsKeysetSort(criteria.Sort) {
+		list.NextCursor = newItemCursor(criteria, items[len(items)-1])
+	}
+	if criteria.Facets != nil {
+		list.Facets, err = u.computeFacets(ctx, criteria, *criteria.Facets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
 }
 
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
@@ -76,6 +202,11 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 }
 
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	categories, err := u.assignableCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// バリデーションして、新しいエンティティを作成
 	item, err := entity.NewItem(
 		input.Name,
@@ -83,12 +214,42 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 		input.Brand,
 		input.PurchasePrice,
 		input.PurchaseDate,
+		input.Attributes,
+		categories,
 	)
+	if err == nil {
+		err = item.SetTags(input.Tags)
+	}
+	var move *entity.ItemMove
+	if err == nil && input.LocationID != nil {
+		move, err = item.MoveTo(*input.LocationID, "", time.Time{})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
 	}
 
-	createdItem, err := u.itemRepo.Create(ctx, item)
+	var createdItem *entity.Item
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if move != nil {
+			if err := u.lockLocation(ctx, *move.ToLocationID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		createdItem, err = u.createItem(ctx, item)
+		if err != nil {
+			return err
+		}
+		if move != nil {
+			move.ItemID = createdItem.ID
+			move.Actor = ActorFromContext(ctx)
+			if err := u.locationRepo.CreateMove(ctx, move); err != nil {
+				return err
+			}
+		}
+		return u.recordRevision(ctx, entity.RevisionCreate, nil, createdItem)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create item: %w", err)
 	}
@@ -97,9 +258,9 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 }
 
 // UpdateItem はアイテムの部分更新を行うユースケース関数
-// 更新対象フィールド: name, brand, purchase_price
-// 不変フィールド: id, category, purchase_date, created_at, updated_at
-func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
+// 更新対象フィールド: name, category, brand, purchase_price, purchase_date, attributes
+// 不変フィールド: id, created_at, updated_at
+func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput, expectedVersion *int) (*entity.Item, error) {
 	// IDのバリデーション（0以下は無効）
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
@@ -107,116 +268,219 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 
 	// 更新対象のフィールドが一つでもあるかチェック
 	// 全てnilの場合は更新するものがないのでエラー
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		return nil, fmt.Errorf("%w: no fields to update", domainErrors.ErrInvalidInput)
+	if input.Name == nil && input.Category == nil && input.Brand == nil && input.PurchasePrice == nil && input.PurchaseDate == nil && input.Attributes == nil {
+		return nil, domainErrors.NewInputError(domainErrors.CodeNoFieldsToUpdate, "no fields to update", nil)
 	}
 
 	// 入力値のバリデーション（空文字、長さ、負の値など）
 	if err := validateUpdateItemInput(input); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
 	}
 
-	// リポジトリ層のUpdate関数を呼び出してデータベースを更新
-	updatedItem, err := u.itemRepo.Update(ctx, id, input.Name, input.Brand, input.PurchasePrice)
+	categories, err := u.assignableCategories(ctx)
 	if err != nil {
-		// アイテムが存在しない場合のエラーハンドリング
-		if domainErrors.IsNotFoundError(err) {
-			return nil, domainErrors.ErrItemNotFound
+		return nil, err
+	}
+
+	// 現在のアイテムに送信されたフィールドだけを重ねて、全体として保存する
+	return u.modifyItem(ctx, id, expectedVersion, entity.RevisionUpdate, func(item *entity.Item) error {
+		name, category, brand, price, date := item.Name, item.Category, item.Brand, item.PurchasePrice, item.PurchaseDate
+		if input.Name != nil {
+			name = *input.Name
 		}
-		// その他のデータベースエラー
-		return nil, fmt.Errorf("failed to update item: %w", err)
+		if input.Category != nil {
+			category = *input.Category
+		}
+		if input.Brand != nil {
+			brand = *input.Brand
+		}
+		if input.PurchasePrice != nil {
+			price = *input.PurchasePrice
+		}
+		if input.PurchaseDate != nil {
+			date = *input.PurchaseDate
+		}
+		return item.Update(name, category, brand, price, date, item.Attributes.Merge(input.Attributes), categories)
+	})
+}
+
+// ReplaceItem はアイテムの内容を丸ごと置き換えるユースケース関数（PUT）
+// id と created_at は保持されるため、カテゴリーや購入日の誤りも作り直さずに修正できる
+func (u *itemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput, expectedVersion *int) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
 	}
 
-	// 更新成功時は更新されたアイテムを返す
-	return updatedItem, nil
+	categories, err := u.assignableCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.modifyItem(ctx, id, expectedVersion, entity.RevisionUpdate, func(item *entity.Item) error {
+		return item.Update(input.Name, input.Category, input.Brand, input.PurchasePrice, input.PurchaseDate, input.Attributes, categories)
+	})
 }
 
-func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+// SetItemTags はアイテムのタグを丸ごと置き換える（PUT /items/{id}/tags）
+// タグもアイテムの内容の一部として扱うため、バージョンが進み、変更履歴（update）に記録される
+func (u *itemUsecase) SetItemTags(ctx context.Context, id int64, tags []string, expectedVersion *int) (*entity.Item, error) {
 	if id <= 0 {
-		return domainErrors.ErrInvalidInput
+		return nil, domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
+	return u.modifyItem(ctx, id, expectedVersion, entity.RevisionUpdate, func(item *entity.Item) error {
+		return item.SetTags(tags)
+	})
+}
+
+// createItem はアイテムとタグを登録する。トランザクション内で呼び出すこと
+func (u *itemUsecase) createItem(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	created, err := u.itemRepo.Create(ctx, item)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return domainErrors.ErrItemNotFound
+		return nil, err
+	}
+	if len(item.Tags) > 0 {
+		if err := u.tagRepo.ReplaceItemTags(ctx, created.ID, item.Tags); err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("failed to check item existence: %w", err)
+		created.Tags = item.Tags
 	}
+	return created, nil
+}
+
+// modifyItem は現在のアイテムを取得して apply で変更し、変更履歴（action）と合わせて1トランザクションで保存する
+// 保存時は取得したバージョンを条件にするため、取得後に他のリクエストで更新されていた場合は ErrVersionConflict になる
+func (u *itemUsecase) modifyItem(ctx context.Context, id int64, expectedVersion *int, action entity.RevisionAction, apply func(item *entity.Item) error) (*entity.Item, error) {
+	var updatedItem *entity.Item
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && item.Version != *expectedVersion {
+			return domainErrors.ErrVersionConflict
+		}
+
+		// エンティティのバリデーションで全フィールドを検証する
+		before := *item
+		if err := apply(item); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		// タグは別テーブルのため、変わった場合のみ先に保存する（Update の再取得に反映される）
+		if !slices.Equal(before.Tags, item.Tags) {
+			if err := u.tagRepo.ReplaceItemTags(ctx, id, item.Tags); err != nil {
+				return err
+			}
+		}
 
-	err = u.itemRepo.Delete(ctx, id)
+		updatedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+		return u.recordRevision(ctx, action, &before, updatedItem)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+		// アイテムが存在しない場合のエラーハンドリング
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		// 他のリクエストで先に更新されていた場合
+		if domainErrors.IsConflictError(err) {
+			return nil, domainErrors.ErrVersionConflict
+		}
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		// その他のデータベースエラー
+		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
 
-	return nil
+	return updatedItem, nil
 }
 
-func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get category summary: %w", err)
+// DeleteItem はアイテムをゴミ箱に移動する
+// ゴミ箱のアイテムは RestoreItem で元に戻すか、PurgeItem / PurgeExpiredTrash で完全に削除する
+func (u *itemUsecase) DeleteItem(ctx context.Context, id int64, expectedVersion *int) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
 	}
 
-	// 合計計算
-	total := 0
-	for _, count := range categoryCounts {
-		total += count
-	}
+	// 存在確認と削除を1トランザクションで行う
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to check item existence: %w", err)
+		}
+		if expectedVersion != nil && item.Version != *expectedVersion {
+			return domainErrors.ErrVersionConflict
+		}
 
-	summary := make(map[string]int)
-	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
+		err = u.itemRepo.Delete(ctx, id, expectedVersion)
+		if err != nil {
+			if domainErrors.IsConflictError(err) {
+				return domainErrors.ErrVersionConflict
+			}
+			return fmt.Errorf("failed to delete item: %w", err)
 		}
-	}
 
-	return &CategorySummary{
-		Categories: summary,
-		Total:      total,
-	}, nil
+		// 削除時点の内容を記録する（削除でバージョンが1つ進む）
+		deleted := *item
+		deletedAt := time.Now()
+		deleted.Version++
+		deleted.DeletedAt = &deletedAt
+		return u.recordRevision(ctx, entity.RevisionDelete, item, &deleted)
+	})
 }
 
 // validateUpdateItemInput はUpdateItemInputのバリデーションを行う関数
 // nilでないフィールドのみをチェックする（部分更新対応）
+// エラーはフィールドごとに domainErrors.ValidationErrors で返す
 func validateUpdateItemInput(input UpdateItemInput) error {
-	// エラーメッセージを格納するスライス
-	var errs []string
+	// フィールドごとのエラーを格納するスライス
+	var errs domainErrors.ValidationErrors
 
 	// Nameがnilでない（更新対象）の場合のバリデーション
 	if input.Name != nil {
-		if *input.Name == "" {
+		// エンティティと同じく正規化してから検証する（空白のみの場合は空文字になる）
+		name := entity.NormalizeText(*input.Name)
+		if name == "" {
 			// 空文字は禁止
-			errs = append(errs, "name cannot be empty")
-		} else if len(*input.Name) > 100 {
-			// 100文字を超えるのは禁止
-			errs = append(errs, "name must be 100 characters or less")
+			errs.Add("name", domainErrors.CodeRequired, "name cannot be empty", nil)
+		} else if entity.TextLength(name) > entity.MaxNameLength {
+			// 100文字を超えるのは禁止（バイト数ではなく文字数で数える）
+			errs.Add("name", domainErrors.CodeTooLong, "name must be 100 characters or less", map[string]interface{}{"max": entity.MaxNameLength})
 		}
 	}
 
 	// Brandがnilでない（更新対象）の場合のバリデーション
 	if input.Brand != nil {
-		if *input.Brand == "" {
+		brand := entity.NormalizeText(*input.Brand)
+		if brand == "" {
 			// 空文字は禁止
-			errs = append(errs, "brand cannot be empty")
-		} else if len(*input.Brand) > 100 {
-			// 100文字を超えるのは禁止
-			errs = append(errs, "brand must be 100 characters or less")
+			errs.Add("brand", domainErrors.CodeRequired, "brand cannot be empty", nil)
+		} else if entity.TextLength(brand) > entity.MaxNameLength {
+			// 100文字を超えるのは禁止（バイト数ではなく文字数で数える）
+			errs.Add("brand", domainErrors.CodeTooLong, "brand must be 100 characters or less", map[string]interface{}{"max": entity.MaxNameLength})
 		}
 	}
 
-	// PurchasePriceがnilでない（更新対象）かつ負の値の場合はエラー
-	if input.PurchasePrice != nil && *input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	// Category, PurchaseDate は空文字のみここで弾き、値の妥当性はエンティティで検証する
+	if input.Category != nil && entity.NormalizeText(*input.Category) == "" {
+		errs.Add("category", domainErrors.CodeRequired, "category cannot be empty", nil)
+	}
+	if input.PurchaseDate != nil && entity.NormalizeText(*input.PurchaseDate) == "" {
+		errs.Add("purchase_date", domainErrors.CodeRequired, "purchase_date cannot be empty", nil)
 	}
 
-	// エラーがある場合はカンマ区切りで連結して返す
-	if len(errs) > 0 {
-		return fmt.Errorf(strings.Join(errs, ", "))
+	// PurchasePriceがnilでない（更新対象）かつ負の値の場合はエラー
+	if input.PurchasePrice != nil && *input.PurchasePrice < 0 {
+		errs.Add("purchase_price", domainErrors.CodeTooSmall, "purchase_price must be 0 or greater", map[string]interface{}{"min": 0})
 	}
 
 	// エラーがない場合はnilを返す
-	return nil
+	return errs.Err()
 }
@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"iter"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -21,13 +24,50 @@ type MockItemRepository struct {
 
 // FindAll はモック版の全アイテム取得関数
 // m.Called(ctx) でモックが呼ばれたことを記録し、事前に設定された戻り値を返す
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx) // モックの呼び出しを記録
+func (m *MockItemRepository) FindAll(ctx context.Context, criteria ItemCriteria) ([]*entity.Item, error) {
+	args := m.Called(ctx, criteria) // モックの呼び出しを記録
 	// args.Get(0) で最初の戻り値（アイテムスライス）を取得
 	// args.Error(1) で2番目の戻り値（エラー）を取得
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindPage(ctx context.Context, criteria ItemCriteria, after *ItemCursor) ([]*entity.Item, error) {
+	args := m.Called(ctx, criteria, after)
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) Search(ctx context.Context, criteria ItemCriteria) ([]*ItemSearchHit, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).([]*ItemSearchHit), args.Error(1)
+}
+
+// Iterate は設定されたアイテムを順に返し、最後にエラーが設定されていればそれを返す
+// 実装と同様に、ctx がキャンセルされた場合はその時点でエラーを返して終了する
+func (m *MockItemRepository) Iterate(ctx context.Context, criteria ItemCriteria) iter.Seq2[*entity.Item, error] {
+	args := m.Called(ctx, criteria)
+	items, _ := args.Get(0).([]*entity.Item)
+	err := args.Error(1)
+	return func(yield func(*entity.Item, error) bool) {
+		for _, item := range items {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				yield(nil, ctxErr)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (m *MockItemRepository) Count(ctx context.Context, criteria ItemCriteria) (int, error) {
+	args := m.Called(ctx, criteria)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -46,9 +86,9 @@ func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*en
 
 // Update はモック版のアイテム更新関数（今回追加した関数）
 // 実際のデータベース更新は行わず、テスト用の動作をシミュレートする
-func (m *MockItemRepository) Update(ctx context.Context, id int64, name, brand *string, purchasePrice *int) (*entity.Item, error) {
+func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	// モックの呼び出しを記録（全ての引数を渡す）
-	args := m.Called(ctx, id, name, brand, purchasePrice)
+	args := m.Called(ctx, item)
 	// 戻り値がnilの場合（エラーケース）
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -57,57 +97,420 @@ func (m *MockItemRepository) Update(ctx context.Context, id int64, name, brand *
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
+func (m *MockItemRepository) Delete(ctx context.Context, id int64, expectedVersion *int) error {
+	args := m.Called(ctx, id, expectedVersion)
 	return args.Error(0)
 }
 
-func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+func (m *MockItemRepository) CountByField(ctx context.Context, criteria ItemCriteria, field FacetField, limit int) ([]FacetCount, error) {
+	args := m.Called(ctx, criteria, field, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]FacetCount), args.Error(1)
+}
+
+func (m *MockItemRepository) SumByCategory(ctx context.Context, statuses []string) ([]CategoryTotal, error) {
+	args := m.Called(ctx, statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CategoryTotal), args.Error(1)
+}
+
+func (m *MockItemRepository) CountByPriceBuckets(ctx context.Context, criteria ItemCriteria, buckets []PriceBucket) ([]int, error) {
+	args := m.Called(ctx, criteria, buckets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockItemRepository) FindTrashed(ctx context.Context, limit, offset int) ([]*entity.Item, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) CountTrashed(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]int), args.Error(1)
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) Restore(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) Purge(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) FindTrashedBefore(ctx context.Context, before time.Time) ([]*entity.Item, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+// MockTransactor は実際のトランザクションを張らずに fn をそのまま実行するモック
+// 呼び出し回数と、fn が返したエラー（nil でなければ本来はロールバックされる）を記録する
+type MockTransactor struct {
+	calls int
+	err   error
+}
+
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	m.err = fn(ctx)
+	return m.err
+}
+
+// MockItemRevisionRepository は記録された変更履歴をメモリに保持するモック
+// err を設定すると Create がそのエラーを返す
+type MockItemRevisionRepository struct {
+	revisions []*entity.ItemRevision
+	err       error
+}
+
+func (m *MockItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) error {
+	if m.err != nil {
+		return m.err
+	}
+	revision.Revision = len(m.revisionsOf(revision.ItemID)) + 1
+	m.revisions = append(m.revisions, revision)
+	return nil
+}
+
+func (m *MockItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	revisions := m.revisionsOf(itemID)
+	// 新しい順に返す
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+	return revisions, nil
+}
+
+func (m *MockItemRevisionRepository) FindByRevision(ctx context.Context, itemID int64, revision int) (*entity.ItemRevision, error) {
+	for _, r := range m.revisionsOf(itemID) {
+		if r.Revision == revision {
+			return r, nil
+		}
+	}
+	return nil, domainErrors.ErrRevisionNotFound
+}
+
+func (m *MockItemRevisionRepository) revisionsOf(itemID int64) []*entity.ItemRevision {
+	var revisions []*entity.ItemRevision
+	for _, r := range m.revisions {
+		if r.ItemID == itemID {
+			revisions = append(revisions, r)
+		}
+	}
+	return revisions
+}
+
+// MockCategoryRepository はカテゴリーをメモリに保持するモック
+// itemCounts にコードごとのアイテム件数を設定すると CountItems がその値を返す
+// err を設定するとすべてのメソッドがそのエラーを返す
+type MockCategoryRepository struct {
+	categories   []*entity.Category
+	itemCounts   map[string]int
+	findAllCalls int
+	err          error
+}
+
+// newMockCategoryRepository はデフォルトの5カテゴリーを登録したモックを返す
+func newMockCategoryRepository() *MockCategoryRepository {
+	m := &MockCategoryRepository{itemCounts: make(map[string]int)}
+	for i, code := range entity.ValidCategories {
+		m.categories = append(m.categories, &entity.Category{
+			ID:        int64(i + 1),
+			Code:      code,
+			NameJa:    code,
+			NameEn:    code,
+			SortOrder: (i + 1) * 10,
+			Active:    true,
+		})
+	}
+	return m
+}
+
+func (m *MockCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	m.findAllCalls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	categories := make([]*entity.Category, len(m.categories))
+	for i, c := range m.categories {
+		copied := *c
+		categories[i] = &copied
+	}
+	return categories, nil
+}
+
+func (m *MockCategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, c := range m.categories {
+		if c.ID == id {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrCategoryNotFound
+}
+
+func (m *MockCategoryRepository) LockByID(ctx context.Context, id int64) (*entity.Category, error) {
+	return m.FindByID(ctx, id)
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, c := range m.categories {
+		if c.Code == category.Code {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+	}
+	created := *category
+	created.ID = int64(len(m.categories) + 1)
+	m.categories = append(m.categories, &created)
+	return &created, nil
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for i, c := range m.categories {
+		if c.ID == category.ID {
+			updated := *category
+			m.categories[i] = &updated
+			return &updated, nil
+		}
+	}
+	return nil, domainErrors.ErrCategoryNotFound
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, id int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i, c := range m.categories {
+		if c.ID == id {
+			m.categories = append(m.categories[:i], m.categories[i+1:]...)
+			return nil
+		}
+	}
+	return domainErrors.ErrCategoryNotFound
+}
+
+func (m *MockCategoryRepository) CountItems(ctx context.Context, code string) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.itemCounts[code], nil
 }
 
 func TestNewItemUsecase(t *testing.T) {
 	mockRepo := new(MockItemRepository)
-	usecase := NewItemUsecase(mockRepo)
+	categoryRepo := newMockCategoryRepository()
+	usecase := NewItemUsecase(mockRepo, new(MockItemRevisionRepository), categoryRepo, NewCategoryCache(categoryRepo, time.Hour), newMockTagRepository(), newMockLocationRepository(), newMockItemStatusEventRepository(), newMockDisposalRepository(), new(MockTransactor))
 
 	assert.NotNil(t, usecase)
 }
 
+// testItemDeps は newTestItemUsecase に渡すリポジトリ
+// 指定しなかったものはテスト用の空のモックを使う
+type testItemDeps struct {
+	itemRepo     ItemRepository
+	revisionRepo ItemRevisionRepository
+	categoryRepo CategoryRepository
+	tagRepo      TagRepository
+	locationRepo LocationRepository
+	statusRepo   ItemStatusEventRepository
+	disposalRepo DisposalRepository
+	transactor   Transactor
+}
+
+// newTestItemUsecase はテストで必要なリポジトリだけを指定して ItemUsecase を作成する
+// NewItemUsecase に依存が増えても、各機能のテストを書き換えずに済むようにする
+func newTestItemUsecase(t *testing.T, deps testItemDeps) ItemUsecase {
+	t.Helper()
+
+	if deps.itemRepo == nil {
+		deps.itemRepo = new(MockItemRepository)
+	}
+	if deps.revisionRepo == nil {
+		deps.revisionRepo = new(MockItemRevisionRepository)
+	}
+	if deps.categoryRepo == nil {
+		deps.categoryRepo = newMockCategoryRepository()
+	}
+	if deps.tagRepo == nil {
+		deps.tagRepo = newMockTagRepository()
+	}
+	if deps.locationRepo == nil {
+		deps.locationRepo = newMockLocationRepository()
+	}
+	if deps.statusRepo == nil {
+		deps.statusRepo = newMockItemStatusEventRepository()
+	}
+	if deps.disposalRepo == nil {
+		deps.disposalRepo = newMockDisposalRepository()
+	}
+	if deps.transactor == nil {
+		deps.transactor = new(MockTransactor)
+	}
+
+	return NewItemUsecase(deps.itemRepo, deps.revisionRepo, deps.categoryRepo, NewCategoryCache(deps.categoryRepo, time.Hour), deps.tagRepo, deps.locationRepo, deps.statusRepo, deps.disposalRepo, deps.transactor)
+}
+
 func TestItemUsecase_GetAllItems(t *testing.T) {
+	// 絞り込み条件なし・id の降順で作成したカーソルの条件のハッシュ
+	idDescFilter := (&ItemCriteria{Sort: []SortField{{Field: "id", Desc: true}}}).fingerprint()
+
 	tests := []struct {
 		name          string
+		criteria      ItemCriteria
 		setupMock     func(*MockItemRepository)
 		expectedCount int
-		expectedErr   error
+		// nilの場合は件数を数えないことを期待する
+		expectedTotal  *int
+		expectedLimit  int
+		expectedOffset int
+		// nilの場合は次ページのカーソルがないことを期待する
+		expectedNextCursor *ItemCursor
+		expectedErr        error
 	}{
 		{
-			name: "正常系: 複数のアイテムを取得",
+			name:     "正常系: 複数のアイテムを取得",
+			criteria: ItemCriteria{},
 			setupMock: func(mockRepo *MockItemRepository) {
-				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
-				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02", nil, entity.FixedCategories)
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(items, nil)
+				mockRepo.On("Count", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(2, nil)
 			},
 			expectedCount: 2,
+			expectedTotal: intPtr(2),
+			expectedLimit: DefaultItemLimit,
 			expectedErr:   nil,
 		},
 		{
-			name: "正常系: アイテムが0件",
+			name:     "正常系: 絞り込みとページング条件がリポジトリに渡される",
+			criteria: ItemCriteria{Category: "時計", MinPrice: intPtr(100000), Limit: 1, Offset: 1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計2", "時計", "OMEGA", 300000, "2023-01-03", nil, entity.FixedCategories)
+				expected := ItemCriteria{
+					Category: "時計",
+					MinPrice: intPtr(100000),
+					Limit:    1,
+					Offset:   1,
+					Sort:     DefaultItemSort(),
+				}
+				mockRepo.On("FindAll", mock.Anything, expected).Return([]*entity.Item{item}, nil)
+				mockRepo.On("Count", mock.Anything, expected).Return(3, nil)
+			},
+			expectedCount:  1,
+			expectedTotal:  intPtr(3),
+			expectedLimit:  1,
+			expectedOffset: 1,
+			expectedErr:    nil,
+		},
+		{
+			name:     "正常系: アイテムが0件",
+			criteria: ItemCriteria{},
 			setupMock: func(mockRepo *MockItemRepository) {
-				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(([]*entity.Item)(nil), nil)
+				mockRepo.On("Count", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(0, nil)
 			},
 			expectedCount: 0,
+			expectedTotal: intPtr(0),
+			expectedLimit: DefaultItemLimit,
 			expectedErr:   nil,
 		},
 		{
-			name: "異常系: データベースエラー",
+			name:     "異常系: 不正な検索条件",
+			criteria: ItemCriteria{Limit: MaxItemLimit + 1},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// バリデーションでエラーになるため、リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "正常系: カーソル指定時はキーセット方式で1件多く取得し、件数は数えない",
+			criteria: ItemCriteria{
+				Limit: 2,
+				After: &ItemCursor{Sort: SortField{Field: "id", Desc: true}, Value: "10", ID: 10, Filter: idDescFilter},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				var items []*entity.Item
+				for _, id := range []int64{9, 8, 7} {
+					item, _ := entity.NewItem("時計", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+					item.ID = id
+					items = append(items, item)
+				}
+				mockRepo.On("FindPage", mock.Anything, mock.MatchedBy(func(c ItemCriteria) bool {
+					return c.Limit == 3
+				}), mock.AnythingOfType("*usecase.ItemCursor")).Return(items, nil)
+			},
+			expectedCount:      2,
+			expectedLimit:      2,
+			expectedNextCursor: &ItemCursor{Sort: SortField{Field: "id", Desc: true}, Value: "8", ID: 8, Filter: idDescFilter},
+		},
+		{
+			name: "異常系: カーソルと異なる絞り込み条件",
+			criteria: ItemCriteria{
+				Category: "時計",
+				After:    &ItemCursor{Sort: SortField{Field: "id", Desc: true}, Value: "10", ID: 10, Filter: idDescFilter},
+			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				// バリデーションでエラーになるため、リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: カーソルとoffsetの併用",
+			criteria: ItemCriteria{Offset: 20, After: &ItemCursor{Sort: SortField{Field: "id"}, Value: "10", ID: 10}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// バリデーションでエラーになるため、リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: カーソルと異なる並び順",
+			criteria: ItemCriteria{Sort: []SortField{{Field: "name"}}, After: &ItemCursor{Sort: SortField{Field: "id"}, Value: "10", ID: 10}},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// バリデーションでエラーになるため、リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: データベースエラー",
+			criteria: ItemCriteria{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Count", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(0, nil)
+				mockRepo.On("FindAll", mock.Anything, mock.AnythingOfType("ItemCriteria")).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectedCount: 0,
 			expectedErr:   domainErrors.ErrDatabaseError,
@@ -118,10 +521,10 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
 
 			ctx := context.Background()
-			items, err := usecase.GetAllItems(ctx)
+			list, err := usecase.GetAllItems(ctx, tt.criteria)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -130,8 +533,15 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.Len(t, items, tt.expectedCount)
+			require.NoError(t, err)
+			require.NotNil(t, list.Items)
+			assert.Len(t, list.Items, tt.expectedCount)
+			assert.Equal(t, tt.expectedTotal, list.Total)
+			assert.Equal(t, tt.expectedLimit, list.Limit)
+			assert.Equal(t, tt.expectedOffset, list.Offset)
+			if tt.expectedNextCursor != nil {
+				assert.Equal(t, tt.expectedNextCursor, list.NextCursor)
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -145,6 +555,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 		name      string
 		id        int64
 		input     UpdateItemInput
+		version   *int // If-Match で指定されたバージョン
 		setupMock func(*MockItemRepository)
 		wantErr   bool
 		wantItem  bool
@@ -159,12 +570,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				// BrandとPurchasePriceはnilのまま（更新対象外）
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				// 更新後のアイテムを作成
-				updatedItem, _ := entity.NewItem("更新された時計", "時計", "ROLEX", 1000000, "2023-01-01")
-				updatedItem.ID = 1
-				// モックに期待する呼び出しを設定
-				// Update(ctx, id=1, name="更新された時計", brand=nil, price=nil) が呼ばれることを期待
-				mockRepo.On("Update", mock.Anything, int64(1), stringPtr("更新された時計"), (*string)(nil), (*int)(nil)).Return(updatedItem, nil)
+				// 更新前のアイテムを取得できるように設定
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				// 名前だけが変わり、他のフィールドは元の値のまま保存されることを期待
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Name == "更新された時計" && item.Brand == "ROLEX" && item.PurchasePrice == 1000000 && item.Version == 3
+				})).Return(storedItem(), nil)
 			},
 			wantErr:  false, // エラーは期待しない
 			wantItem: true,  // アイテムが返されることを期待
@@ -180,11 +591,11 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: intPtr(2000000),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				// 複数フィールドが更新されたアイテムを作成
-				updatedItem, _ := entity.NewItem("新しい時計", "時計", "OMEGA", 2000000, "2023-01-01")
-				updatedItem.ID = 1
-				// すべてのフィールドが渡されることを期待
-				mockRepo.On("Update", mock.Anything, int64(1), stringPtr("新しい時計"), stringPtr("OMEGA"), intPtr(2000000)).Return(updatedItem, nil)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				// すべてのフィールドが反映されることを期待
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Name == "新しい時計" && item.Brand == "OMEGA" && item.PurchasePrice == 2000000
+				})).Return(storedItem(), nil)
 			},
 			wantErr:  false,
 			wantItem: true,
@@ -212,7 +623,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				// 更新フィールドがないためリポジトリは呼ばれない
 				// モックの設定は不要
 			},
-			wantErr:  true,  // "no fields to update" エラーが発生
+			wantErr:  true, // "no fields to update" エラーが発生
 			wantItem: false,
 		},
 		{
@@ -225,7 +636,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			setupMock: func(mockRepo *MockItemRepository) {
 				// バリデーションでエラーになるため、リポジトリは呼ばれない
 			},
-			wantErr:  true,  // "name cannot be empty" エラーが発生
+			wantErr:  true, // "name cannot be empty" エラーが発生
 			wantItem: false,
 		},
 		{
@@ -238,7 +649,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			setupMock: func(mockRepo *MockItemRepository) {
 				// バリデーションでエラーになるため、リポジトリは呼ばれない
 			},
-			wantErr:  true,  // "purchase_price must be 0 or greater" エラーが発生
+			wantErr:  true, // "purchase_price must be 0 or greater" エラーが発生
 			wantItem: false,
 		},
 		{
@@ -249,10 +660,71 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				Name: stringPtr("更新された時計"),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				// リポジトリのUpdateメソッドがErrItemNotFoundを返すように設定
-				mockRepo.On("Update", mock.Anything, int64(999), stringPtr("更新された時計"), (*string)(nil), (*int)(nil)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+				// リポジトリのFindByIDメソッドがErrItemNotFoundを返すように設定
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			wantErr:  true, // ErrItemNotFound エラーが発生
+			wantItem: false,
+		},
+		{
+			name:    "正常系: 指定したバージョンと一致する場合のみ更新",
+			id:      1,
+			input:   UpdateItemInput{Name: stringPtr("更新された時計")},
+			version: intPtr(3),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return(storedItem(), nil)
+			},
+			wantErr:  false,
+			wantItem: true,
+		},
+		{
+			name:    "異常系: 指定したバージョンが古い",
+			id:      1,
+			input:   UpdateItemInput{Name: stringPtr("更新された時計")},
+			version: intPtr(2),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			wantErr:  true,
+			wantItem: false,
+		},
+		{
+			name:  "異常系: 取得後に他のリクエストで更新された",
+			id:    1,
+			input: UpdateItemInput{Name: stringPtr("更新された時計")},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return((*entity.Item)(nil), domainErrors.ErrVersionConflict)
+			},
+			wantErr:  true,
+			wantItem: false,
+		},
+		{
+			name: "正常系: カテゴリーと購入日を修正",
+			id:   1,
+			input: UpdateItemInput{
+				Category:     stringPtr("ジュエリー"),
+				PurchaseDate: stringPtr("2022-12-24"),
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Category == "ジュエリー" && item.PurchaseDate == "2022-12-24" && item.Name == "時計1"
+				})).Return(storedItem(), nil)
+			},
+			wantErr:  false,
+			wantItem: true,
+		},
+		{
+			name:  "異常系: 無効なカテゴリー",
+			id:    1,
+			input: UpdateItemInput{Category: stringPtr("家具")},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// エンティティのバリデーションでエラーになるため、Updateは呼ばれない
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
 			},
-			wantErr:  true,  // ErrItemNotFound エラーが発生
+			wantErr:  true,
 			wantItem: false,
 		},
 	}
@@ -266,20 +738,20 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			// テストケース固有のモック設定を実行
 			tt.setupMock(mockRepo)
 			// モックを使ってユースケースのインスタンスを作成
-			usecase := NewItemUsecase(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
 
 			// テスト対象の関数を実行
 			ctx := context.Background()
-			item, err := usecase.UpdateItem(ctx, tt.id, tt.input)
+			item, err := usecase.UpdateItem(ctx, tt.id, tt.input, tt.version)
 
 			// 期待される結果と実際の結果を比較
 			if tt.wantErr {
 				// エラーが期待される場合
-				assert.Error(t, err)     // エラーが発生していることを確認
-				assert.Nil(t, item)      // アイテムはnilであることを確認
+				assert.Error(t, err) // エラーが発生していることを確認
+				assert.Nil(t, item)  // アイテムはnilであることを確認
 			} else {
 				// 正常終了が期待される場合
-				assert.NoError(t, err)   // エラーが発生していないことを確認
+				assert.NoError(t, err) // エラーが発生していないことを確認
 				if tt.wantItem {
 					assert.NotNil(t, item) // アイテムが返されていることを確認
 				}
@@ -291,6 +763,118 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 	}
 }
 
+// storedItem はデータベースに保存済みのアイテム（ID: 1, バージョン: 3）を作成する
+func storedItem() *entity.Item {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+	item.ID = 1
+	item.Version = 3
+	return item
+}
+
+func TestItemUsecase_ReplaceItem(t *testing.T) {
+	validInput := ReplaceItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+	}
+
+	tests := []struct {
+		name        string
+		id          int64
+		input       ReplaceItemInput
+		version     *int
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 全フィールドを置き換え",
+			id:    1,
+			input: validInput,
+			setupMock: func(mockRepo *MockItemRepository) {
+				stored := storedItem()
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(stored, nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.ID == 1 && item.Version == 3 && item.CreatedAt.Equal(stored.CreatedAt) &&
+						item.Name == "ロレックス デイトナ" && item.PurchaseDate == "2023-01-15"
+				})).Return(stored, nil)
+			},
+		},
+		{
+			name:    "正常系: バージョンが一致する",
+			id:      1,
+			input:   validInput,
+			version: intPtr(3),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return(storedItem(), nil)
+			},
+		},
+		{
+			name:        "異常系: 無効なID",
+			id:          0,
+			input:       validInput,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 購入日の形式が不正",
+			id:   1,
+			input: ReplaceItemInput{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023/01/15",
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: アイテムが見つからない",
+			id:    999,
+			input: validInput,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:    "異常系: バージョンが一致しない",
+			id:      1,
+			input:   validInput,
+			version: intPtr(1),
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			expectedErr: domainErrors.ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			item, err := usecase.ReplaceItem(context.Background(), tt.id, tt.input, tt.version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, item)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // ヘルパー関数群
 // Go言語では値からポインタを直接作ることができないため、これらの関数を使用
 
@@ -304,6 +888,11 @@ func intPtr(i int) *int {
 	return &i // &演算子でiのアドレス（ポインタ）を取得
 }
 
+// int64Ptr は整数値からint64型のポインタを作成する
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func TestItemUsecase_GetItemByID(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -316,7 +905,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 			name: "正常系: 存在するアイテムを取得",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
 			},
@@ -354,7 +943,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
 
 			ctx := context.Background()
 			item, err := usecase.GetItemByID(ctx, tt.id)
@@ -394,7 +983,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15", nil, entity.FixedCategories)
 				createdItem.ID = 1
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(createdItem, nil)
 			},
@@ -450,7 +1039,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
 
 			ctx := context.Background()
 			item, err := usecase.CreateItem(ctx, tt.input)
@@ -480,6 +1069,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 	tests := []struct {
 		name        string
 		id          int64
+		version     *int
 		setupMock   func(*MockItemRepository)
 		expectError bool
 		expectedErr error
@@ -488,10 +1078,10 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			name: "正常系: 存在するアイテムを削除",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), (*int)(nil)).Return(nil)
 			},
 			expectError: false,
 		},
@@ -525,12 +1115,52 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			name: "異常系: Deleteでデータベースエラー",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), (*int)(nil)).Return(domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+		{
+			name:    "正常系: バージョンが一致する場合に削除",
+			id:      1,
+			version: intPtr(2),
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+				item.ID = 1
+				item.Version = 2
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1), intPtr(2)).Return(nil)
+			},
+			expectError: false,
+		},
+		{
+			name:    "異常系: バージョンが一致しない",
+			id:      1,
+			version: intPtr(1),
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
+				item.ID = 1
+				item.Version = 2
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrVersionConflict,
+		},
+		{
+			name:    "異常系: 存在確認後に他のリクエストで更新された",
+			id:      1,
+			version: intPtr(2),
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01", nil, entity.FixedCategories)
 				item.ID = 1
+				item.Version = 2
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+				mockRepo.On("Delete", mock.Anything, int64(1), intPtr(2)).Return(domainErrors.ErrVersionConflict)
 			},
 			expectError: true,
+			expectedErr: domainErrors.ErrVersionConflict,
 		},
 	}
 
@@ -538,10 +1168,11 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			transactor := new(MockTransactor)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, transactor: transactor})
 
 			ctx := context.Background()
-			err := usecase.DeleteItem(ctx, tt.id)
+			err := usecase.DeleteItem(ctx, tt.id, tt.version)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -552,6 +1183,12 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
+			// 存在確認と削除は1つのトランザクションで行われ、失敗時はロールバックされる
+			if tt.id > 0 {
+				assert.Equal(t, 1, transactor.calls)
+				assert.Equal(t, tt.expectError, transactor.err != nil)
+			}
+
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -569,11 +1206,11 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: 複数カテゴリーのアイテムがある場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{
-					"時計":  2,
-					"バッグ": 1,
+				summary := []CategoryTotal{
+					{Category: "時計", Count: 2, Value: 3000000},
+					{Category: "バッグ", Count: 1, Value: 2000000},
 				}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("SumByCategory", mock.Anything, mock.Anything).Return(summary, nil)
 			},
 			expectedTotal:      3,
 			expectedWatchCount: 2,
@@ -583,8 +1220,8 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: アイテムが0件の場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				summary := []CategoryTotal{}
+				mockRepo.On("SumByCategory", mock.Anything, mock.Anything).Return(summary, nil)
 			},
 			expectedTotal:      0,
 			expectedWatchCount: 0,
@@ -594,7 +1231,7 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]int)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("SumByCategory", mock.Anything, mock.Anything).Return(([]CategoryTotal)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -604,10 +1241,10 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
 
 			ctx := context.Background()
-			summary, err := usecase.GetCategorySummary(ctx)
+			summary, err := usecase.GetCategorySummary(ctx, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -619,17 +1256,116 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, summary)
 
+			counts := make(map[string]int)
+			for _, node := range summary.Categories {
+				counts[node.Code] = node.Count
+			}
+
 			assert.Equal(t, tt.expectedTotal, summary.Total)
-			assert.Equal(t, tt.expectedWatchCount, summary.Categories["時計"])
-			assert.Equal(t, tt.expectedBagCount, summary.Categories["バッグ"])
+			assert.Equal(t, tt.expectedWatchCount, counts["時計"])
+			assert.Equal(t, tt.expectedBagCount, counts["バッグ"])
 
 			// すべてのカテゴリーがレスポンスに含まれているかチェック
 			expectedCategories := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 			for _, category := range expectedCategories {
-				assert.Contains(t, summary.Categories, category)
+				assert.Contains(t, counts, category)
 			}
 
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+// バリデーションエラーがフィールド単位の情報を保ったまま返されることを確認する
+func TestItemUsecase_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(usecase ItemUsecase) error
+		setupMock func(*MockItemRepository)
+		want      []domainErrors.ValidationError
+	}{
+		{
+			name: "異常系: 登録時のエンティティのバリデーション",
+			run: func(usecase ItemUsecase) error {
+				_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+					Name:          "時計",
+					Category:      "家具",
+					PurchasePrice: -1,
+					PurchaseDate:  "2023/01/01",
+				})
+				return err
+			},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			want: []domainErrors.ValidationError{
+				{Field: "category", Code: domainErrors.CodeInvalidCategory},
+				{Field: "brand", Code: domainErrors.CodeRequired},
+				{Field: "purchase_price", Code: domainErrors.CodeTooSmall},
+				{Field: "purchase_date", Code: domainErrors.CodeInvalidFormat},
+			},
+		},
+		{
+			name: "異常系: 部分更新の入力のバリデーション",
+			run: func(usecase ItemUsecase) error {
+				_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{
+					Name:  stringPtr(strings.Repeat("a", 101)),
+					Brand: stringPtr(""),
+				}, nil)
+				return err
+			},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			want: []domainErrors.ValidationError{
+				{Field: "name", Code: domainErrors.CodeTooLong},
+				{Field: "brand", Code: domainErrors.CodeRequired},
+			},
+		},
+		{
+			name: "異常系: 部分更新で空白のみの名前、101文字のブランド",
+			run: func(usecase ItemUsecase) error {
+				_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{
+					Name:  stringPtr("　 "),
+					Brand: stringPtr(strings.Repeat("ロ", 101)),
+				}, nil)
+				return err
+			},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			want: []domainErrors.ValidationError{
+				{Field: "name", Code: domainErrors.CodeRequired},
+				{Field: "brand", Code: domainErrors.CodeTooLong},
+			},
+		},
+		{
+			name: "異常系: 部分更新後のエンティティのバリデーション",
+			run: func(usecase ItemUsecase) error {
+				_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{Category: stringPtr("家具")}, nil)
+				return err
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(storedItem(), nil)
+			},
+			want: []domainErrors.ValidationError{
+				{Field: "category", Code: domainErrors.CodeInvalidCategory},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			err := tt.run(usecase)
+
+			assert.True(t, domainErrors.IsValidationError(err))
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			require.Len(t, errs, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Field, errs[i].Field)
+				assert.Equal(t, want.Code, errs[i].Code)
+				assert.NotEmpty(t, errs[i].Message)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
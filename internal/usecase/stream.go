@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"iter"
+
+	"aicon-coding-test/internal/domain/entity"
+)
+
+// StreamItems は一覧と同じ絞り込み・並び替え条件に一致するすべてのアイテムを1件ずつ返すイテレーターを返す
+// ページング（limit / offset / cursor）とファセットの指定は無視する
+// 条件が不正な場合は、読み取りを始める前にエラーを返す
+func (u *itemUsecase) StreamItems(ctx context.Context, criteria ItemCriteria) (iter.Seq2[*entity.Item, error], error) {
+	criteria.Limit = 0
+	criteria.Offset = 0
+	criteria.After = nil
+	criteria.Facets = nil
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := u.expandCategoryFilter(ctx, &criteria); err != nil {
+		return nil, err
+	}
+	if err := u.expandLocationFilter(ctx, &criteria); err != nil {
+		return nil, err
+	}
+
+	return u.itemRepo.Iterate(ctx, criteria), nil
+}
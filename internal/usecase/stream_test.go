@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestItemUsecase_StreamItems(t *testing.T) {
+	items := []*entity.Item{{ID: 1, Name: "時計1"}, {ID: 2, Name: "時計2"}, {ID: 3, Name: "時計3"}}
+
+	tests := []struct {
+		name      string
+		criteria  ItemCriteria
+		setupMock func(*MockItemRepository)
+		stopAfter int // 0 の場合は最後まで読む
+		cancelAt  int // 0 の場合はキャンセルしない
+		wantIDs   []int64
+		wantErr   error
+	}{
+		{
+			name: "正常系: 絞り込み条件を引き継ぎ、ページングの条件は無視する",
+			criteria: ItemCriteria{
+				Category: "時計",
+				Limit:    500,
+				Offset:   10,
+				Facets:   &FacetOptions{},
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Iterate", mock.Anything, mock.MatchedBy(func(c ItemCriteria) bool {
+					return c.Category == "時計" && c.Offset == 0 && c.Facets == nil && len(c.Sort) > 0
+				})).Return(items, nil)
+			},
+			wantIDs: []int64{1, 2, 3},
+		},
+		{
+			name: "正常系: 呼び出し側がループを抜けたらそれ以降のアイテムを渡さない",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Iterate", mock.Anything, mock.Anything).Return(items, nil)
+			},
+			stopAfter: 2,
+			wantIDs:   []int64{1, 2},
+		},
+		{
+			name: "異常系: 読み取り中に context がキャンセルされたらキャンセルのエラーを渡す",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Iterate", mock.Anything, mock.Anything).Return(items, nil)
+			},
+			cancelAt: 1,
+			wantIDs:  []int64{1},
+			wantErr:  context.Canceled,
+		},
+		{
+			name:      "異常系: 絞り込み条件が不正な場合は読み取りを始めない",
+			criteria:  ItemCriteria{PurchasedFrom: "2023/01/01"},
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: 途中でデータベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Iterate", mock.Anything, mock.Anything).Return(items[:1], domainErrors.ErrDatabaseError)
+			},
+			wantIDs: []int64{1},
+			wantErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var ids []int64
+			seq, err := usecase.StreamItems(ctx, tt.criteria)
+			if err == nil {
+				for item, iterErr := range seq {
+					if iterErr != nil {
+						err = iterErr
+						break
+					}
+					ids = append(ids, item.ID)
+					if len(ids) == tt.cancelAt {
+						cancel()
+					}
+					if len(ids) == tt.stopAfter {
+						break
+					}
+				}
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// TagUsecase はタグの一覧（タグクラウド）と、名前の変更・統合を行う
+// アイテムへのタグの付け外しは ItemUsecase.SetItemTags で行う
+type TagUsecase interface {
+	// GetTags はすべてのタグを、付いているアイテムの件数の多い順に返す
+	GetTags(ctx context.Context) ([]TagCount, error)
+	// RenameTag はタグの名前を変更する（同じ名前のタグがある場合は ErrDuplicateEntry。統合には MergeTags を使う）
+	RenameTag(ctx context.Context, id int64, input RenameTagInput) (*entity.Tag, error)
+	// MergeTags は source のタグを target のタグに統合し、統合後の target を返す
+	MergeTags(ctx context.Context, sourceID int64, input MergeTagsInput) (*TagCount, error)
+}
+
+// TagCount はタグと、タグが付いているアイテム（ゴミ箱を除く）の件数
+type TagCount struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RenameTagInput はタグの名前の変更の入力
+type RenameTagInput struct {
+	Name string `json:"name"`
+}
+
+// MergeTagsInput はタグの統合の入力（統合先のタグのID）
+type MergeTagsInput struct {
+	Into int64 `json:"into"`
+}
+
+type tagUsecase struct {
+	tagRepo    TagRepository
+	transactor Transactor
+}
+
+func NewTagUsecase(tagRepo TagRepository, transactor Transactor) TagUsecase {
+	return &tagUsecase{
+		tagRepo:    tagRepo,
+		transactor: transactor,
+	}
+}
+
+func (u *tagUsecase) GetTags(ctx context.Context) ([]TagCount, error) {
+	tags, err := u.tagRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+	if tags == nil {
+		tags = []TagCount{}
+	}
+	return tags, nil
+}
+
+func (u *tagUsecase) RenameTag(ctx context.Context, id int64, input RenameTagInput) (*entity.Tag, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var renamed *entity.Tag
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		tag, err := u.tagRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tag.Rename(input.Name); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+
+		renamed, err = u.tagRepo.Update(ctx, tag)
+		return err
+	})
+	if err != nil {
+		return nil, tagWriteError("rename", err)
+	}
+
+	return renamed, nil
+}
+
+func (u *tagUsecase) MergeTags(ctx context.Context, sourceID int64, input MergeTagsInput) (*TagCount, error) {
+	if sourceID <= 0 || input.Into <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if sourceID == input.Into {
+		return nil, domainErrors.NewInputError(domainErrors.CodeMergeIntoSelf, "cannot merge a tag into itself", nil)
+	}
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := u.tagRepo.FindByID(ctx, sourceID); err != nil {
+			return err
+		}
+		if _, err := u.tagRepo.FindByID(ctx, input.Into); err != nil {
+			return err
+		}
+		return u.tagRepo.Merge(ctx, sourceID, input.Into)
+	})
+	if err != nil {
+		return nil, tagWriteError("merge", err)
+	}
+
+	// 統合後の件数を返す
+	tags, err := u.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.ID == input.Into {
+			return &tag, nil
+		}
+	}
+	return nil, domainErrors.ErrTagNotFound
+}
+
+// tagWriteError は変更・統合のエラーのうち、呼び出し側で判別するものはそのまま返す
+func tagWriteError(op string, err error) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return domainErrors.ErrTagNotFound
+	case domainErrors.IsValidationError(err), errors.Is(err, domainErrors.ErrDuplicateEntry):
+		return err
+	default:
+		return fmt.Errorf("failed to %s tag: %w", op, err)
+	}
+}
@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// MockTagRepository はタグとアイテムへの付与をメモリに保持するモック
+// err を設定するとすべてのメソッドがそのエラーを返す
+type MockTagRepository struct {
+	tags     []*entity.Tag
+	itemTags map[int64][]int64 // アイテムID → タグID
+	touched  []int64           // バージョンを進めたアイテムID
+	err      error
+}
+
+func newMockTagRepository() *MockTagRepository {
+	return &MockTagRepository{itemTags: make(map[int64][]int64)}
+}
+
+// newMockTagRepositoryWith は names のタグ（IDは1から順番）を登録し、itemTags のとおりにアイテムへ付けたモックを返す
+func newMockTagRepositoryWith(names []string, itemTags map[int64][]int64) *MockTagRepository {
+	m := newMockTagRepository()
+	for i, name := range names {
+		m.tags = append(m.tags, &entity.Tag{ID: int64(i + 1), Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	}
+	for itemID, tagIDs := range itemTags {
+		m.itemTags[itemID] = tagIDs
+	}
+	return m
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context) ([]TagCount, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	counts := make(map[int64]int)
+	for _, tagIDs := range m.itemTags {
+		for _, id := range tagIDs {
+			counts[id]++
+		}
+	}
+	tags := make([]TagCount, 0, len(m.tags))
+	for _, t := range m.tags {
+		tags = append(tags, TagCount{ID: t.ID, Name: t.Name, Count: counts[t.ID]})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+func (m *MockTagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, t := range m.tags {
+		if t.ID == id {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrTagNotFound
+}
+
+func (m *MockTagRepository) ReplaceItemTags(ctx context.Context, itemID int64, names []string) error {
+	if m.err != nil {
+		return m.err
+	}
+	tagIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		tagIDs = append(tagIDs, m.findOrCreate(name))
+	}
+	m.itemTags[itemID] = tagIDs
+	return nil
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, t := range m.tags {
+		if t.ID != tag.ID && strings.EqualFold(t.Name, tag.Name) {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+	}
+	for _, t := range m.tags {
+		if t.ID == tag.ID {
+			t.Name = tag.Name
+			m.touch(tag.ID)
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, domainErrors.ErrTagNotFound
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, sourceID, targetID int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.touch(sourceID)
+	for itemID, tagIDs := range m.itemTags {
+		merged := make([]int64, 0, len(tagIDs))
+		hasTarget := false
+		for _, id := range tagIDs {
+			if id == sourceID || id == targetID {
+				if !hasTarget {
+					merged = append(merged, targetID)
+					hasTarget = true
+				}
+				continue
+			}
+			merged = append(merged, id)
+		}
+		m.itemTags[itemID] = merged
+	}
+	for i, t := range m.tags {
+		if t.ID == sourceID {
+			m.tags = append(m.tags[:i], m.tags[i+1:]...)
+			return nil
+		}
+	}
+	return domainErrors.ErrTagNotFound
+}
+
+// tagNames はアイテムに付いているタグの名前を返す
+func (m *MockTagRepository) tagNames(itemID int64) []string {
+	names := []string{}
+	for _, id := range m.itemTags[itemID] {
+		for _, t := range m.tags {
+			if t.ID == id {
+				names = append(names, t.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *MockTagRepository) findOrCreate(name string) int64 {
+	for _, t := range m.tags {
+		if strings.EqualFold(t.Name, name) {
+			return t.ID
+		}
+	}
+	id := int64(len(m.tags) + 1)
+	m.tags = append(m.tags, &entity.Tag{ID: id, Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	return id
+}
+
+func (m *MockTagRepository) touch(tagID int64) {
+	for itemID, tagIDs := range m.itemTags {
+		for _, id := range tagIDs {
+			if id == tagID {
+				m.touched = append(m.touched, itemID)
+			}
+		}
+	}
+	sort.Slice(m.touched, func(i, j int) bool { return m.touched[i] < m.touched[j] })
+}
+
+func TestItemUsecase_SetItemTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "正常系: 正規化・重複除去して名前順に保存する",
+			tags: []string{" 旅行 ", "限定品", "Gift", "gift"},
+			want: []string{"Gift", "旅行", "限定品"},
+		},
+		{
+			name: "正常系: 空の配列ですべてのタグを外す",
+			tags: []string{},
+			want: []string{},
+		},
+		{
+			name:    "異常系: 空のタグ名",
+			tags:    []string{"旅行", " "},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: タグ名が長すぎる",
+			tags:    []string{strings.Repeat("あ", entity.MaxTagNameLength+1)},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tagRepo := newMockTagRepositoryWith([]string{"旅行"}, map[int64][]int64{1: {1}})
+			revisionRepo := new(MockItemRevisionRepository)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo, tagRepo: tagRepo})
+
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(func() *entity.Item {
+				item := storedItem()
+				item.Tags = []string{"旅行"}
+				return item
+			}(), nil)
+			var saved *entity.Item
+			mockRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*entity.Item)
+			}).Return(storedItem(), nil).Maybe()
+
+			_, err := usecase.SetItemTags(context.Background(), 1, tt.tags, nil)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, domainErrors.IsValidationError(err))
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				assert.Equal(t, []string{"旅行"}, tagRepo.tagNames(1))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, saved.Tags)
+			assert.Equal(t, tt.want, tagRepo.tagNames(1))
+			require.Len(t, revisionRepo.revisions, 1)
+			require.NotEmpty(t, revisionRepo.revisions[0].Changes)
+			assert.Equal(t, "tags", revisionRepo.revisions[0].Changes[0].Field)
+		})
+	}
+
+	t.Run("正常系: タグが変わらない場合は item_tags を書き換えない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		tagRepo := newMockTagRepositoryWith([]string{"旅行"}, map[int64][]int64{1: {1}})
+		tagRepo.err = domainErrors.ErrDatabaseError
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, tagRepo: tagRepo})
+
+		item := storedItem()
+		item.Tags = []string{"旅行"}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(item, nil).Maybe()
+
+		_, err := usecase.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: intPtr(1200000)}, nil)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestItemUsecase_CreateItemWithTags(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	tagRepo := newMockTagRepository()
+	usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, tagRepo: tagRepo})
+
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(storedItem(), nil)
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		Tags:          []string{"限定品", "母からの贈り物"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"母からの贈り物", "限定品"}, item.Tags)
+	assert.Equal(t, []string{"母からの贈り物", "限定品"}, tagRepo.tagNames(1))
+}
+
+func TestTagUsecase_GetTags(t *testing.T) {
+	t.Run("正常系: 件数の多い順・名前順", func(t *testing.T) {
+		repo := newMockTagRepositoryWith([]string{"旅行", "限定品", "ギフト"}, map[int64][]int64{1: {1, 2}, 2: {2}})
+		usecase := NewTagUsecase(repo, new(MockTransactor))
+
+		tags, err := usecase.GetTags(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []TagCount{
+			{ID: 2, Name: "限定品", Count: 2},
+			{ID: 1, Name: "旅行", Count: 1},
+			{ID: 3, Name: "ギフト", Count: 0},
+		}, tags)
+	})
+
+	t.Run("正常系: タグがない場合は空の配列", func(t *testing.T) {
+		usecase := NewTagUsecase(newMockTagRepository(), new(MockTransactor))
+
+		tags, err := usecase.GetTags(context.Background())
+
+		require.NoError(t, err)
+		assert.NotNil(t, tags)
+		assert.Empty(t, tags)
+	})
+}
+
+func TestTagUsecase_RenameTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int64
+		input   RenameTagInput
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "正常系: 名前を変更する",
+			id:    1,
+			input: RenameTagInput{Name: " 旅行用 "},
+			want:  "旅行用",
+		},
+		{
+			name:  "正常系: 大文字・小文字のみの変更",
+			id:    3,
+			input: RenameTagInput{Name: "GIFT"},
+			want:  "GIFT",
+		},
+		{
+			name:    "異常系: 同じ名前のタグがある",
+			id:      1,
+			input:   RenameTagInput{Name: "限定品"},
+			wantErr: domainErrors.ErrDuplicateEntry,
+		},
+		{
+			name:    "異常系: 空の名前",
+			id:      1,
+			input:   RenameTagInput{Name: ""},
+			wantErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:    "異常系: 存在しないタグ",
+			id:      99,
+			input:   RenameTagInput{Name: "旅行用"},
+			wantErr: domainErrors.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockTagRepositoryWith([]string{"旅行", "限定品", "gift"}, map[int64][]int64{1: {1}, 2: {1, 2}})
+			usecase := NewTagUsecase(repo, new(MockTransactor))
+
+			tag, err := usecase.RenameTag(context.Background(), tt.id, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tag.Name)
+			if tt.id == 1 {
+				assert.Equal(t, []int64{1, 2}, repo.touched)
+			}
+		})
+	}
+}
+
+func TestTagUsecase_MergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		sourceID int64
+		input    MergeTagsInput
+		want     *TagCount
+		wantErr  error
+	}{
+		{
+			name:     "正常系: 統合先の件数を返す（両方付いていたアイテムは1件として数える）",
+			sourceID: 1,
+			input:    MergeTagsInput{Into: 2},
+			want:     &TagCount{ID: 2, Name: "travel", Count: 3},
+		},
+		{
+			name:     "異常系: 自分自身への統合",
+			sourceID: 1,
+			input:    MergeTagsInput{Into: 1},
+			wantErr:  domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 統合先の指定がない",
+			sourceID: 1,
+			wantErr:  domainErrors.ErrInvalidInput,
+		},
+		{
+			name:     "異常系: 統合先が存在しない",
+			sourceID: 1,
+			input:    MergeTagsInput{Into: 99},
+			wantErr:  domainErrors.ErrTagNotFound,
+		},
+		{
+			name:     "異常系: 統合元が存在しない",
+			sourceID: 99,
+			input:    MergeTagsInput{Into: 2},
+			wantErr:  domainErrors.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockTagRepositoryWith([]string{"旅行", "travel"}, map[int64][]int64{1: {1}, 2: {1, 2}, 3: {2}})
+			usecase := NewTagUsecase(repo, new(MockTransactor))
+
+			tag, err := usecase.MergeTags(context.Background(), tt.sourceID, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, repo.tags, 2)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tag)
+			assert.Len(t, repo.tags, 1)
+			assert.Equal(t, []string{"travel"}, repo.tagNames(2))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/domain/tax"
+)
+
+// TransferIncomeUsecase は売却したアイテムの譲渡所得の計算書（ワークシート）を作成する
+// 課税対象・基準額・控除額などはルール表（tax.Rules）で決まる
+type TransferIncomeUsecase interface {
+	// GetWorksheet は year（1月1日〜12月31日）に売却した記録から計算書を作成する
+	GetWorksheet(ctx context.Context, year int) (*tax.Worksheet, error)
+}
+
+// DisposedItem は売却の記録と売却したアイテムの情報
+type DisposedItem struct {
+	Disposal     *entity.Disposal
+	Name         string
+	Category     string
+	PurchaseDate string // YYYY-MM-DD 形式
+}
+
+type transferIncomeUsecase struct {
+	disposalRepo DisposalRepository
+	categoryRepo CategoryRepository
+	rules        *tax.Rules
+}
+
+func NewTransferIncomeUsecase(disposalRepo DisposalRepository, categoryRepo CategoryRepository, rules *tax.Rules) TransferIncomeUsecase {
+	return &transferIncomeUsecase{
+		disposalRepo: disposalRepo,
+		categoryRepo: categoryRepo,
+		rules:        rules,
+	}
+}
+
+func (u *transferIncomeUsecase) GetWorksheet(ctx context.Context, year int) (*tax.Worksheet, error) {
+	if year < 1 || year > 9999 {
+		return nil, domainErrors.NewInputError(domainErrors.CodeOutOfRange, "year must be between 1 and 9999", map[string]interface{}{"name": "year", "min": 1, "max": 9999})
+	}
+	rule, ok := u.rules.For(year)
+	if !ok {
+		return nil, domainErrors.NewInputError(domainErrors.CodeNoTaxRule, fmt.Sprintf("no tax rule applies to year %d", year), map[string]interface{}{"year": year})
+	}
+
+	items, err := u.disposalRepo.FindByYear(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve disposals: %w", err)
+	}
+
+	// 子カテゴリーのアイテムも親カテゴリーのルールで判定できるよう、最上位からのコードを渡す
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+	tree := entity.NewCategoryTree(categories)
+
+	disposals := make([]tax.Disposal, 0, len(items))
+	for _, item := range items {
+		path := tree.Path(item.Category)
+		if path == nil {
+			path = []string{item.Category}
+		}
+		disposals = append(disposals, tax.Disposal{
+			ItemID:        item.Disposal.ItemID,
+			Name:          item.Name,
+			Category:      item.Category,
+			CategoryPath:  path,
+			PurchaseDate:  item.PurchaseDate,
+			PurchasePrice: item.Disposal.Cost,
+			SoldOn:        item.Disposal.SoldOn,
+			SalePrice:     item.Disposal.SalePrice,
+			Fees:          item.Disposal.Fees,
+		})
+	}
+
+	worksheet, err := rule.Worksheet(year, disposals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worksheet: %w", err)
+	}
+	return worksheet, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+	"aicon-coding-test/internal/domain/tax"
+)
+
+func TestTransferIncomeUsecase_GetWorksheet(t *testing.T) {
+	rules, err := tax.ParseRules([]byte(`{"rules": [{
+		"effective_from": 2000, "taxable_categories": ["時計", "バッグ"], "min_taxable_price": 300000,
+		"long_term_years": 5, "special_deduction": 500000, "long_term_taxable_percent": 50, "estimated_cost_percent": 5
+	}]}`))
+	require.NoError(t, err)
+
+	sold := func(itemID int64, category, purchaseDate string, cost int, soldOn string, salePrice int) *DisposedItem {
+		disposal := &entity.Disposal{ItemID: itemID, SoldOn: soldOn, SalePrice: salePrice}
+		disposal.SetCost(cost)
+		return &DisposedItem{Disposal: disposal, Name: category + "の売却", Category: category, PurchaseDate: purchaseDate}
+	}
+
+	t.Run("正常系: 子カテゴリーのアイテムも親カテゴリーのルールで判定する", func(t *testing.T) {
+		disposalRepo := newMockDisposalRepository()
+		disposalRepo.sold = []*DisposedItem{
+			sold(1, "時計", "2015-01-01", 1000000, "2024-03-01", 2000000),
+			sold(2, "ミニクラッチ", "2023-01-01", 200000, "2024-05-01", 600000),
+			sold(3, "ジュエリー", "2023-01-01", 100000, "2024-06-01", 900000),
+			sold(4, "時計", "2020-01-01", 500000, "2023-06-01", 800000),
+		}
+		usecase := NewTransferIncomeUsecase(disposalRepo, newMockCategoryTree(), rules)
+
+		worksheet, err := usecase.GetWorksheet(context.Background(), 2024)
+
+		require.NoError(t, err)
+		require.Len(t, worksheet.Lines, 3)
+		assert.Equal(t, tax.TermLong, worksheet.Lines[0].Term)
+		assert.True(t, worksheet.Lines[1].Taxable)
+		assert.Equal(t, tax.TermShort, worksheet.Lines[1].Term)
+		assert.False(t, worksheet.Lines[2].Taxable)
+		assert.Equal(t, tax.ExemptCategory, worksheet.Lines[2].ExemptReason)
+
+		assert.Equal(t, 400000, worksheet.ShortTerm.NetGain)
+		assert.Equal(t, 400000, worksheet.ShortTerm.Deduction)
+		assert.Equal(t, 100000, worksheet.LongTerm.Deduction)
+		assert.Equal(t, 900000, worksheet.LongTerm.Income)
+		assert.Equal(t, 450000, worksheet.TaxableIncome)
+	})
+
+	t.Run("正常系: 売却がない年", func(t *testing.T) {
+		usecase := NewTransferIncomeUsecase(newMockDisposalRepository(), newMockCategoryTree(), rules)
+
+		worksheet, err := usecase.GetWorksheet(context.Background(), 2024)
+
+		require.NoError(t, err)
+		assert.Empty(t, worksheet.Lines)
+		assert.Equal(t, 0, worksheet.TaxableIncome)
+	})
+
+	errorTests := []struct {
+		name string
+		year int
+	}{
+		{name: "異常系: 年が範囲外", year: 0},
+		{name: "異常系: 年が上限の9999を超える", year: 10000},
+		{name: "異常系: ルールが適用されない年", year: 1999},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTransferIncomeUsecase(newMockDisposalRepository(), newMockCategoryTree(), rules)
+
+			_, err := usecase.GetWorksheet(context.Background(), tt.year)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		})
+	}
+}
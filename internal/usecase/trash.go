@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+// GetTrashedItems はゴミ箱にあるアイテムを削除日時の新しい順に返す
+func (u *itemUsecase) GetTrashedItems(ctx context.Context, limit, offset int) (*ItemList, error) {
+	criteria := ItemCriteria{Limit: limit, Offset: offset}
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+
+	total, err := u.itemRepo.CountTrashed(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count trashed items: %w", err)
+	}
+
+	items, err := u.itemRepo.FindTrashed(ctx, criteria.Limit, criteria.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve trashed items: %w", err)
+	}
+	if items == nil {
+		items = []*entity.Item{}
+	}
+
+	return &ItemList{
+		Items:  items,
+		Total:  &total,
+		Limit:  criteria.Limit,
+		Offset: criteria.Offset,
+	}, nil
+}
+
+// RestoreItem はゴミ箱にあるアイテムを元に戻す
+func (u *itemUsecase) RestoreItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var item *entity.Item
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 復元前（削除日時が設定された状態）との差分を記録する
+		trashed, err := u.itemRepo.FindTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		item, err = u.itemRepo.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+		return u.recordRevision(ctx, entity.RevisionRestore, trashed, item)
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to restore item: %w", err)
+	}
+
+	return item, nil
+}
+
+// PurgeItem はゴミ箱にあるアイテムを完全に削除する
+// 削除していないアイテムをいきなり消すことはできない（先に DeleteItem でゴミ箱に移動する）
+// 売却の記録があるアイテムは譲渡所得の計算に必要なため削除できない（ErrItemSold）
+func (u *itemUsecase) PurgeItem(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		trashed, err := u.itemRepo.FindTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return u.purge(ctx, trashed)
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		if errors.Is(err, domainErrors.ErrItemSold) {
+			return domainErrors.ErrItemSold
+		}
+		return fmt.Errorf("failed to purge item: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredTrash はゴミ箱に移動してから retention 以上経過したアイテムを完全に削除し、件数を返す
+// 1件ずつ削除と変更履歴（purge）の記録をまとめて行う。途中で復元・売却されたアイテムは数えない
+func (u *itemUsecase) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, fmt.Errorf("%w: retention must be positive", domainErrors.ErrInvalidInput)
+	}
+
+	expired, err := u.itemRepo.FindTrashedBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve expired trash: %w", err)
+	}
+
+	var purged int64
+	for _, item := range expired {
+		err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return u.purge(ctx, item)
+		})
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) || errors.Is(err, domainErrors.ErrItemSold) {
+				continue
+			}
+			return purged, fmt.Errorf("failed to purge expired trash: %w", err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purge はゴミ箱にあるアイテムを完全に削除し、削除時点のアイテムを変更履歴（purge）に記録する
+// 変更履歴はアイテムを削除した後も残る。書き込みと同じトランザクション内で呼び出すこと
+func (u *itemUsecase) purge(ctx context.Context, trashed *entity.Item) error {
+	_, err := u.disposalRepo.FindByItemID(ctx, trashed.ID)
+	if err == nil {
+		return fmt.Errorf("%w: item %d has a sale record", domainErrors.ErrItemSold, trashed.ID)
+	}
+	if !errors.Is(err, domainErrors.ErrDisposalNotFound) {
+		return err
+	}
+
+	if err := u.itemRepo.Purge(ctx, trashed.ID); err != nil {
+		return err
+	}
+	return u.recordRevision(ctx, entity.RevisionPurge, trashed, trashed)
+}
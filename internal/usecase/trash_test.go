@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"aicon-coding-test/internal/domain/entity"
+	domainErrors "aicon-coding-test/internal/domain/errors"
+)
+
+func TestItemUsecase_GetTrashedItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		offset     int
+		setupMock  func(*MockItemRepository)
+		wantLimit  int
+		wantTotal  int
+		wantLength int
+		wantErr    error
+	}{
+		{
+			name: "正常系: デフォルトの件数で取得",
+			setupMock: func(mockRepo *MockItemRepository) {
+				deletedAt := time.Now()
+				trashed := &entity.Item{ID: 1, Name: "時計1", DeletedAt: &deletedAt}
+				mockRepo.On("CountTrashed", mock.Anything).Return(1, nil)
+				mockRepo.On("FindTrashed", mock.Anything, DefaultItemLimit, 0).Return([]*entity.Item{trashed}, nil)
+			},
+			wantLimit:  DefaultItemLimit,
+			wantTotal:  1,
+			wantLength: 1,
+		},
+		{
+			name:   "正常系: ゴミ箱が空",
+			limit:  10,
+			offset: 20,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountTrashed", mock.Anything).Return(0, nil)
+				mockRepo.On("FindTrashed", mock.Anything, 10, 20).Return(([]*entity.Item)(nil), nil)
+			},
+			wantLimit:  10,
+			wantTotal:  0,
+			wantLength: 0,
+		},
+		{
+			name:      "異常系: limit が上限を超える",
+			limit:     MaxItemLimit + 1,
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountTrashed", mock.Anything).Return(0, domainErrors.ErrDatabaseError)
+			},
+			wantErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			list, err := usecase.GetTrashedItems(context.Background(), tt.limit, tt.offset)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, list)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantLimit, list.Limit)
+				assert.Equal(t, &tt.wantTotal, list.Total)
+				assert.NotNil(t, list.Items)
+				assert.Len(t, list.Items, tt.wantLength)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RestoreItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        int64
+		setupMock func(*MockItemRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系: ゴミ箱のアイテムを復元",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				deletedAt := time.Now()
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1", DeletedAt: &deletedAt}, nil)
+				mockRepo.On("Restore", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1"}, nil)
+			},
+		},
+		{
+			name: "異常系: ゴミ箱にない",
+			id:   999,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			wantErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:      "異常系: 無効なID",
+			id:        0,
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+			item, err := usecase.RestoreItem(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, item)
+			} else {
+				assert.NoError(t, err)
+				assert.Nil(t, item.DeletedAt)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_PurgeItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		setupMock     func(*MockItemRepository)
+		wantErr       error
+		wantRevisions []entity.RevisionAction
+	}{
+		{
+			name: "正常系: ゴミ箱のアイテムを完全に削除し、purge として記録する",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1"}, nil)
+				mockRepo.On("Purge", mock.Anything, int64(1)).Return(nil)
+			},
+			wantRevisions: []entity.RevisionAction{entity.RevisionPurge},
+		},
+		{
+			name: "異常系: ゴミ箱にない（削除していないアイテムを含む）",
+			id:   2,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindTrashedByID", mock.Anything, int64(2)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			wantErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:      "異常系: 無効なID",
+			id:        -1,
+			setupMock: func(mockRepo *MockItemRepository) {},
+			wantErr:   domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			revisionRepo := new(MockItemRevisionRepository)
+			usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo})
+
+			err := usecase.PurgeItem(context.Background(), tt.id)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var actions []entity.RevisionAction
+			for _, r := range revisionRepo.revisions {
+				actions = append(actions, r.Action)
+			}
+			assert.Equal(t, tt.wantRevisions, actions)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 売却の記録があるアイテムは削除しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1", Status: entity.StatusSold}, nil)
+		disposalRepo := newMockDisposalRepository()
+		disposalRepo.disposals = []*entity.Disposal{{ID: 1, ItemID: 1}}
+		revisionRepo := new(MockItemRevisionRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo, disposalRepo: disposalRepo})
+
+		err := usecase.PurgeItem(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemSold)
+		mockRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+		assert.Empty(t, revisionRepo.revisions)
+	})
+}
+
+func TestItemUsecase_PurgeExpiredTrash(t *testing.T) {
+	t.Run("正常系: 保存期間より前に削除されたアイテムを完全に削除し、purge として記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		retention := 30 * 24 * time.Hour
+		now := time.Now()
+		mockRepo.On("FindTrashedBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			cutoff := now.Add(-retention)
+			return !before.Before(cutoff) && before.Before(cutoff.Add(time.Minute))
+		})).Return([]*entity.Item{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+		mockRepo.On("Purge", mock.Anything, int64(1)).Return(nil)
+		// 一覧を取得した後に復元されたアイテムは数えない
+		mockRepo.On("Purge", mock.Anything, int64(2)).Return(domainErrors.ErrItemNotFound)
+		mockRepo.On("Purge", mock.Anything, int64(3)).Return(nil)
+		revisionRepo := new(MockItemRevisionRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, revisionRepo: revisionRepo})
+
+		purged, err := usecase.PurgeExpiredTrash(WithActor(context.Background(), "trash-sweeper"), retention)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		require.Len(t, revisionRepo.revisions, 2)
+		for i, id := range []int64{1, 3} {
+			assert.Equal(t, id, revisionRepo.revisions[i].ItemID)
+			assert.Equal(t, entity.RevisionPurge, revisionRepo.revisions[i].Action)
+			assert.Equal(t, "trash-sweeper", revisionRepo.revisions[i].Actor)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一覧を取得した後に売却されたアイテムは削除せず数えない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTrashedBefore", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+		mockRepo.On("Purge", mock.Anything, int64(2)).Return(nil)
+		disposalRepo := newMockDisposalRepository()
+		disposalRepo.disposals = []*entity.Disposal{{ID: 1, ItemID: 1}}
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo, disposalRepo: disposalRepo})
+
+		purged, err := usecase.PurgeExpiredTrash(context.Background(), time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		mockRepo.AssertNotCalled(t, "Purge", mock.Anything, int64(1))
+	})
+
+	t.Run("異常系: 削除に失敗した場合はそこで止める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTrashedBefore", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+		mockRepo.On("Purge", mock.Anything, int64(1)).Return(nil)
+		mockRepo.On("Purge", mock.Anything, int64(2)).Return(domainErrors.ErrDatabaseError)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+		purged, err := usecase.PurgeExpiredTrash(context.Background(), time.Hour)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, int64(1), purged)
+	})
+
+	t.Run("異常系: 保存期間が0以下", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := newTestItemUsecase(t, testItemDeps{itemRepo: mockRepo})
+
+		_, err := usecase.PurgeExpiredTrash(context.Background(), 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindTrashedBefore", mock.Anything, mock.Anything)
+	})
+}